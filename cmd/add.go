@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+var (
+	addDate    string
+	addTicket  string
+	addDesc    string
+	addStatus  string
+	addPR      string
+	addUpnext  string
+	addBlocker string
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Append a task to the worklog file.",
+	Long:  `Appends a task to today's entry (or --date) in the worklog file, creating the date entry if needed. The status defaults to "in progress" and must be one of: completed, in progress, not started.`,
+	Run:   runAddCommand,
+}
+
+func init() {
+	addCmd.Flags().StringVar(&addDate, "date", "", "Date to add the task to (YYYY-MM-DD, defaults to today).")
+	addCmd.Flags().StringVar(&addTicket, "ticket", "", "JIRA ticket key or URL.")
+	addCmd.Flags().StringVar(&addDesc, "desc", "", "Description of the work done.")
+	addCmd.Flags().StringVar(&addStatus, "status", model.StatusInProgress, "Task status ("+statusComment+").")
+	addCmd.Flags().StringVar(&addPR, "pr", "", "GitHub PR URL.")
+	addCmd.Flags().StringVar(&addUpnext, "upnext", "", "What you plan to work on next for this task.")
+	addCmd.Flags().StringVar(&addBlocker, "blocker", "", "What is blocking this task.")
+
+	rootCmd.AddCommand(addCmd)
+}
+
+func runAddCommand(cmd *cobra.Command, args []string) {
+	filePath, err := singleWorkLogPath()
+	if err != nil {
+		slog.Error("cannot add task", "error", err)
+		os.Exit(1)
+	}
+
+	date, task, err := buildTask()
+	if err != nil {
+		slog.Error("invalid task", "error", err)
+		os.Exit(1)
+	}
+
+	workData, err := loadWorkData(filePath)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+	if workData == nil {
+		workData = make(model.WorkData)
+	}
+
+	dailyLog := workData[date]
+	dailyLog.Tasks = append(dailyLog.Tasks, task)
+	workData[date] = dailyLog
+
+	if err := saveWorkData(filePath, workData); err != nil {
+		slog.Error("failed to save work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(statusWriter(cmd.OutOrStdout()), "✅ Added task to %s in %s\n", date, filePath)
+}
+
+// buildTask validates the add flags and returns the target date and the task to append.
+func buildTask() (string, model.Task, error) {
+	date := addDate
+	if date == "" {
+		date = nowFunc().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", date); err != nil {
+		return "", model.Task{}, fmt.Errorf("invalid --date %q, use YYYY-MM-DD", date)
+	}
+
+	status, ok := model.CanonicalStatus(addStatus)
+	if !ok {
+		return "", model.Task{}, fmt.Errorf("unknown status %q, use one of: %s", addStatus, statusComment)
+	}
+
+	if addTicket == "" && addDesc == "" {
+		return "", model.Task{}, fmt.Errorf("a task needs at least --ticket or --desc")
+	}
+
+	return date, model.Task{
+		Status:            status,
+		Description:       addDesc,
+		JiraTicket:        addTicket,
+		UpnextDescription: addUpnext,
+		GithubPR:          addPR,
+		Blocker:           addBlocker,
+	}, nil
+}
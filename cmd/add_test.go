@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestAddCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	nowFunc = func() time.Time { return time.Date(2024, 8, 5, 10, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { nowFunc = time.Now })
+
+	t.Run("appends to an existing date", func(t *testing.T) {
+		output := executeCommandText(t, "add", "--file", tmpFile, "--date", "2024-08-01",
+			"--ticket", "SCR-9", "--desc", "Wrote the add command", "--status", "Completed", "--pr", "https://github.com/example/repo/pull/9")
+		if !strings.Contains(output, "Added task to 2024-08-01") {
+			t.Errorf("Unexpected output: %s", output)
+		}
+
+		workData, err := loadWorkData(tmpFile)
+		if err != nil {
+			t.Fatalf("loadWorkData failed: %v", err)
+		}
+		dailyLog := workData["2024-08-01"]
+		if len(dailyLog.WorkLogEntries) != 2 {
+			t.Errorf("Existing work log entries were not preserved: %+v", dailyLog.WorkLogEntries)
+		}
+		if len(dailyLog.Tasks) != 3 {
+			t.Fatalf("Expected 3 tasks, got %d", len(dailyLog.Tasks))
+		}
+		want := model.Task{
+			Status:      model.StatusCompleted,
+			Description: "Wrote the add command",
+			JiraTicket:  "SCR-9",
+			GithubPR:    "https://github.com/example/repo/pull/9",
+		}
+		if got := dailyLog.Tasks[2]; got.Status != want.Status || got.Description != want.Description || got.JiraTicket != want.JiraTicket || got.GithubPR != want.GithubPR {
+			t.Errorf("Unexpected task: %+v", got)
+		}
+		if len(workData) != 3 {
+			t.Errorf("Expected other dates to be preserved, got %d dates", len(workData))
+		}
+	})
+
+	t.Run("creates today's entry with the default status", func(t *testing.T) {
+		executeCommandText(t, "add", "--file", tmpFile, "--desc", "Planning", "--upnext", "Write tests")
+
+		workData, err := loadWorkData(tmpFile)
+		if err != nil {
+			t.Fatalf("loadWorkData failed: %v", err)
+		}
+		tasks := workData["2024-08-05"].Tasks
+		if len(tasks) != 1 || tasks[0].Status != model.StatusInProgress || tasks[0].UpnextDescription != "Write tests" {
+			t.Errorf("Unexpected tasks for today: %+v", tasks)
+		}
+	})
+}
+
+func TestBuildTaskValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		date   string
+		ticket string
+		desc   string
+		status string
+		errMsg string
+	}{
+		{name: "unknown status", desc: "x", status: "done", errMsg: `unknown status "done"`},
+		{name: "bad date", date: "08/01/2024", desc: "x", status: model.StatusInProgress, errMsg: "invalid --date"},
+		{name: "empty task", status: model.StatusInProgress, errMsg: "at least --ticket or --desc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addDate, addTicket, addDesc, addStatus = tt.date, tt.ticket, tt.desc, tt.status
+			t.Cleanup(func() { addDate, addTicket, addDesc, addStatus = "", "", "", model.StatusInProgress })
+
+			_, _, err := buildTask()
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// defaultArchiveFile is the archive written to when --archive-file is not given.
+const defaultArchiveFile = "worklog-archive.yml"
+
+var (
+	archiveBefore    string
+	archiveOlderThan string
+	archiveFile      string
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move old entries out of the work log into an archive file.",
+	Long:  `Moves every date before --before (or older than --older-than) from the work log to an archive file, appending to the archive if it already exists. The archive is written before the work log is updated, so a failed write never loses entries, and running the command again is safe.`,
+	Run:   runArchiveCommand,
+}
+
+func init() {
+	archiveCmd.Flags().StringVar(&archiveBefore, "before", "", "Archive dates before this date (YYYY-MM-DD).")
+	archiveCmd.Flags().StringVar(&archiveOlderThan, "older-than", "", "Archive dates older than N days, weeks, or months (e.g. 90d, 12w, 6m).")
+	archiveCmd.Flags().StringVar(&archiveFile, "archive-file", defaultArchiveFile, "Archive file to append the moved entries to.")
+	archiveCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which dates would move without changing any files.")
+
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func runArchiveCommand(cmd *cobra.Command, args []string) {
+	filePath, err := singleWorkLogPath()
+	if err != nil {
+		slog.Error("cannot archive", "error", err)
+		os.Exit(exitBadInput)
+	}
+	cutoff, err := archiveCutoff(archiveBefore, archiveOlderThan, nowFunc())
+	if err != nil {
+		slog.Error("invalid archive cutoff", "error", err)
+		os.Exit(exitBadInput)
+	}
+	archivePath := expandPath(archiveFile)
+	if filepath.Clean(archivePath) == filepath.Clean(filePath) {
+		slog.Error("--archive-file must differ from the work log file", "path", filePath)
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadWorkData(filePath)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+	archive, err := loadWorkData(archivePath)
+	if errors.Is(err, os.ErrNotExist) {
+		archive, err = make(model.WorkData), nil
+	}
+	if err != nil {
+		slog.Error("failed to load archive file", "error", err, "path", archivePath)
+		os.Exit(1)
+	}
+	if archive == nil {
+		archive = make(model.WorkData)
+	}
+
+	out := cmd.OutOrStdout()
+	dates := datesBefore(workData, cutoff)
+	if len(dates) == 0 {
+		fmt.Fprintf(out, "Nothing to archive before %s.\n", cutoff)
+		return
+	}
+
+	if dryRun {
+		fmt.Fprintf(out, "Dry run: would move %d date(s) before %s from %s to %s:\n", len(dates), cutoff, filePath, archivePath)
+		printArchivedDates(out, workData, dates)
+		return
+	}
+
+	mergeIntoArchive(archive, workData, dates)
+
+	// Write the archive first: if that fails the work log is untouched, and if the
+	// work log write fails the entries are in both files rather than neither
+	if err := saveWorkData(archivePath, archive); err != nil {
+		slog.Error("failed to write archive file, work log left unchanged", "error", err, "path", archivePath)
+		os.Exit(1)
+	}
+	for _, date := range dates {
+		delete(workData, date)
+	}
+	if err := saveWorkData(filePath, workData); err != nil {
+		slog.Error("archive written but failed to update work log, run archive again to finish", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(out, "📦 Moved %d date(s) before %s from %s to %s:\n", len(dates), cutoff, filePath, archivePath)
+	printArchivedDates(out, archive, dates)
+}
+
+// archiveCutoff returns the YYYY-MM-DD date before which entries are archived,
+// from exactly one of --before and --older-than.
+func archiveCutoff(before, olderThan string, now time.Time) (string, error) {
+	switch {
+	case before != "" && olderThan != "":
+		return "", fmt.Errorf("%w: --before and --older-than cannot be combined", ErrBadDateRange)
+	case before != "":
+		if _, err := time.Parse("2006-01-02", before); err != nil {
+			return "", fmt.Errorf("%w: invalid --before date, use YYYY-MM-DD: %w", ErrBadDateRange, err)
+		}
+		return before, nil
+	case olderThan != "":
+		// Entries exactly the given age are not older than it, so they stay.
+		cutoff, err := spanAgo("--older-than", olderThan, now)
+		if err != nil {
+			return "", err
+		}
+		return cutoff.Format("2006-01-02"), nil
+	default:
+		return "", fmt.Errorf("%w: pass --before or --older-than", ErrBadDateRange)
+	}
+}
+
+// datesBefore returns the work log dates before cutoff in order. Keys that are
+// not valid dates are left in the work log.
+func datesBefore(workData model.WorkData, cutoff string) []string {
+	var dates []string
+	for date := range workData {
+		if _, err := time.Parse("2006-01-02", date); err == nil && date < cutoff {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// mergeIntoArchive appends the given dates from workData to archive. A date the
+// archive already holds with identical content is skipped, so rerunning after a
+// failed work log write does not duplicate entries.
+func mergeIntoArchive(archive, workData model.WorkData, dates []string) {
+	for _, date := range dates {
+		dailyLog := workData[date]
+		existing, exists := archive[date]
+		if exists && reflect.DeepEqual(existing, dailyLog) {
+			continue
+		}
+		existing.WorkLogEntries = append(existing.WorkLogEntries, dailyLog.WorkLogEntries...)
+		existing.Tasks = append(existing.Tasks, dailyLog.Tasks...)
+		archive[date] = existing
+	}
+}
+
+// printArchivedDates lists each date with its task and work log entry counts.
+func printArchivedDates(out io.Writer, workData model.WorkData, dates []string) {
+	for _, date := range dates {
+		dailyLog := workData[date]
+		fmt.Fprintf(out, "  • %s (%d task(s), %d work log entries)\n", date, len(dailyLog.Tasks), len(dailyLog.WorkLogEntries))
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestArchiveCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+	archivePath := filepath.Join(t.TempDir(), "archive.yml")
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		before, err := os.ReadFile(tmpFile)
+		if err != nil {
+			t.Fatalf("Failed to read work log: %v", err)
+		}
+
+		output := executeCommandText(t, "archive", "--file", tmpFile, "--archive-file", archivePath, "--before", "2024-08-03", "--dry-run")
+		expected := "Dry run: would move 2 date(s) before 2024-08-03 from " + tmpFile + " to " + archivePath + ":\n" +
+			"  • 2024-08-01 (2 task(s), 2 work log entries)\n" +
+			"  • 2024-08-02 (3 task(s), 1 work log entries)\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+
+		after, err := os.ReadFile(tmpFile)
+		if err != nil {
+			t.Fatalf("Failed to read work log: %v", err)
+		}
+		if string(after) != string(before) {
+			t.Errorf("Dry run modified the work log")
+		}
+		if _, err := os.Stat(archivePath); !os.IsNotExist(err) {
+			t.Errorf("Dry run created the archive file")
+		}
+	})
+
+	t.Run("moves old dates", func(t *testing.T) {
+		output := executeCommandText(t, "archive", "--file", tmpFile, "--archive-file", archivePath, "--before", "2024-08-03")
+		if !strings.HasPrefix(output, "📦 Moved 2 date(s) before 2024-08-03") {
+			t.Errorf("Unexpected output: %q", output)
+		}
+
+		assertDates(t, tmpFile, []string{"2024-08-03"})
+		assertDates(t, archivePath, []string{"2024-08-01", "2024-08-02"})
+	})
+
+	t.Run("rerun is a no-op", func(t *testing.T) {
+		output := executeCommandText(t, "archive", "--file", tmpFile, "--archive-file", archivePath, "--before", "2024-08-03")
+		if output != "Nothing to archive before 2024-08-03.\n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+		assertDates(t, archivePath, []string{"2024-08-01", "2024-08-02"})
+	})
+
+	t.Run("older-than appends to the archive", func(t *testing.T) {
+		nowFunc = func() time.Time { return time.Date(2024, 8, 10, 12, 0, 0, 0, time.Local) }
+		t.Cleanup(func() { nowFunc = time.Now })
+
+		executeCommandText(t, "archive", "--file", tmpFile, "--archive-file", archivePath, "--older-than", "5d")
+
+		assertDates(t, tmpFile, nil)
+		assertDates(t, archivePath, []string{"2024-08-01", "2024-08-02", "2024-08-03"})
+	})
+}
+
+func TestMergeIntoArchiveSkipsArchivedDates(t *testing.T) {
+	day := model.DailyLog{Tasks: []model.Task{{JiraTicket: "PROJ-1", Description: "Work"}}}
+	archive := model.WorkData{"2024-08-01": day}
+	workData := model.WorkData{
+		"2024-08-01": day,
+		"2024-08-02": {Tasks: []model.Task{{JiraTicket: "PROJ-2"}}},
+	}
+
+	mergeIntoArchive(archive, workData, []string{"2024-08-01", "2024-08-02"})
+
+	if got := len(archive["2024-08-01"].Tasks); got != 1 {
+		t.Errorf("Expected the already archived date to be left alone, got %d tasks", got)
+	}
+	if got := len(archive["2024-08-02"].Tasks); got != 1 {
+		t.Errorf("Expected the new date to be archived, got %d tasks", got)
+	}
+}
+
+func TestArchiveCutoff(t *testing.T) {
+	now := time.Date(2024, 8, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		before, olderThan string
+		want              string
+		wantErr           bool
+	}{
+		{before: "2024-08-01", want: "2024-08-01"},
+		{olderThan: "90d", want: "2024-05-12"},
+		{olderThan: "1d", want: "2024-08-09"},
+		{before: "08/01/2024", wantErr: true},
+		{before: "2024-08-01", olderThan: "90d", wantErr: true},
+		{wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := archiveCutoff(tt.before, tt.olderThan, now)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("archiveCutoff(%q, %q) = %q, %v; want %q, error %v", tt.before, tt.olderThan, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// assertDates checks the dates stored in a work log file.
+func assertDates(t *testing.T, path string, want []string) {
+	t.Helper()
+	workData, err := loadWorkData(path)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", path, err)
+	}
+	var got []string
+	for date := range workData {
+		got = append(got, date)
+	}
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s holds dates %v, want %v", path, got, want)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+)
+
+// configFileName is the name of the config file searched for when --config is not given.
+const configFileName = "taskledger.yaml"
+
+// jiraInstancesKey is the config file section mapping JIRA ticket prefixes to
+// other JIRA instances. It is not a flag, so applyConfig skips it.
+const jiraInstancesKey = "jira-instances"
+
+// jiraInstanceConfig is an entry of the jira-instances config file section. The
+// token is read from pat-file, or else from the environment variable pat-env.
+type jiraInstanceConfig struct {
+	BaseURL string `yaml:"base-url"`
+	PATFile string `yaml:"pat-file"`
+	PATEnv  string `yaml:"pat-env"`
+}
+
+// configPath is the explicit config file path set with --config.
+var configPath string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to a config file (defaults to ./"+configFileName+", then ~/.config/taskledger/"+configFileName+").")
+}
+
+// findConfigFile returns the config file to load: the explicit path if given,
+// otherwise the first of ./taskledger.yaml and ~/.config/taskledger/taskledger.yaml
+// that exists. An empty path means no config file was found.
+func findConfigFile(explicit string) (string, error) {
+	if explicit != "" {
+		if _, err := os.Stat(explicit); err != nil {
+			return "", fmt.Errorf("could not read config file: %w", err)
+		}
+		return explicit, nil
+	}
+
+	candidates := []string{configFileName}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".config", "taskledger", configFileName))
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("could not read config file '%s': %w", candidate, err)
+		}
+	}
+	return "", nil
+}
+
+// loadConfig reads a config file mapping flag names to values. Top-level keys set
+// defaults for any command that has that flag; a section named after a command
+// (e.g. "report:") sets defaults for that command only and takes precedence.
+func loadConfig(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file '%s': %w", path, err)
+	}
+
+	config := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("could not parse YAML from config file '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+// applyConfig sets every flag of cmd named in config that was not given on the
+// command line, so explicit flags always take precedence over the config file.
+func applyConfig(cmd *cobra.Command, config map[string]interface{}) error {
+	values := make(map[string]interface{})
+	for key, value := range config {
+		if _, isSection := value.(map[string]interface{}); !isSection {
+			values[key] = value
+		}
+	}
+
+	if section, ok := config[cmd.Name()].(map[string]interface{}); ok {
+		for key, value := range section {
+			if cmd.Flags().Lookup(key) == nil {
+				return fmt.Errorf("unknown option %q in %q section of config file", key, cmd.Name())
+			}
+			values[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		// Top-level keys may name flags that only some commands define
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil || flag.Changed || key == "config" {
+			continue
+		}
+		if err := setFlagFromConfig(flag, values[key]); err != nil {
+			return fmt.Errorf("invalid config value for %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// setFlagFromConfig sets a flag's value from a decoded YAML value. Lists are
+// accepted for slice flags such as --file.
+func setFlagFromConfig(flag *pflag.Flag, value interface{}) error {
+	if list, ok := value.([]interface{}); ok {
+		sliceValue, ok := flag.Value.(pflag.SliceValue)
+		if !ok {
+			return fmt.Errorf("--%s does not accept a list", flag.Name)
+		}
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return sliceValue.Replace(items)
+	}
+	if value == nil {
+		return nil
+	}
+	return flag.Value.Set(strings.TrimSpace(fmt.Sprint(value)))
+}
+
+// jiraInstances decodes the jira-instances section of a config file into JIRA
+// instances by ticket prefix, resolving each instance's token.
+func jiraInstances(config map[string]interface{}) (map[string]jira.Instance, error) {
+	section, ok := config[jiraInstancesKey]
+	if !ok {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(section)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q section: %w", jiraInstancesKey, err)
+	}
+	var entries map[string]jiraInstanceConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid %q section: %w", jiraInstancesKey, err)
+	}
+
+	instances := make(map[string]jira.Instance, len(entries))
+	for prefix, entry := range entries {
+		instance := jira.Instance{BaseURL: entry.BaseURL}
+		switch {
+		case entry.PATFile != "":
+			token, err := os.ReadFile(expandPath(entry.PATFile))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read JIRA token file for prefix %s: %w", prefix, err)
+			}
+			instance.Token = strings.TrimSpace(string(token))
+		case entry.PATEnv != "":
+			instance.Token = strings.TrimSpace(os.Getenv(entry.PATEnv))
+		}
+		instances[prefix] = instance
+	}
+	return instances, nil
+}
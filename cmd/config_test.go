@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigFile(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	configFile := filepath.Join(t.TempDir(), "taskledger.yaml")
+	config := "file:\n  - " + tmpFile + "\n" +
+		"format: csv\n" +
+		"report:\n" +
+		"  format: markdown\n"
+	if err := os.WriteFile(configFile, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Setenv("JIRA_PAT", "")
+
+	t.Run("top-level values apply to every command with the flag", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--config", configFile, "--start-date", "2024-08-01")
+		expected := "date,entries,hours\n" +
+			"2024-08-01,2,7.00\n" +
+			"total,2,7.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("command sections override top-level values", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--config", configFile, "--start-date", "2024-08-01")
+		if !strings.HasPrefix(output, "# Work Report (2024-08-01 to 2024-08-01)") {
+			t.Errorf("Expected a markdown report, got:\n%s", output)
+		}
+	})
+
+	t.Run("command line flags take precedence", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--config", configFile, "--start-date", "2024-08-01", "--format", "text")
+		expected := "Total hours worked from 2024-08-01 to 2024-08-01: 7.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("unknown option in a command section is an error", func(t *testing.T) {
+		err := applyConfig(hoursCmd, map[string]interface{}{
+			"hours": map[string]interface{}{"bogus": true},
+		})
+		if err == nil || !strings.Contains(err.Error(), `unknown option "bogus"`) {
+			t.Errorf("Expected unknown option error, got %v", err)
+		}
+	})
+}
+
+func TestConfigFileJiraInstances(t *testing.T) {
+	t.Setenv("JIRA_PAT", "")
+	t.Setenv("JIRA_BASE_URL", "")
+
+	workLog := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(workLog, []byte(`
+2024-08-01:
+  tasks:
+    - jira_ticket: "PROJ-1"
+      status: "completed"
+      description: "Main instance work"
+    - jira_ticket: "OPS-2"
+      status: "completed"
+      description: "Ops instance work"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+	configFile := filepath.Join(t.TempDir(), "taskledger.yaml")
+	config := "jira-base-url: https://jira.example.com\n" +
+		"jira-instances:\n" +
+		"  OPS:\n" +
+		"    base-url: https://ops.example.com/\n" +
+		"    pat-env: OPS_JIRA_PAT\n"
+	if err := os.WriteFile(configFile, []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	output := executeCommandText(t, "report", "--config", configFile, "--file", workLog, "--start-date", "2024-08-01", "--format", "markdown")
+	for _, link := range []string{"[PROJ-1](https://jira.example.com/browse/PROJ-1)", "[OPS-2](https://ops.example.com/browse/OPS-2)"} {
+		if !strings.Contains(output, link) {
+			t.Errorf("Expected %s in the report, got:\n%s", link, output)
+		}
+	}
+
+	// Instances only last as long as the config file that sets them
+	output = executeCommandText(t, "report", "--file", workLog, "--start-date", "2024-08-01", "--format", "markdown")
+	if !strings.Contains(output, "[OPS-2](https://issues.redhat.com/browse/OPS-2)") {
+		t.Errorf("Expected OPS-2 on the default instance without the config file, got:\n%s", output)
+	}
+
+	if _, err := jiraInstances(map[string]interface{}{"jira-instances": map[string]interface{}{"OPS": map[string]interface{}{"url": "https://ops.example.com"}}}); err == nil {
+		t.Error("Expected an error for an unknown jira-instances option")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/report"
+)
+
+var summaryDate string
+
+var dailySummaryCmd = &cobra.Command{
+	Use:   "daily-summary",
+	Short: "Print a one-line digest of a day's tasks.",
+	Long:  `Prints a single sentence listing the tickets worked on a day, with task counts by status and the number of PRs opened, e.g. "Worked on PROJ-1, PROJ-2 (2 completed, 1 in progress), opened 1 PR". Handy for a time tracker's daily note.`,
+	Run:   runDailySummaryCommand,
+}
+
+func init() {
+	dailySummaryCmd.Flags().StringVar(&summaryDate, "date", "today", "Date to summarize (YYYY-MM-DD, today, or yesterday).")
+	dailySummaryCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for the digest (text, json).")
+	dailySummaryCmd.Flags().BoolVar(&showPrivate, "include-private", false, "Include tasks marked private: true, which are otherwise left out.")
+
+	rootCmd.AddCommand(dailySummaryCmd)
+}
+
+func runDailySummaryCommand(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("unsupported daily summary format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	date := resolveRelativeDate(summaryDate, nowFunc(), true, weekStart)
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		slog.Error("invalid --date, use YYYY-MM-DD, today, or yesterday", "date", summaryDate)
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		os.Exit(1)
+	}
+
+	digest := report.NewDailyDigest(workData, date, report.Options{IncludePrivate: showPrivate})
+	if digest.Tasks == 0 {
+		slog.Error("no tasks logged on date", "date", date)
+		os.Exit(exitNoData)
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(digest, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal daily summary as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+	fmt.Fprintln(out, digest.Summary)
+}
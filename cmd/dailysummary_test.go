@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDailySummaryCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	want := "Worked on PROJ-99, SCR-2 (2 completed, 1 in progress), opened 1 PR\n"
+	if output := executeCommandText(t, "daily-summary", "--file", tmpFile, "--date", "2024-08-02"); output != want {
+		t.Errorf("Expected %q, got %q", want, output)
+	}
+
+	output := executeCommandText(t, "daily-summary", "--file", tmpFile, "--date", "2024-08-03", "--format", "json")
+	var digest struct {
+		Date          string         `json:"date"`
+		Tickets       []string       `json:"tickets"`
+		TasksByStatus map[string]int `json:"tasks_by_status"`
+		PRs           int            `json:"prs"`
+		Summary       string         `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(output), &digest); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+	if digest.Date != "2024-08-03" || len(digest.Tickets) != 1 || digest.Tickets[0] != "SCR-3" || digest.PRs != 0 {
+		t.Errorf("Unexpected digest: %+v", digest)
+	}
+	if digest.TasksByStatus["in progress"] != 1 || digest.TasksByStatus["not started"] != 1 {
+		t.Errorf("Unexpected status counts: %v", digest.TasksByStatus)
+	}
+	if digest.Summary != "Worked on SCR-3 (1 in progress, 1 not started)" {
+		t.Errorf("Unexpected summary %q", digest.Summary)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+	"github.com/bryan-cox/taskledger/internal/report"
+)
+
+var (
+	rangeA string
+	rangeB string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the tickets worked on in two date ranges.",
+	Long:  `Compares two date ranges and reports the JIRA tickets that appeared, disappeared, changed status, or gained new PRs between them. Each range is START:END, or a single date or relative keyword such as last-week.`,
+	Run:   runDiffCommand,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&rangeA, "range-a", "", "Earlier date range as START:END (e.g. 2024-08-01:2024-08-07, or last-week).")
+	diffCmd.Flags().StringVar(&rangeB, "range-b", "", "Later date range as START:END (e.g. 2024-08-08:2024-08-14, or this-week).")
+	diffCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for the diff (text, json).")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+// dateSpan is the first and last logged date of a compared range.
+type dateSpan struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// rangeDiff is the result of the diff command.
+type rangeDiff struct {
+	RangeA dateSpan `json:"range_a"`
+	RangeB dateSpan `json:"range_b"`
+	report.RangeDiff
+}
+
+func runDiffCommand(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("unsupported diff format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if rangeA == "" || rangeB == "" {
+		slog.Error("both --range-a and --range-b are required")
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		os.Exit(1)
+	}
+
+	datesA, err := datesForRange(workData, rangeA)
+	if err != nil {
+		slog.Error("failed to process --range-a", "error", err, "range", rangeA)
+		os.Exit(dateRangeExitCode(err))
+	}
+	datesB, err := datesForRange(workData, rangeB)
+	if err != nil {
+		slog.Error("failed to process --range-b", "error", err, "range", rangeB)
+		os.Exit(dateRangeExitCode(err))
+	}
+
+	result := rangeDiff{
+		RangeA:    dateSpan{Start: datesA[0], End: datesA[len(datesA)-1]},
+		RangeB:    dateSpan{Start: datesB[0], End: datesB[len(datesB)-1]},
+		RangeDiff: report.DiffCategorized(report.CategorizeTasks(workData, datesA, report.Options{}), report.CategorizeTasks(workData, datesB, report.Options{})),
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal diff as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+	printRangeDiff(out, result)
+}
+
+// datesForRange returns the logged dates in a START:END range. A value without a
+// colon is used as both start and end, so a keyword like last-week covers its
+// whole span.
+func datesForRange(workData model.WorkData, value string) ([]string, error) {
+	start, end, found := strings.Cut(value, ":")
+	if !found {
+		end = start
+	}
+	if strings.TrimSpace(start) == "" || strings.TrimSpace(end) == "" {
+		return nil, fmt.Errorf("%w: range must be START:END", ErrBadDateRange)
+	}
+	return getDatesInRange(workData, strings.TrimSpace(start), strings.TrimSpace(end))
+}
+
+// printRangeDiff writes the diff as text, one section per kind of change.
+// Sections without changes are omitted.
+func printRangeDiff(out io.Writer, result rangeDiff) {
+	fmt.Fprintf(out, "Changes from %s to %s compared with %s to %s\n",
+		result.RangeB.Start, result.RangeB.End, result.RangeA.Start, result.RangeA.End)
+
+	if len(result.Appeared) == 0 && len(result.Disappeared) == 0 && len(result.StatusChanged) == 0 && len(result.NewPRs) == 0 {
+		fmt.Fprintln(out, "No ticket changes.")
+		return
+	}
+
+	if len(result.Appeared) > 0 {
+		fmt.Fprintln(out, "\nAppeared:")
+		for _, change := range result.Appeared {
+			fmt.Fprintf(out, "  • %s (%s)\n", change.Ticket, change.Status)
+		}
+	}
+	if len(result.Disappeared) > 0 {
+		fmt.Fprintln(out, "\nDisappeared:")
+		for _, change := range result.Disappeared {
+			fmt.Fprintf(out, "  • %s (was %s)\n", change.Ticket, change.PreviousStatus)
+		}
+	}
+	if len(result.StatusChanged) > 0 {
+		fmt.Fprintln(out, "\nStatus changed:")
+		for _, change := range result.StatusChanged {
+			fmt.Fprintf(out, "  • %s: %s → %s\n", change.Ticket, change.PreviousStatus, change.Status)
+		}
+	}
+	if len(result.NewPRs) > 0 {
+		fmt.Fprintln(out, "\nNew PRs:")
+		for _, change := range result.NewPRs {
+			fmt.Fprintf(out, "  • %s\n", change.Ticket)
+			for _, pr := range change.NewPRs {
+				fmt.Fprintf(out, "    ◦ %s\n", pr)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("text", func(t *testing.T) {
+		output := executeCommandText(t, "diff", "--file", tmpFile, "--range-a", "2024-08-01", "--range-b", "2024-08-02:2024-08-03")
+		expected := "Changes from 2024-08-02 to 2024-08-03 compared with 2024-08-01 to 2024-08-01\n" +
+			"\nAppeared:\n" +
+			"  • PROJ-99 (completed)\n" +
+			"  • SCR-2 (in progress)\n" +
+			"  • SCR-3 (in progress)\n" +
+			"\nDisappeared:\n" +
+			"  • SCR-1 (was completed)\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		output := executeCommandText(t, "diff", "--file", tmpFile, "--range-a", "2024-08-01:2024-08-01", "--range-b", "2024-08-01")
+		expected := "Changes from 2024-08-01 to 2024-08-01 compared with 2024-08-01 to 2024-08-01\nNo ticket changes.\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		output := executeCommandText(t, "diff", "--file", tmpFile, "--range-a", "2024-08-01", "--range-b", "2024-08-02", "--format", "json")
+		var result rangeDiff
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+		}
+		if result.RangeA.Start != "2024-08-01" || result.RangeB.End != "2024-08-02" {
+			t.Errorf("Unexpected ranges: %+v %+v", result.RangeA, result.RangeB)
+		}
+		if len(result.Appeared) != 2 || len(result.Disappeared) != 1 || len(result.StatusChanged) != 0 {
+			t.Errorf("Unexpected diff: %+v", result.RangeDiff)
+		}
+	})
+}
+
+func TestDatesForRangeRejectsEmptyBounds(t *testing.T) {
+	for _, value := range []string{":2024-08-01", "2024-08-01:", ":"} {
+		if _, err := datesForRange(nil, value); err == nil {
+			t.Errorf("datesForRange(%q) returned no error", value)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the worklog file in your editor.",
+	Long:  `Opens the worklog file in $EDITOR (vi, or notepad on Windows, when unset) and validates it once the editor exits, listing any problems the edits introduced.`,
+	Run:   runEditCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+}
+
+// editorRunner runs the editor on a file; tests override it to edit without a terminal.
+var editorRunner = runEditor
+
+func runEditCommand(cmd *cobra.Command, args []string) {
+	filePath, err := singleWorkLogPath()
+	if err != nil {
+		slog.Error("cannot edit work log", "error", err)
+		os.Exit(1)
+	}
+
+	editor := editorCommand(os.Getenv("EDITOR"), runtime.GOOS)
+	if err := editorRunner(editor, filePath); err != nil {
+		slog.Error("editor failed", "error", err, "editor", strings.Join(editor, " "), "path", filePath)
+		os.Exit(1)
+	}
+
+	// Like validate, but the edits are already saved, so problems are warnings
+	out := cmd.OutOrStdout()
+	workData, err := loadWorkData(filePath)
+	if err != nil {
+		fmt.Fprintf(out, "⚠️  %s was saved but could not be loaded: %v\n", filePath, err)
+		return
+	}
+	problems := workData.Validate()
+	if len(problems) == 0 {
+		fmt.Fprintf(statusWriter(out), "✅ %s is valid\n", filePath)
+		return
+	}
+	for _, problem := range problems {
+		fmt.Fprintln(out, problem)
+	}
+	fmt.Fprintf(out, "\n⚠️  %s was saved with %d problem(s); run edit again to fix them\n", filePath, len(problems))
+}
+
+// editorCommand splits an $EDITOR value such as "code --wait" into the program
+// and its arguments, falling back to notepad on Windows and vi elsewhere.
+func editorCommand(editor, goos string) []string {
+	if fields := strings.Fields(editor); len(fields) > 0 {
+		return fields
+	}
+	if goos == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// runEditor opens filePath in the editor with the terminal attached and waits
+// for it to exit.
+func runEditor(editor []string, filePath string) error {
+	c := exec.Command(editor[0], append(editor[1:], filePath)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		editor string
+		goos   string
+		want   []string
+	}{
+		{editor: "nano", goos: "linux", want: []string{"nano"}},
+		{editor: "code --wait", goos: "darwin", want: []string{"code", "--wait"}},
+		{editor: "  emacs   -nw ", goos: "linux", want: []string{"emacs", "-nw"}},
+		{editor: "", goos: "linux", want: []string{"vi"}},
+		{editor: "", goos: "windows", want: []string{"notepad"}},
+	}
+	for _, tt := range tests {
+		if got := editorCommand(tt.editor, tt.goos); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("editorCommand(%q, %q) = %v, want %v", tt.editor, tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestEditCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+	t.Setenv("EDITOR", "code --wait")
+
+	var edited []string
+	var editWith string
+	editorRunner = func(editor []string, filePath string) error {
+		edited = append(editor, filePath)
+		if editWith == "" {
+			return nil
+		}
+		return os.WriteFile(filePath, []byte(editWith), 0644)
+	}
+	t.Cleanup(func() { editorRunner = runEditor })
+
+	t.Run("opens the work log in $EDITOR and validates it", func(t *testing.T) {
+		output := executeCommandText(t, "edit", "--file", tmpFile)
+
+		if want := []string{"code", "--wait", tmpFile}; !reflect.DeepEqual(edited, want) {
+			t.Errorf("Expected editor invocation %v, got %v", want, edited)
+		}
+		if !strings.Contains(output, "✅ "+tmpFile+" is valid") {
+			t.Errorf("Unexpected edit output:\n%s", output)
+		}
+	})
+
+	t.Run("warns about problems introduced by the edits", func(t *testing.T) {
+		editWith = `
+"2024-08-01":
+  work_log:
+    - start_time: "17:00"
+      end_time: "09:00"
+  tasks:
+    - description: "Typo in status"
+      status: "done"
+`
+		output := executeCommandText(t, "edit", "--file", tmpFile)
+
+		if !strings.Contains(output, "2024-08-01: tasks[0].status") || !strings.Contains(output, "was saved with 2 problem(s)") {
+			t.Errorf("Expected problems to be listed, got:\n%s", output)
+		}
+	})
+
+	t.Run("warns when the edited file is not valid YAML", func(t *testing.T) {
+		editWith = "\"2024-08-01\": [unclosed\n"
+		output := executeCommandText(t, "edit", "--file", tmpFile)
+
+		if !strings.Contains(output, "was saved but could not be loaded") {
+			t.Errorf("Expected a parse warning, got:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// importColumns are the columns an imported CSV file must have, in any order.
+var importColumns = []string{"date", "ticket", "description", "status", "hours"}
+
+// importDayStart is when the first imported work_log entry of a day begins if the
+// day has no entries yet.
+const importDayStart = "09:00"
+
+var importFrom string
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tasks and hours from a CSV file into the worklog.",
+	Long: `Reads a CSV file with the columns date, ticket, description, status, and hours (in any order, with a header row) and merges each row into the worklog as a task on that date.
+The hours become a work_log entry attributed to the ticket. Entries for a date are laid end to end, starting at 09:00 or at the end of the date's last existing entry.
+Every row is checked first and nothing is written if any row is invalid.`,
+	Run: runImportCommand,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFrom, "from", "", "CSV file to import.")
+	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be imported without changing the worklog.")
+
+	rootCmd.AddCommand(importCmd)
+}
+
+// importRow is a validated CSV row.
+type importRow struct {
+	Line  int
+	Date  string
+	Task  model.Task
+	Hours time.Duration
+}
+
+func runImportCommand(cmd *cobra.Command, args []string) {
+	if importFrom == "" {
+		slog.Error("pass the CSV file to import with --from")
+		os.Exit(exitBadInput)
+	}
+	filePath, err := singleWorkLogPath()
+	if err != nil {
+		slog.Error("cannot import", "error", err)
+		os.Exit(1)
+	}
+
+	f, err := os.Open(expandPath(importFrom))
+	if err != nil {
+		slog.Error("failed to open CSV file", "error", err, "path", importFrom)
+		os.Exit(1)
+	}
+	rows, errs := parseImportCSV(f)
+	f.Close()
+
+	out := cmd.OutOrStdout()
+	if len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintf(out, "%s: %v\n", importFrom, err)
+		}
+		slog.Error("CSV file has invalid rows, nothing was imported", "path", importFrom, "problems", len(errs))
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadWorkData(filePath)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+	if workData == nil {
+		workData = make(model.WorkData)
+	}
+
+	if err := mergeImportedRows(workData, rows); err != nil {
+		slog.Error("cannot import CSV rows, nothing was imported", "error", err, "path", importFrom)
+		os.Exit(exitBadInput)
+	}
+
+	dates := importedDates(rows)
+	if dryRun {
+		fmt.Fprintf(out, "Dry run: would import %d task(s) on %d date(s) from %s into %s:\n", len(rows), len(dates), importFrom, filePath)
+		printImportedTasks(out, rows)
+		return
+	}
+
+	if err := saveWorkData(filePath, workData); err != nil {
+		slog.Error("failed to save work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+	status := statusWriter(out)
+	fmt.Fprintf(status, "✅ Imported %d task(s) on %d date(s) from %s into %s:\n", len(rows), len(dates), importFrom, filePath)
+	printImportedTasks(status, rows)
+}
+
+// parseImportCSV reads and validates every row of an import CSV file. Errors name
+// the line they were found on; a missing column fails the whole file.
+func parseImportCSV(r io.Reader) ([]importRow, []error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, []error{fmt.Errorf("file is empty, expected a header row with the columns %s", strings.Join(importColumns, ", "))}
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		column[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	var missing []string
+	for _, name := range importColumns {
+		if _, ok := column[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, []error{fmt.Errorf("line 1: missing column(s) %s", strings.Join(missing, ", "))}
+	}
+
+	var rows []importRow
+	var errs []error
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// csv.ParseError already names the line
+			errs = append(errs, err)
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				continue
+			}
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			return strings.TrimSpace(record[column[name]])
+		}
+
+		row, err := buildImportRow(line, field)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, errs
+}
+
+// buildImportRow validates the fields of one CSV row.
+func buildImportRow(line int, field func(string) string) (importRow, error) {
+	date := field("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return importRow{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", date)
+	}
+
+	status, ok := model.CanonicalStatus(field("status"))
+	if !ok {
+		return importRow{}, fmt.Errorf("unknown status %q, use one of: %s", field("status"), statusComment)
+	}
+
+	ticket, description := field("ticket"), field("description")
+	if ticket == "" && description == "" {
+		return importRow{}, fmt.Errorf("a row needs at least a ticket or a description")
+	}
+
+	var hours time.Duration
+	if value := field("hours"); value != "" {
+		h, err := strconv.ParseFloat(value, 64)
+		if err != nil || h < 0 || h > 24 {
+			return importRow{}, fmt.Errorf("invalid hours %q, use a number from 0 to 24", value)
+		}
+		hours = time.Duration(h * float64(time.Hour)).Round(time.Minute)
+	}
+
+	return importRow{
+		Line:  line,
+		Date:  date,
+		Task:  model.Task{Status: status, Description: description, JiraTicket: ticket},
+		Hours: hours,
+	}, nil
+}
+
+// mergeImportedRows appends each row's task to its date in workData and records
+// its hours as a work_log entry attributed to its ticket. A date's entries are
+// laid end to end from the end of its latest existing entry, or from
+// importDayStart. An entry ending exactly at midnight is written as 00:00 with
+// next_day set. It fails without changing workData if entries would run past
+// midnight.
+func mergeImportedRows(workData model.WorkData, rows []importRow) error {
+	// Find where each date's imported entries start before touching workData
+	midnight := importMidnight()
+	next := make(map[string]time.Time)
+	var entries []model.WorkLog
+	for _, row := range rows {
+		if row.Hours == 0 {
+			entries = append(entries, model.WorkLog{})
+			continue
+		}
+		start, ok := next[row.Date]
+		if !ok {
+			start = importStartTime(workData[row.Date].WorkLogEntries)
+		}
+		end := start.Add(row.Hours)
+		if end.After(midnight) {
+			return fmt.Errorf("line %d: the hours logged on %s run past midnight", row.Line, row.Date)
+		}
+		next[row.Date] = end
+		entries = append(entries, model.WorkLog{StartTime: start.Format("15:04"), EndTime: end.Format("15:04"), NextDay: end.Equal(midnight), Ticket: row.Task.JiraTicket})
+	}
+
+	for i, row := range rows {
+		dailyLog := workData[row.Date]
+		dailyLog.Tasks = append(dailyLog.Tasks, row.Task)
+		if entries[i] != (model.WorkLog{}) {
+			dailyLog.WorkLogEntries = append(dailyLog.WorkLogEntries, entries[i])
+		}
+		workData[row.Date] = dailyLog
+	}
+	return nil
+}
+
+// importStartTime returns the latest end time among entries, or importDayStart
+// when there is none. Entries ending the next day and unparsable times are ignored.
+func importStartTime(entries []model.WorkLog) time.Time {
+	start, _ := model.ParseWorkTime(importDayStart)
+	for _, entry := range entries {
+		if entry.NextDay {
+			continue
+		}
+		if end, err := model.ParseWorkTime(entry.EndTime); err == nil && end.After(start) {
+			start = end
+		}
+	}
+	return start
+}
+
+// importMidnight returns midnight at the end of the day parsed work log times
+// fall on.
+func importMidnight() time.Time {
+	dayStart, _ := model.ParseWorkTime("00:00")
+	return dayStart.AddDate(0, 0, 1)
+}
+
+// importedDates returns the distinct dates of rows in order.
+func importedDates(rows []importRow) []string {
+	seen := make(map[string]bool)
+	var dates []string
+	for _, row := range rows {
+		if !seen[row.Date] {
+			seen[row.Date] = true
+			dates = append(dates, row.Date)
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// printImportedTasks lists the imported rows by date.
+func printImportedTasks(out io.Writer, rows []importRow) {
+	sorted := append([]importRow(nil), rows...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+	for _, row := range sorted {
+		label := row.Task.JiraTicket
+		if row.Task.Description != "" {
+			label = strings.TrimSpace(label + " " + row.Task.Description)
+		}
+		fmt.Fprintf(out, "  %s: %s (%s, %s)\n", row.Date, label, row.Task.Status, formatDuration(row.Hours, durationHM))
+	}
+}
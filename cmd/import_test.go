@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	t.Run("valid rows", func(t *testing.T) {
+		csv := "Date,Ticket,Description,Status,Hours\n" +
+			"2024-08-05,SCR-10,Wrote the importer,Completed,2.5\n" +
+			"2024-08-05,,Team sync,in progress,\n"
+		rows, errs := parseImportCSV(strings.NewReader(csv))
+		if len(errs) > 0 {
+			t.Fatalf("Unexpected errors: %v", errs)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d", len(rows))
+		}
+		want := importRow{Line: 2, Date: "2024-08-05", Task: model.Task{JiraTicket: "SCR-10", Description: "Wrote the importer", Status: model.StatusCompleted}, Hours: 150 * time.Minute}
+		if fmt.Sprint(rows[0]) != fmt.Sprint(want) {
+			t.Errorf("Expected %+v, got %+v", want, rows[0])
+		}
+		if rows[1].Line != 3 || rows[1].Hours != 0 || rows[1].Task.Status != model.StatusInProgress {
+			t.Errorf("Unexpected second row: %+v", rows[1])
+		}
+	})
+
+	t.Run("missing columns", func(t *testing.T) {
+		_, errs := parseImportCSV(strings.NewReader("date,ticket,status\n2024-08-05,SCR-1,completed\n"))
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), "missing column(s) description, hours") {
+			t.Errorf("Expected a missing column error, got %v", errs)
+		}
+	})
+
+	t.Run("row errors name their line", func(t *testing.T) {
+		csv := "date,ticket,description,status,hours\n" +
+			"08/05/2024,SCR-1,Bad date,completed,1\n" +
+			"2024-08-05,SCR-2,Bad status,done,1\n" +
+			"2024-08-05,,,completed,1\n" +
+			"2024-08-05,SCR-4,Bad hours,completed,lots\n" +
+			"2024-08-05,SCR-5,Too few fields\n" +
+			"2024-08-05,SCR-6,Fine,completed,1\n"
+		rows, errs := parseImportCSV(strings.NewReader(csv))
+		if len(rows) != 1 || rows[0].Line != 7 {
+			t.Errorf("Expected only the row on line 7 to parse, got %+v", rows)
+		}
+		want := []string{
+			`line 2: invalid date "08/05/2024"`,
+			`line 3: unknown status "done"`,
+			"line 4: a row needs at least a ticket or a description",
+			`line 5: invalid hours "lots"`,
+			"line 6",
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("Expected %d errors, got %v", len(want), errs)
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), want[i]) {
+				t.Errorf("Expected error %d to contain %q, got %q", i, want[i], err)
+			}
+		}
+	})
+}
+
+func TestMergeImportedRows(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "12:30"}, {StartTime: "22:00", EndTime: "01:00", NextDay: true}}},
+	}
+	rows := []importRow{
+		{Line: 2, Date: "2024-08-01", Task: model.Task{JiraTicket: "SCR-1"}, Hours: 90 * time.Minute},
+		{Line: 3, Date: "2024-08-02", Task: model.Task{JiraTicket: "SCR-2"}, Hours: time.Hour},
+		{Line: 4, Date: "2024-08-02", Task: model.Task{Description: "No hours"}},
+		{Line: 5, Date: "2024-08-02", Task: model.Task{JiraTicket: "SCR-3"}, Hours: 30 * time.Minute},
+	}
+	if err := mergeImportedRows(workData, rows); err != nil {
+		t.Fatalf("mergeImportedRows failed: %v", err)
+	}
+
+	if got := workData["2024-08-01"].WorkLogEntries[2]; got != (model.WorkLog{StartTime: "12:30", EndTime: "14:00", Ticket: "SCR-1"}) {
+		t.Errorf("Expected the entry to start after the last one ending that day, got %+v", got)
+	}
+	want := []model.WorkLog{{StartTime: "09:00", EndTime: "10:00", Ticket: "SCR-2"}, {StartTime: "10:00", EndTime: "10:30", Ticket: "SCR-3"}}
+	if got := workData["2024-08-02"].WorkLogEntries; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected entries %+v, got %+v", want, got)
+	}
+	if got := len(workData["2024-08-02"].Tasks); got != 3 {
+		t.Errorf("Expected 3 tasks on 2024-08-02, got %d", got)
+	}
+
+	late := model.WorkData{"2024-08-01": {WorkLogEntries: []model.WorkLog{{StartTime: "18:00", EndTime: "23:00"}}}}
+	err := mergeImportedRows(late, []importRow{{Line: 9, Date: "2024-08-01", Hours: 2 * time.Hour}})
+	if err == nil || !strings.Contains(err.Error(), "line 9") {
+		t.Errorf("Expected a past midnight error for line 9, got %v", err)
+	}
+	if len(late["2024-08-01"].Tasks) != 0 || len(late["2024-08-01"].WorkLogEntries) != 1 {
+		t.Errorf("Expected the work data to be unchanged after an error, got %+v", late)
+	}
+
+	full := model.WorkData{"2024-08-01": {WorkLogEntries: []model.WorkLog{{StartTime: "6:00 PM", EndTime: "10:00 PM"}}}}
+	if err := mergeImportedRows(full, []importRow{{Line: 10, Date: "2024-08-01", Hours: 2 * time.Hour}}); err != nil {
+		t.Fatalf("Expected a row ending at midnight to be accepted, got %v", err)
+	}
+	if got := full["2024-08-01"].WorkLogEntries[1]; got != (model.WorkLog{StartTime: "22:00", EndTime: "00:00", NextDay: true}) {
+		t.Errorf("Expected the entry to end at midnight the next day, got %+v", got)
+	}
+	err = mergeImportedRows(late, []importRow{{Line: 10, Date: "2024-08-01", Hours: time.Hour}, {Line: 11, Date: "2024-08-01", Hours: time.Minute}})
+	if err == nil || !strings.Contains(err.Error(), "line 11") {
+		t.Errorf("Expected a past midnight error for line 11 after a row ending at midnight, got %v", err)
+	}
+}
+
+func TestImportCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	csvFile := filepath.Join(t.TempDir(), "import.csv")
+	csv := "date,ticket,description,status,hours\n" +
+		"2024-08-03,SCR-4,Imported follow-up,completed,1.5\n" +
+		"2024-08-06,,Imported planning,not started,2\n"
+	if err := os.WriteFile(csvFile, []byte(csv), 0644); err != nil {
+		t.Fatalf("Failed to write CSV file: %v", err)
+	}
+
+	t.Run("dry run leaves the worklog unchanged", func(t *testing.T) {
+		before, err := os.ReadFile(tmpFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := executeCommandText(t, "import", "--file", tmpFile, "--from", csvFile, "--dry-run")
+		if !strings.Contains(output, "Dry run: would import 2 task(s) on 2 date(s)") || !strings.Contains(output, "2024-08-03: SCR-4 Imported follow-up (completed, 1h 30m)") {
+			t.Errorf("Unexpected dry run output:\n%s", output)
+		}
+		after, err := os.ReadFile(tmpFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(before) != string(after) {
+			t.Error("Dry run changed the worklog file")
+		}
+	})
+
+	t.Run("imports the rows", func(t *testing.T) {
+		output := executeCommandText(t, "import", "--file", tmpFile, "--from", csvFile)
+		if !strings.Contains(output, "Imported 2 task(s) on 2 date(s)") {
+			t.Errorf("Unexpected output:\n%s", output)
+		}
+
+		workData, err := loadWorkData(tmpFile)
+		if err != nil {
+			t.Fatalf("loadWorkData failed: %v", err)
+		}
+		day := workData["2024-08-03"]
+		if len(day.Tasks) != 3 || day.Tasks[2].JiraTicket != "SCR-4" {
+			t.Errorf("Expected SCR-4 appended to 2024-08-03, got %+v", day.Tasks)
+		}
+		if last := day.WorkLogEntries[len(day.WorkLogEntries)-1]; last.Ticket != "SCR-4" || last.EndTime == "" {
+			t.Errorf("Expected a work_log entry for SCR-4, got %+v", last)
+		}
+		newDay := workData["2024-08-06"]
+		if len(newDay.Tasks) != 1 || newDay.Tasks[0].Status != model.StatusNotStarted {
+			t.Errorf("Unexpected tasks for 2024-08-06: %+v", newDay.Tasks)
+		}
+		if len(newDay.WorkLogEntries) != 1 || newDay.WorkLogEntries[0] != (model.WorkLog{StartTime: "09:00", EndTime: "11:00"}) {
+			t.Errorf("Unexpected work_log for 2024-08-06: %+v", newDay.WorkLogEntries)
+		}
+	})
+}
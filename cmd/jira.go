@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+)
+
+var (
+	jiraCmd = &cobra.Command{
+		Use:   "jira",
+		Short: "Look up JIRA tickets.",
+		Long:  `Looks up JIRA tickets on the configured instance, whether or not they appear in the work log.`,
+	}
+
+	jiraSummaryCmd = &cobra.Command{
+		Use:   "summary <ticket-or-url>",
+		Short: "Print a ticket's summary and link.",
+		Long:  `Prints a JIRA ticket's key, summary, and browse URL as "KEY: summary (url)". The ticket can be a key like PROJ-123 or a browse URL. The summary is fetched with the JIRA_PAT token and the configured --jira-base-url.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   runJiraSummaryCommand,
+	}
+)
+
+// jiraSummaryJSON is the JSON output of the jira summary command.
+type jiraSummaryJSON struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	URL      string `json:"url"`
+	Status   string `json:"status,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+func init() {
+	jiraSummaryCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for the ticket (text, json).")
+	jiraSummaryCmd.Flags().DurationVar(&jiraTimeout, "jira-timeout", jira.DefaultTimeout, "Timeout for the JIRA API request.")
+
+	jiraCmd.AddCommand(jiraSummaryCmd)
+	rootCmd.AddCommand(jiraCmd)
+}
+
+func runJiraSummaryCommand(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("unsupported jira summary format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if jiraTimeout <= 0 {
+		slog.Error("--jira-timeout must be positive", "jira_timeout", jiraTimeout)
+		os.Exit(exitBadInput)
+	}
+	ticketID := jira.ExtractTicketID(args[0])
+	if ticketID == "" {
+		slog.Error("not a JIRA ticket key or browse URL", "ticket", args[0])
+		os.Exit(exitBadInput)
+	}
+
+	client := newJiraClient()
+	client.Fields = nil
+	info, err := client.FetchTicketSummary(ticketID)
+	if err != nil {
+		slog.Error("failed to fetch JIRA ticket", "error", err, "ticket", ticketID)
+		os.Exit(1)
+	}
+	if info.Summary == "" {
+		slog.Warn("no JIRA summary fetched, set JIRA_PAT or the instance token to fetch it", "ticket", ticketID)
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(jiraSummaryJSON{
+			Key:      info.Key,
+			Summary:  info.Summary,
+			URL:      info.URL,
+			Status:   info.Status,
+			Assignee: info.Assignee,
+		}, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal JIRA ticket as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+	if info.Summary == "" {
+		fmt.Fprintf(out, "%s (%s)\n", info.Key, info.URL)
+		return
+	}
+	fmt.Fprintf(out, "%s: %s (%s)\n", info.Key, info.Summary, info.URL)
+}
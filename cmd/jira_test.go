@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJiraSummaryCommand(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"key": "PROJ-7", "fields": {"summary": "Fix login", "status": {"name": "In Progress"}, "assignee": null}}`))
+	}))
+	defer server.Close()
+	t.Setenv("JIRA_PAT", "secret")
+
+	t.Run("ticket key", func(t *testing.T) {
+		requests = nil
+		output := executeCommandText(t, "jira", "summary", "PROJ-7", "--jira-base-url", server.URL)
+		want := "PROJ-7: Fix login (" + server.URL + "/browse/PROJ-7)\n"
+		if output != want {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", want, output)
+		}
+		if len(requests) != 1 || requests[0] != "/rest/api/2/issue/PROJ-7" {
+			t.Errorf("Expected one request for PROJ-7, got %q", requests)
+		}
+	})
+
+	t.Run("browse URL as json", func(t *testing.T) {
+		output := executeCommandText(t, "jira", "summary", server.URL+"/browse/PROJ-7", "--jira-base-url", server.URL, "--format", "json")
+		var got jiraSummaryJSON
+		if err := json.Unmarshal([]byte(output), &got); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		want := jiraSummaryJSON{Key: "PROJ-7", Summary: "Fix login", URL: server.URL + "/browse/PROJ-7", Status: "In Progress"}
+		if got != want {
+			t.Errorf("Got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("without a token", func(t *testing.T) {
+		requests = nil
+		t.Setenv("JIRA_PAT", "")
+		output := executeCommandText(t, "jira", "summary", "PROJ-7", "--jira-base-url", server.URL)
+		if want := "PROJ-7 (" + server.URL + "/browse/PROJ-7)\n"; output != want {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", want, output)
+		}
+		if len(requests) != 0 {
+			t.Errorf("Expected no requests without a token, got %q", requests)
+		}
+	})
+}
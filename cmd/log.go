@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+var (
+	logCmd = &cobra.Command{
+		Use:   "log",
+		Short: "Record work_log start and stop times.",
+		Long:  `Records work_log entries for today using the current time, rounded to the minute.`,
+	}
+
+	logStartCmd = &cobra.Command{
+		Use:   "start",
+		Short: "Start a work_log entry at the current time.",
+		Run:   runLogStartCommand,
+	}
+
+	logStopCmd = &cobra.Command{
+		Use:   "stop",
+		Short: "End today's open work_log entry at the current time.",
+		Run:   runLogStopCommand,
+	}
+)
+
+func init() {
+	logCmd.AddCommand(logStartCmd)
+	logCmd.AddCommand(logStopCmd)
+	rootCmd.AddCommand(logCmd)
+}
+
+func runLogStartCommand(cmd *cobra.Command, args []string) {
+	updateWorkLog(cmd, startWorkLog)
+}
+
+func runLogStopCommand(cmd *cobra.Command, args []string) {
+	updateWorkLog(cmd, stopWorkLog)
+}
+
+// startWorkLog appends an open work_log entry starting at now, refusing if one is already open.
+func startWorkLog(workData model.WorkData, now time.Time) (string, error) {
+	date := now.Format("2006-01-02")
+	dailyLog := workData[date]
+	if i := openWorkLogIndex(dailyLog); i >= 0 {
+		return "", fmt.Errorf("an entry started at %s is still open, run 'log stop' first", dailyLog.WorkLogEntries[i].StartTime)
+	}
+
+	start := now.Format("15:04")
+	dailyLog.WorkLogEntries = append(dailyLog.WorkLogEntries, model.WorkLog{StartTime: start})
+	workData[date] = dailyLog
+	return fmt.Sprintf("⏱️  Started work at %s on %s", start, date), nil
+}
+
+// stopWorkLog sets the end time of the last open work_log entry for now's date.
+func stopWorkLog(workData model.WorkData, now time.Time) (string, error) {
+	date := now.Format("2006-01-02")
+	dailyLog := workData[date]
+	i := openWorkLogIndex(dailyLog)
+	if i < 0 {
+		return "", fmt.Errorf("no open work_log entry for %s, run 'log start' first", date)
+	}
+
+	entry := &dailyLog.WorkLogEntries[i]
+	entry.EndTime = now.Format("15:04")
+	workData[date] = dailyLog
+	return fmt.Sprintf("✅ Logged %s-%s on %s", entry.StartTime, entry.EndTime, date), nil
+}
+
+// updateWorkLog loads the work log, applies update with the current time rounded
+// to the minute, and saves the result.
+func updateWorkLog(cmd *cobra.Command, update func(workData model.WorkData, now time.Time) (string, error)) {
+	filePath, err := singleWorkLogPath()
+	if err != nil {
+		slog.Error("cannot update work log", "error", err)
+		os.Exit(1)
+	}
+
+	workData, err := loadWorkData(filePath)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+	if workData == nil {
+		workData = make(model.WorkData)
+	}
+
+	message, err := update(workData, nowFunc().Round(time.Minute))
+	if err != nil {
+		slog.Error("failed to update work log", "error", err)
+		os.Exit(1)
+	}
+
+	if err := saveWorkData(filePath, workData); err != nil {
+		slog.Error("failed to save work log file", "error", err, "path", filePath)
+		os.Exit(1)
+	}
+	fmt.Fprintln(statusWriter(cmd.OutOrStdout()), message)
+}
+
+// openWorkLogIndex returns the index of the last work_log entry without an end time, or -1.
+func openWorkLogIndex(dailyLog model.DailyLog) int {
+	for i := len(dailyLog.WorkLogEntries) - 1; i >= 0; i-- {
+		if dailyLog.WorkLogEntries[i].EndTime == "" {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestLogCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+	t.Cleanup(func() { nowFunc = time.Now })
+
+	// 09:00:40 rounds up to 09:01
+	nowFunc = func() time.Time { return time.Date(2024, 8, 5, 9, 0, 40, 0, time.Local) }
+	output := executeCommandText(t, "log", "start", "--file", path)
+	if !strings.Contains(output, "Started work at 09:01 on 2024-08-05") {
+		t.Errorf("Unexpected start output: %q", output)
+	}
+
+	// 12:29:20 rounds down to 12:29
+	nowFunc = func() time.Time { return time.Date(2024, 8, 5, 12, 29, 20, 0, time.Local) }
+	output = executeCommandText(t, "log", "stop", "--file", path)
+	if !strings.Contains(output, "Logged 09:01-12:29 on 2024-08-05") {
+		t.Errorf("Unexpected stop output: %q", output)
+	}
+
+	output = executeCommandText(t, "hours", "--file", path)
+	expected := "Total hours worked from 2024-08-05 to 2024-08-05: 3.47\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+}
+
+func TestStartStopWorkLogErrors(t *testing.T) {
+	now := time.Date(2024, 8, 5, 14, 0, 0, 0, time.Local)
+
+	t.Run("stop without an open entry", func(t *testing.T) {
+		workData := model.WorkData{
+			"2024-08-05": {WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "12:00"}}},
+		}
+		if _, err := stopWorkLog(workData, now); err == nil || !strings.Contains(err.Error(), "no open work_log entry") {
+			t.Errorf("Expected an error about no open entry, got %v", err)
+		}
+	})
+
+	t.Run("start while an entry is open", func(t *testing.T) {
+		workData := model.WorkData{
+			"2024-08-05": {WorkLogEntries: []model.WorkLog{{StartTime: "13:00"}}},
+		}
+		if _, err := startWorkLog(workData, now); err == nil || !strings.Contains(err.Error(), "still open") {
+			t.Errorf("Expected an error about the open entry, got %v", err)
+		}
+		if len(workData["2024-08-05"].WorkLogEntries) != 1 {
+			t.Error("Work log should not change when start fails")
+		}
+	})
+}
@@ -1,44 +1,176 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	htmltemplate "html/template"
 	"io"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"github.com/bryan-cox/taskledger/internal/clipboard"
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/hours"
+	"github.com/bryan-cox/taskledger/internal/ical"
 	"github.com/bryan-cox/taskledger/internal/jira"
 	"github.com/bryan-cox/taskledger/internal/model"
 	"github.com/bryan-cox/taskledger/internal/report"
+	"github.com/bryan-cox/taskledger/internal/slack"
 )
 
 // --- CLI Flags ---
 
 var (
-	filePath      string
+	filePaths     []string
 	startDate     string
 	endDate       string
 	copyHTML      bool
+	copyText      bool
 	htmlFile      string
 	showHTML      bool
 	openHTML      bool
 	jiraSummaries string
+	outputFormat  string
+	jiraBaseURL   string
+	jiraPATFile   string
+	jiraPATCmd    string
+	jiraWorkers   int
+	jiraCacheTTL  time.Duration
+	jiraTimeout   time.Duration
+	jiraStatus    bool
+	jiraFields    []string
+	htmlTheme     string
+	outputFile    string
+	noJiraCache   bool
+	forceInit     bool
+	strictHours   bool
+	mergeOverlaps bool
+	groupBy       string
+	lastDays      int
+	sinceValue    string
+	slackWebhook  string
+	dryRun        bool
+	byTicket      bool
+	timezone      string
+	durationStyle string
+	icalFile      string
+	warnEmpty     bool
+	includeEmpty  bool
+	countDupes    bool
+	collapseDone  bool
+	mergeStatus   bool
+	forceColor    bool
+	noColor       bool
+	ticketFilter  []string
+	tagFilter     []string
+	excludeTicket []string
+	excludeStatus []string
+	weekStartName string
+	minDuration   time.Duration
+	roundTo       string
+	roundMode     string
+	fileFormat    string
+	summaryOnly   bool
+	listPRs       bool
+	redact        bool
+	redactMap     bool
+	showPrivate   bool
+	endExclusive  bool
+	dateList      []string
+	withHours     bool
+	hoursDetail   bool
+	templatePath  string
+	htmlTmplPath  string
+	byProject     bool
+	headerDone    string
+	headerNext    string
+	headerBlocked string
+	lenient       bool
+	quiet         bool
+	failOnBlocker bool
+	prOrder       string
+	flatNonFeat   bool
+	logLevel      string
+	logFormat     string
+	expectedHours float64
+	copyBoth      bool
+	wrapWidth     int
+	staleAfter    string
+	outputDir     string
+)
+
+// weekStart is the first day of the week parsed from --week-start.
+var weekStart = time.Monday
+
+// Supported report output formats.
+const (
+	formatText       = "text"
+	formatMarkdown   = "markdown"
+	formatJSON       = "json"
+	formatCSV        = "csv"
+	formatAsciiDoc   = "adoc"
+	formatSlack      = "slack"
+	formatConfluence = "confluence"
+)
+
+// Supported work log file formats for --file-format.
+const (
+	fileFormatYAML = "yaml"
+	fileFormatJSON = "json"
+)
+
+// Supported styles for durations printed by the hours command.
+const (
+	durationDecimal = "decimal" // 7.50
+	durationHM      = "hm"      // 7h 30m
+	durationISO8601 = "iso8601" // PT7H30M
+)
+
+// Supported formats for --log-format.
+const (
+	logFormatJSON = "json"
+	logFormatText = "text"
+)
+
+// roundingNone is the --rounding value that leaves work_log entries unrounded.
+const roundingNone = "none"
+
+// Exit codes used by the report and hours commands so scripts can tell an empty
+// date range apart from invalid input and from failures reading the work log.
+const (
+	exitFailure  = 1 // IO, parse, or other failures
+	exitNoData   = 2 // no work log entries in the requested range
+	exitBadInput = 3 // invalid flags or date range
+	exitBlocked  = 4 // report --fail-on-blocker found blocked tasks
+)
+
+// Sentinel errors returned when selecting the dates to report on.
+var (
+	ErrNoData       = errors.New("no data found")
+	ErrBadDateRange = errors.New("invalid date range")
 )
 
 // --- Cobra Command Definitions ---
 
 var (
 	rootCmd = &cobra.Command{
-		Use:   "taskledger",
-		Short: "A CLI tool to track work and generate reports from a YAML log.",
-		Long:  `TaskLedger is a command-line interface for parsing a work log YAML file to calculate hours worked and generate status reports.`,
+		Use:              "taskledger",
+		Short:            "A CLI tool to track work and generate reports from a YAML log.",
+		Long:             `TaskLedger is a command-line interface for parsing a work log YAML file to calculate hours worked and generate status reports.`,
+		PersistentPreRun: setupCommand,
 	}
 
 	hoursCmd = &cobra.Command{
@@ -71,18 +203,94 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&filePath, "file", "worklog.yml", "Path to the YAML work log file.")
+	rootCmd.PersistentFlags().StringSliceVar(&filePaths, "file", []string{"worklog.yml"}, "Path to the YAML work log file. Repeat or comma-separate to merge several files.")
+	rootCmd.PersistentFlags().StringVar(&fileFormat, "file-format", "", "Work log file format (yaml, json). Defaults to json for .json files and yaml otherwise.")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress status messages such as save and clipboard confirmations. Report content, warnings, and errors are still printed.")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Minimum level of log messages written to standard error (debug, info, warn, error).")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormatJSON, "Format of log messages written to standard error (json, text).")
+	rootCmd.PersistentFlags().BoolVar(&lenient, "lenient", false, "Skip days that fail to parse, with a warning, instead of failing the whole file. Commands that write the work log ignore it.")
+	rootCmd.PersistentFlags().StringVar(&weekStartName, "week-start", model.WeekStartMonday, "First day of the week for --group-by week and relative week ranges (monday, sunday).")
+	rootCmd.PersistentFlags().StringVar(&jiraBaseURL, "jira-base-url", "", "JIRA instance base URL (defaults to $JIRA_BASE_URL, then "+jira.DefaultBaseURL+").")
+	rootCmd.PersistentFlags().StringVar(&jiraPATFile, "jira-pat-file", "", "Read the JIRA personal access token from this file instead of $JIRA_PAT.")
+	rootCmd.PersistentFlags().StringVar(&jiraPATCmd, "jira-pat-cmd", "", "Run this command and use its output as the JIRA personal access token, e.g. \"pass show jira\". --jira-pat-file wins over it, and it wins over $JIRA_PAT.")
 
-	hoursCmd.Flags().StringVar(&startDate, "start-date", "", "Start date (YYYY-MM-DD).")
-	hoursCmd.Flags().StringVar(&endDate, "end-date", "", "End date (YYYY-MM-DD).")
+	hoursCmd.Flags().StringVar(&startDate, "start-date", "", "Start date (YYYY-MM-DD, or today, yesterday, this-week, last-week, this-month, last-month).")
+	hoursCmd.Flags().StringVar(&endDate, "end-date", "", "End date (YYYY-MM-DD, or a relative keyword like --start-date).")
+	hoursCmd.Flags().BoolVar(&endExclusive, "end-exclusive", false, "Leave the --end-date day out of the range, e.g. --start-date 2024-08-05 --end-date 2024-08-12 for one week.")
+	hoursCmd.Flags().StringSliceVar(&dateList, "dates", nil, "Use exactly these dates (comma-separated YYYY-MM-DD) instead of a date range. Dates without entries are skipped.")
+	hoursCmd.Flags().IntVar(&lastDays, "last", 0, "Use the N most recent logged dates instead of a date range.")
+	hoursCmd.Flags().StringVar(&sinceValue, "since", "", "Start N days, weeks, or months ago and run through today (e.g. 7d, 2w, 1m).")
+	hoursCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for hours (text, csv, json).")
+	hoursCmd.Flags().BoolVar(&strictHours, "strict", false, "Treat overlapping work_log entries as an error.")
+	hoursCmd.Flags().BoolVar(&mergeOverlaps, "merge-overlaps", false, "Merge overlapping work_log entries before summing so overlapping time is counted once.")
+	hoursCmd.Flags().StringVar(&groupBy, "group-by", "", "Bucket hours by day, week (ISO week, or weeks starting on --week-start), or month.")
+	hoursCmd.Flags().BoolVar(&byTicket, "by-ticket", false, "Break hours down by the ticket set on each work_log entry.")
+	hoursCmd.Flags().StringVar(&timezone, "timezone", "", "IANA time zone the work log times are in (e.g. America/New_York, UTC).")
+	hoursCmd.Flags().StringVar(&durationStyle, "duration-format", durationDecimal, "How text output shows durations (decimal, hm, iso8601).")
+	hoursCmd.Flags().DurationVar(&minDuration, "min-duration", 0, "Ignore work_log entries shorter than this (e.g. 5m). Zero keeps every entry.")
+	hoursCmd.Flags().StringVar(&roundTo, "rounding", roundingNone, "Round each work_log entry to a multiple of this duration before summing (none, 15m, 30m, 1h).")
+	hoursCmd.Flags().StringVar(&roundMode, "round-mode", hours.RoundNearest, "How --rounding rounds each entry (up, down, nearest).")
+	hoursCmd.Flags().Float64Var(&expectedHours, "expected-hours", 8, "Hours expected on a standard work day; half days expect half, pto and holiday days none.")
+	hoursCmd.Flags().StringVar(&icalFile, "ical-file", "", "Also export the work_log entries as iCalendar events to this .ics file.")
 
-	reportCmd.Flags().StringVar(&startDate, "start-date", "", "Start date (YYYY-MM-DD).")
-	reportCmd.Flags().StringVar(&endDate, "end-date", "", "End date (YYYY-MM-DD).")
+	reportCmd.Flags().StringVar(&startDate, "start-date", "", "Start date (YYYY-MM-DD, or today, yesterday, this-week, last-week, this-month, last-month).")
+	reportCmd.Flags().StringVar(&endDate, "end-date", "", "End date (YYYY-MM-DD, or a relative keyword like --start-date).")
+	reportCmd.Flags().BoolVar(&endExclusive, "end-exclusive", false, "Leave the --end-date day out of the range, e.g. --start-date 2024-08-05 --end-date 2024-08-12 for one week.")
+	reportCmd.Flags().StringSliceVar(&dateList, "dates", nil, "Use exactly these dates (comma-separated YYYY-MM-DD) instead of a date range. Dates without entries are skipped.")
+	reportCmd.Flags().IntVar(&lastDays, "last", 0, "Use the N most recent logged dates instead of a date range.")
+	reportCmd.Flags().StringVar(&sinceValue, "since", "", "Start N days, weeks, or months ago and run through today (e.g. 7d, 2w, 1m).")
+	reportCmd.Flags().StringArrayVar(&ticketFilter, "ticket", nil, "Only include tasks for this JIRA ticket key or URL (repeatable).")
+	reportCmd.Flags().StringArrayVar(&tagFilter, "tag", nil, "Only include tasks carrying this tag (repeatable; any tag matches).")
+	reportCmd.Flags().StringArrayVar(&excludeTicket, "exclude-ticket", nil, "Leave out tasks for this JIRA ticket key or URL (repeatable). Applied after --ticket and --tag.")
+	reportCmd.Flags().StringArrayVar(&excludeStatus, "exclude-status", nil, "Leave out tasks with this status, e.g. \"not started\" (repeatable, case-insensitive).")
+	reportCmd.Flags().StringVar(&groupBy, "group-by", "", "Group the completed section by ticket (the default) or tag, or list each day's tasks under a date header with date. Tasks without tags stay grouped by ticket. date supports the text format only.")
+	reportCmd.Flags().BoolVar(&byProject, "by-project", false, "Print a sub-report per project (the task's project field, or its JIRA ticket prefix). Text format only.")
+	reportCmd.Flags().BoolVar(&forceColor, "color", false, "Color the text report even when standard output is not a terminal.")
+	reportCmd.Flags().BoolVar(&noColor, "no-color", false, "Never color the text report (also disabled by the NO_COLOR environment variable).")
+	reportCmd.Flags().BoolVar(&summaryOnly, "summary-only", false, "List only the tickets in each section (with JIRA summaries when available), without descriptions or PR links. Text format only.")
+	reportCmd.Flags().BoolVar(&listPRs, "prs", false, "List every GitHub PR referenced by a task in the range, deduplicated, with its state when GITHUB_TOKEN is set (text, json).")
+	reportCmd.Flags().StringVar(&templatePath, "template", "", "Render the text report with this Go text/template file instead of the built-in layout.")
+	reportCmd.Flags().StringVar(&headerDone, "header-completed", "", "Title of the completed section in every format (defaults to the built-in title).")
+	reportCmd.Flags().StringVar(&headerNext, "header-nextup", "", "Title of the next up section in every format (defaults to the built-in title).")
+	reportCmd.Flags().StringVar(&headerBlocked, "header-blocked", "", "Title of the blocked section in every format (defaults to the built-in title).")
+	reportCmd.Flags().BoolVar(&includeEmpty, "include-empty-sections", false, "Print the completed, next up, and blocked headers even when a section has no entries.")
+	reportCmd.Flags().BoolVar(&countDupes, "count-duplicates", false, "Annotate descriptions logged more than once for a ticket with their count, e.g. \"code review (x3)\".")
+	reportCmd.Flags().StringVar(&prOrder, "pr-order", report.PROrderURL, "Order of each ticket's PR links in every format: url sorts them by URL, chrono keeps the order they were first logged in.")
+	reportCmd.Flags().BoolVar(&collapseDone, "collapse-completed", false, "Show only the most recent description of each completed ticket, like the next up section. PR links still cover every day.")
+	reportCmd.Flags().StringVar(&staleAfter, "stale-after", "", "List tickets whose latest task is still in progress more than this long before the end of the range (e.g. 7d, 2w, 1m) in a \"Possibly stale\" section.")
+	reportCmd.Flags().IntVar(&wrapWidth, "wrap", 0, "Wrap description and blocker bullets of the text report at N columns, aligning continuation lines under the text. Zero does not wrap.")
+	reportCmd.Flags().BoolVar(&flatNonFeat, "no-nonfeature-grouping", false, "List non-feature work (tasks without a JIRA ticket, NO-JIRA, and free-text tickets) inline with the other tickets in sorted order, instead of under a \"Non-feature work\" entry at the end of each section.")
+	reportCmd.Flags().BoolVar(&mergeStatus, "merge-same-ticket-across-status", false, "List a ticket only in the section of its latest status: next up tickets are left out of the completed section.")
+	reportCmd.Flags().BoolVar(&showPrivate, "include-private", false, "Include tasks marked private: true, which are otherwise left out of the report.")
+	reportCmd.Flags().BoolVar(&withHours, "with-hours", false, "Append the total hours worked over the same date range (text, markdown, adoc, confluence, and HTML).")
+	reportCmd.Flags().BoolVar(&hoursDetail, "show-hours-detail", false, "Append each day's work_log time ranges and total (text, markdown, adoc, confluence, and HTML, where each day is a table).")
+	reportCmd.Flags().StringVar(&durationStyle, "duration-format", durationDecimal, "How --with-hours and --show-hours-detail show totals (decimal, hm, iso8601).")
+	reportCmd.Flags().BoolVar(&redact, "redact", false, "Replace JIRA tickets with TICKET-N and PR links with PR-N placeholders for sharing outside the company. PR titles are not fetched.")
+	reportCmd.Flags().BoolVar(&redactMap, "redact-map", false, "With --redact, print each placeholder and the identifier it replaced to standard error.")
+	reportCmd.Flags().BoolVar(&failOnBlocker, "fail-on-blocker", false, "Exit with code 4 after printing the report when the blocked section has entries, e.g. to fail a CI job.")
+	reportCmd.Flags().BoolVar(&warnEmpty, "warn-empty", false, "Log completed tasks that have no description, PR, or blocker and are left out of the report.")
 	reportCmd.Flags().BoolVar(&copyHTML, "copy-html", false, "Attempt to copy the report as formatted HTML to clipboard.")
+	reportCmd.Flags().BoolVar(&copyText, "copy-text", false, "Copy the rendered report to the clipboard as plain text.")
+	reportCmd.Flags().BoolVar(&copyBoth, "append-clipboard", false, "Copy the report to the clipboard as both HTML and plain text (the rendered --format), letting the pasting app pick.")
+	reportCmd.Flags().StringVar(&slackWebhook, "slack-webhook", "", "Post the report to this Slack incoming webhook URL (defaults to $SLACK_WEBHOOK_URL).")
+	reportCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the Slack webhook payload instead of sending it.")
+	reportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the report in the selected --format to this file instead of standard output.")
+	reportCmd.Flags().StringVar(&outputDir, "output-dir", "", "Write the report as text, HTML, and JSON (plus an hours CSV with --with-hours) to files named after the date range in this directory, instead of standard output.")
 	reportCmd.Flags().StringVar(&htmlFile, "html-file", "", "Save the report as HTML to the specified file.")
 	reportCmd.Flags().BoolVar(&showHTML, "show-html", false, "Display the HTML content in the terminal.")
-	reportCmd.Flags().BoolVar(&openHTML, "open-html", false, "Automatically open the HTML file in the default browser after saving.")
+	reportCmd.Flags().BoolVar(&openHTML, "open-html", false, "Open the HTML report in the default browser (saved to a temporary file unless --html-file is set).")
+	reportCmd.Flags().StringVar(&htmlTheme, "theme", report.ThemePlain, "HTML theme (plain, light, dark). plain keeps Slack-friendly unstyled markup.")
+	reportCmd.Flags().StringVar(&htmlTmplPath, "html-template", "", "Render HTML output with this Go html/template file instead of the built-in markup.")
 	reportCmd.Flags().StringVar(&jiraSummaries, "jira-summaries", "", "Path to JSON file with pre-fetched JIRA ticket summaries.")
+	reportCmd.Flags().IntVar(&jiraWorkers, "jira-concurrency", jira.DefaultConcurrency, "Maximum number of JIRA tickets to fetch in parallel.")
+	reportCmd.Flags().DurationVar(&jiraCacheTTL, "jira-cache-ttl", jira.DefaultCacheTTL, "How long cached JIRA ticket summaries stay fresh.")
+	reportCmd.Flags().DurationVar(&jiraTimeout, "jira-timeout", jira.DefaultTimeout, "Timeout for each JIRA API request.")
+	reportCmd.Flags().StringSliceVar(&jiraFields, "jira-fields", nil, "Extra JIRA field IDs to fetch, e.g. customfield_10002,customfield_12310 (comma-separated). Shown in HTML output and available to templates.")
+	reportCmd.Flags().BoolVar(&jiraStatus, "jira-show-status", false, "Show each ticket's JIRA status and assignee in HTML output.")
+	reportCmd.Flags().BoolVar(&noJiraCache, "no-jira-cache", false, "Always fetch JIRA ticket summaries from the API, bypassing the on-disk cache.")
+	reportCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for the report (text, markdown, adoc, confluence, json, slack).")
+
+	initCmd.Flags().BoolVar(&forceInit, "force", false, "Overwrite an existing work log file without asking.")
 
 	rootCmd.AddCommand(hoursCmd)
 	rootCmd.AddCommand(reportCmd)
@@ -99,83 +307,1011 @@ func main() {
 
 // --- Command Handlers ---
 
+// newLogHandler returns a slog handler writing to w in the given format (json or
+// text) that drops messages below level (debug, info, warn, or error).
+func newLogHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var opts slog.HandlerOptions
+	switch strings.ToLower(level) {
+	case "debug":
+		opts.Level = slog.LevelDebug
+	case "info":
+		opts.Level = slog.LevelInfo
+	case "warn":
+		opts.Level = slog.LevelWarn
+	case "error":
+		opts.Level = slog.LevelError
+	default:
+		return nil, fmt.Errorf("unsupported log level %q, use debug, info, warn, or error", level)
+	}
+
+	switch format {
+	case logFormatJSON:
+		return slog.NewJSONHandler(w, &opts), nil
+	case logFormatText:
+		return slog.NewTextHandler(w, &opts), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q, use json or text", format)
+	}
+}
+
+// setupCommand applies persistent configuration before any subcommand runs.
+func setupCommand(cmd *cobra.Command, args []string) {
+	path, err := findConfigFile(configPath)
+	if err != nil {
+		slog.Error("failed to find config file", "error", err)
+		os.Exit(1)
+	}
+	var instances map[string]jira.Instance
+	if path != "" {
+		config, err := loadConfig(path)
+		if err != nil {
+			slog.Error("failed to load config file", "error", err, "path", path)
+			os.Exit(1)
+		}
+		if err := applyConfig(cmd, config); err != nil {
+			slog.Error("failed to apply config file", "error", err, "path", path)
+			os.Exit(1)
+		}
+		if instances, err = jiraInstances(config); err != nil {
+			slog.Error("failed to apply config file", "error", err, "path", path)
+			os.Exit(1)
+		}
+	}
+
+	// Log settings may come from the config file, so the logger is replaced only now
+	handler, err := newLogHandler(os.Stderr, logLevel, logFormat)
+	if err != nil {
+		slog.Error("invalid logging flags", "error", err)
+		os.Exit(exitBadInput)
+	}
+	slog.SetDefault(slog.New(handler))
+
+	switch fileFormat {
+	case "", fileFormatYAML, fileFormatJSON:
+	default:
+		slog.Error("unsupported work log file format, use yaml or json", "file_format", fileFormat)
+		os.Exit(exitBadInput)
+	}
+
+	start, err := model.ParseWeekStart(weekStartName)
+	if err != nil {
+		slog.Error("invalid --week-start", "error", err)
+		os.Exit(exitBadInput)
+	}
+	weekStart = start
+
+	baseURL := jiraBaseURL
+	if baseURL == "" {
+		baseURL = os.Getenv("JIRA_BASE_URL")
+	}
+	if baseURL == "" {
+		baseURL = jira.DefaultBaseURL
+	}
+	if err := jira.SetBaseURL(baseURL); err != nil {
+		slog.Error("failed to configure JIRA", "error", err)
+		os.Exit(1)
+	}
+	if err := jira.SetInstances(instances); err != nil {
+		slog.Error("failed to configure JIRA", "error", err)
+		os.Exit(1)
+	}
+	jira.TokenFile = ""
+	if jiraPATFile != "" {
+		jira.TokenFile = expandPath(jiraPATFile)
+	}
+	jira.TokenCommand = jiraPATCmd
+}
+
 func runHoursCommand(cmd *cobra.Command, args []string) {
-	workData, err := loadWorkData(filePath)
+	if outputFormat != formatText && outputFormat != formatCSV && outputFormat != formatJSON {
+		slog.Error("unsupported hours format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	switch durationStyle {
+	case durationDecimal, durationHM, durationISO8601:
+	default:
+		slog.Error("unsupported duration format, use decimal, hm, or iso8601", "duration_format", durationStyle)
+		os.Exit(exitBadInput)
+	}
+	if minDuration < 0 {
+		slog.Error("--min-duration cannot be negative", "min_duration", minDuration)
+		os.Exit(exitBadInput)
+	}
+	rounding, err := parseRounding(roundTo)
+	if err != nil {
+		slog.Error("invalid --rounding", "error", err, "rounding", roundTo)
+		os.Exit(exitBadInput)
+	}
+	switch roundMode {
+	case hours.RoundUp, hours.RoundDown, hours.RoundNearest:
+	default:
+		slog.Error("unsupported round mode, use up, down, or nearest", "round_mode", roundMode)
+		os.Exit(exitBadInput)
+	}
+	if expectedHours <= 0 {
+		slog.Error("--expected-hours must be positive", "expected_hours", expectedHours)
+		os.Exit(exitBadInput)
+	}
+
+	var location *time.Location
+	zoneSuffix := ""
+	if timezone != "" {
+		var err error
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			slog.Error("unknown time zone, use an IANA name like America/New_York", "timezone", timezone, "error", err)
+			os.Exit(exitBadInput)
+		}
+		zoneSuffix = fmt.Sprintf(" (%s)", location)
+	}
+
+	workData, err := loadAndMergeWorkData(filePaths)
 	if err != nil {
-		slog.Error("failed to load work log file", "error", err, "path", filePath)
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
 		os.Exit(1)
 	}
 
-	dates, err := getDatesInRange(workData, startDate, endDate)
+	dates, err := selectDates(workData, startDate, endDate, sinceValue, lastDays)
 	if err != nil {
-		slog.Error("failed to process date range", "error", err, "start_date", startDate, "end_date", endDate)
+		slog.Error("failed to process date range", "error", err, "start_date", startDate, "end_date", endDate, "since", sinceValue, "last", lastDays)
+		os.Exit(dateRangeExitCode(err))
+	}
+
+	hoursOpts := hours.Options{MergeOverlaps: mergeOverlaps, Location: location, MinDuration: minDuration, Rounding: rounding, RoundMode: roundMode}
+	workData = hours.DropShortEntries(workData, dates, hoursOpts)
+
+	overlaps := hours.FindOverlaps(workData, dates, hoursOpts)
+	for _, overlap := range overlaps {
+		slog.Warn("overlapping work log entries", "date", overlap.Date, "first", overlap.First, "second", overlap.Second)
+	}
+	if strictHours && len(overlaps) > 0 {
+		slog.Error("work log contains overlapping entries", "count", len(overlaps))
 		os.Exit(1)
 	}
 
-	var totalDuration time.Duration
-	for _, date := range dates {
-		dailyLog, exists := workData[date]
-		if !exists {
-			continue
+	dailyTotals := hours.DailyTotals(workData, dates, hoursOpts)
+
+	if icalFile != "" {
+		if err := writeICalFile(icalFile, workData, dates, location); err != nil {
+			slog.Error("failed to write iCalendar file", "error", err, "path", icalFile)
+			os.Exit(1)
 		}
-		for _, logEntry := range dailyLog.WorkLogEntries {
-			start, err1 := time.Parse("15:04", logEntry.StartTime)
-			end, err2 := time.Parse("15:04", logEntry.EndTime)
-			if err1 != nil || err2 != nil {
-				slog.Warn("could not parse time entry, skipping", "date", date, "entry", logEntry)
-				continue
+		slog.Info("saved work log as iCalendar", "path", icalFile)
+	}
+
+	if groupBy != "" && byTicket {
+		slog.Error("--group-by and --by-ticket cannot be combined")
+		os.Exit(exitBadInput)
+	}
+
+	if outputFormat == formatJSON {
+		if groupBy != "" || byTicket {
+			slog.Error("--format json lists every day and cannot be combined with --group-by or --by-ticket")
+			os.Exit(exitBadInput)
+		}
+		data, err := hours.MarshalJSON(workData, dates, dailyTotals, hoursOpts)
+		if err != nil {
+			slog.Error("failed to marshal hours as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return
+	}
+
+	var grouping string
+	var buckets []hours.Bucket
+	switch {
+	case groupBy != "":
+		grouping = groupBy
+		buckets, err = hours.GroupTotals(workData, dates, dailyTotals, groupBy, weekStart)
+		if err != nil {
+			slog.Error("failed to group hours", "error", err, "group_by", groupBy)
+			os.Exit(1)
+		}
+	case byTicket:
+		// Without any ticketed entries there is nothing to break down, so fall
+		// back to the plain total
+		ticketBuckets := hours.TicketTotals(workData, dates, hoursOpts)
+		if len(ticketBuckets) > 1 || (len(ticketBuckets) == 1 && ticketBuckets[0].Label != hours.UnassignedTicket) {
+			grouping = "ticket"
+			buckets = ticketBuckets
+		}
+	}
+
+	if grouping != "" {
+		if outputFormat == formatCSV {
+			if err := hours.WriteGroupedCSV(cmd.OutOrStdout(), grouping, buckets); err != nil {
+				slog.Error("failed to write hours CSV", "error", err)
+				os.Exit(1)
 			}
-			totalDuration += end.Sub(start)
+			return
+		}
+		var total time.Duration
+		cmd.Printf("Hours worked by %s from %s to %s%s:\n", grouping, dates[0], dates[len(dates)-1], zoneSuffix)
+		for _, bucket := range buckets {
+			cmd.Printf("  %s: %s\n", bucket.Label, formatDuration(bucket.Duration, durationStyle))
+			total += bucket.Duration
 		}
+		cmd.Printf("Total: %s\n", formatDuration(total, durationStyle))
+		printExpectedHours(cmd, workData, dates, total)
+		return
 	}
 
-	cmd.Printf("Total hours worked from %s to %s: %.2f\n", dates[0], dates[len(dates)-1], totalDuration.Hours())
+	if outputFormat == formatCSV {
+		if err := hours.WriteCSV(cmd.OutOrStdout(), workData, dates, dailyTotals); err != nil {
+			slog.Error("failed to write hours CSV", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	totalDuration := hours.Total(dailyTotals, dates)
+	cmd.Printf("Total hours worked from %s to %s%s: %s\n", dates[0], dates[len(dates)-1], zoneSuffix, formatDuration(totalDuration, durationStyle))
+	printExpectedHours(cmd, workData, dates, totalDuration)
+}
+
+// printExpectedHours prints the logged, expected, and delta hours for the range
+// when --expected-hours is set or any of the dates has a day_type, so plain work
+// logs keep their single total line.
+func printExpectedHours(cmd *cobra.Command, workData model.WorkData, dates []string, logged time.Duration) {
+	if !cmd.Flags().Changed("expected-hours") && !hours.HasDayTypes(workData, dates) {
+		return
+	}
+	standard := time.Duration(expectedHours * float64(time.Hour))
+	expected := hours.Expected(workData, dates, standard)
+	delta, sign := logged-expected, "+"
+	if delta < 0 {
+		delta, sign = -delta, "-"
+	}
+	cmd.Printf("Logged: %s, expected: %s, delta: %s%s\n", formatDuration(logged, durationStyle), formatDuration(expected, durationStyle), sign, formatDuration(delta, durationStyle))
+}
+
+// writeICalFile exports the work log entries on the given dates to an .ics file.
+func writeICalFile(path string, workData model.WorkData, dates []string, location *time.Location) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := ical.Write(f, workData, dates, location, nowFunc()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// parseRounding parses a --rounding value: none, or a positive duration such as
+// 15m, 30m, or 1h.
+func parseRounding(value string) (time.Duration, error) {
+	if value == roundingNone || value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("rounding must be positive, got %s", value)
+	}
+	return d, nil
+}
+
+// formatDuration renders a duration in one of the --duration-format styles:
+// decimal hours (7.50), hours and minutes (7h 30m), or an ISO 8601 duration
+// (PT7H30M). Totals longer than a day keep counting hours rather than days.
+func formatDuration(d time.Duration, style string) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	h, m := minutes/60, minutes%60
+
+	switch style {
+	case durationHM:
+		return fmt.Sprintf("%dh %02dm", h, m)
+	case durationISO8601:
+		switch {
+		case h == 0:
+			return fmt.Sprintf("PT%dM", m)
+		case m == 0:
+			return fmt.Sprintf("PT%dH", h)
+		default:
+			return fmt.Sprintf("PT%dH%dM", h, m)
+		}
+	default:
+		return hours.FormatHours(d)
+	}
 }
 
 func runReportCommand(cmd *cobra.Command, args []string) {
-	workData, err := loadWorkData(filePath)
+	opts, textTemplate, htmlTemplate := validateReportFlags()
+
+	workData, err := loadAndMergeWorkData(filePaths)
 	if err != nil {
-		slog.Error("failed to load work log file", "error", err, "path", filePath)
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
 		os.Exit(1)
 	}
 
-	dates, err := getDatesInRange(workData, startDate, endDate)
+	dates, err := selectDates(workData, startDate, endDate, sinceValue, lastDays)
 	if err != nil {
-		slog.Error("failed to process date range", "error", err, "start_date", startDate, "end_date", endDate)
-		os.Exit(1)
+		slog.Error("failed to process date range", "error", err, "start_date", startDate, "end_date", endDate, "since", sinceValue, "last", lastDays)
+		os.Exit(dateRangeExitCode(err))
+	}
+
+	// Hours cover every work_log entry in the range, whatever tasks are filtered out
+	if withHours || hoursDetail {
+		totals := hours.DailyTotals(workData, dates, hours.Options{})
+		if withHours {
+			opts.HTMLFooter = "Total hours: " + formatDuration(hours.Total(totals, dates), durationStyle)
+		}
+		if hoursDetail {
+			opts.HoursDetail = report.NewHoursDetail(workData, dates, totals, func(d time.Duration) string {
+				return formatDuration(d, durationStyle)
+			})
+		}
+	}
+
+	if len(ticketFilter) > 0 {
+		workData = report.FilterTickets(workData, ticketFilter)
+	}
+	if len(tagFilter) > 0 {
+		workData = report.FilterTags(workData, tagFilter)
+	}
+	if len(excludeTicket) > 0 {
+		workData = report.ExcludeTickets(workData, excludeTicket)
+	}
+	if len(excludeStatus) > 0 {
+		workData = report.ExcludeStatuses(workData, excludeStatus)
+	}
+
+	if warnEmpty {
+		for _, err := range report.FindEmptyTasks(workData, dates, opts) {
+			slog.Warn("skipping empty task", "error", err)
+		}
 	}
 
 	// Categorize tasks into completed, next up, and blocked
-	tasks := report.CategorizeTasks(workData, dates)
+	tasks := categorizeReport(workData, dates, opts)
 
-	// Generate and print the human-readable report to standard output
-	out := cmd.OutOrStdout()
-	fmt.Fprintf(out, "Work Report (%s to %s)\n", dates[0], dates[len(dates)-1])
-	fmt.Fprintln(out, "=======Autogenerated by TaskLedger=======")
+	// Identifiers are redacted from the rendered output, after everything that
+	// depends on them has been resolved
+	redactOutput := func(s string) string { return s }
+	if redact {
+		redaction := report.NewRedaction(workData, dates)
+		redactOutput = redaction.Apply
+		if redactMap {
+			for _, id := range redaction.IDs {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s\t%s\n", id.Placeholder, id.Original)
+			}
+		}
+	}
+
+	if listPRs {
+		var list bytes.Buffer
+		if err := printPRList(&list, dates, tasks); err != nil {
+			slog.Error("failed to marshal pull requests as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), redactOutput(list.String()))
+		return
+	}
+
+	// With --by-project the text report is made of one sub-report per project
+	textReports := []projectReport{{WorkData: workData, Tasks: tasks}}
+	if byProject {
+		if reports := projectReports(workData, dates, opts); len(reports) > 0 {
+			textReports = reports
+		}
+	}
 
-	report.PrintCompletedTasks(out, tasks.Completed)
-	report.PrintNextUpTasks(out, tasks.NextUp)
-	report.PrintBlockedTasks(out, tasks.Blocked)
+	renderReport(cmd.OutOrStdout(), workData, dates, tasks, textReports, textTemplate, htmlTemplate, opts, redactOutput)
+}
+
+// validateReportFlags checks the report command's flags, exiting with
+// exitBadInput when they are invalid or conflict, and returns the report
+// options and parsed templates they select.
+func validateReportFlags() (report.Options, *template.Template, *htmltemplate.Template) {
+	switch outputFormat {
+	case formatText, formatMarkdown, formatAsciiDoc, formatConfluence, formatJSON, formatSlack:
+	default:
+		slog.Error("unsupported report format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if summaryOnly && outputFormat != formatText {
+		slog.Error("--summary-only only supports the text format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if listPRs && outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("--prs only supports the text and json formats", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	staleDays, err := parseStaleAfter(staleAfter)
+	if err != nil {
+		slog.Error("invalid --stale-after", "error", err)
+		os.Exit(exitBadInput)
+	}
+	if outputDir != "" {
+		// --output-dir writes fixed formats to files, so flags that choose another
+		// destination or format would be silently ignored
+		conflicts := []struct {
+			flag string
+			set  bool
+		}{
+			{"--output", outputFile != ""},
+			{"--format", outputFormat != formatText},
+			{"--copy-html", copyHTML},
+			{"--copy-text", copyText},
+			{"--append-clipboard", copyBoth},
+			{"--slack-webhook", slackWebhook != ""},
+			{"--dry-run", dryRun},
+			{"--html-file", htmlFile != ""},
+			{"--show-html", showHTML},
+			{"--open-html", openHTML},
+		}
+		for _, conflict := range conflicts {
+			if conflict.set {
+				slog.Error("--output-dir cannot be combined with this flag", "flag", conflict.flag)
+				os.Exit(exitBadInput)
+			}
+		}
+	}
+	if wrapWidth < 0 {
+		slog.Error("--wrap cannot be negative", "wrap", wrapWidth)
+		os.Exit(exitBadInput)
+	}
+	if wrapWidth > 0 && outputFormat != formatText {
+		slog.Error("--wrap only supports the text format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if flatNonFeat && summaryOnly {
+		slog.Error("--no-nonfeature-grouping cannot be combined with --summary-only, which collapses non-feature work into one line")
+		os.Exit(exitBadInput)
+	}
+	if copyBoth && (copyHTML || copyText) {
+		slog.Error("--append-clipboard already copies HTML and text and cannot be combined with --copy-html or --copy-text")
+		os.Exit(exitBadInput)
+	}
+	if byProject && outputFormat != formatText {
+		slog.Error("--by-project only supports the text format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if templatePath != "" && (outputFormat != formatText || summaryOnly) {
+		slog.Error("--template only supports the text format without --summary-only", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	textTemplate, err := report.ParseTextTemplate(templatePath)
+	if err != nil {
+		slog.Error("invalid report template", "error", err, "template", templatePath)
+		os.Exit(exitBadInput)
+	}
+	htmlTemplate, err := report.ParseHTMLTemplate(htmlTmplPath)
+	if err != nil {
+		slog.Error("invalid HTML report template", "error", err, "template", htmlTmplPath)
+		os.Exit(exitBadInput)
+	}
+	if withHours && (outputFormat == formatJSON || outputFormat == formatSlack) {
+		slog.Error("--with-hours supports the text, markdown, adoc, and confluence formats", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	if hoursDetail && (outputFormat == formatJSON || outputFormat == formatSlack) {
+		slog.Error("--show-hours-detail supports the text, markdown, adoc, and confluence formats", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+	switch durationStyle {
+	case durationDecimal, durationHM, durationISO8601:
+	default:
+		slog.Error("unsupported duration format, use decimal, hm, or iso8601", "duration_format", durationStyle)
+		os.Exit(exitBadInput)
+	}
+	switch prOrder {
+	case report.PROrderURL, report.PROrderChrono:
+	default:
+		slog.Error("unsupported PR order, use url or chrono", "pr_order", prOrder)
+		os.Exit(exitBadInput)
+	}
+	if jiraWorkers < 1 {
+		slog.Error("--jira-concurrency must be at least 1", "jira_concurrency", jiraWorkers)
+		os.Exit(exitBadInput)
+	}
+	if jiraTimeout <= 0 {
+		slog.Error("--jira-timeout must be positive", "jira_timeout", jiraTimeout)
+		os.Exit(exitBadInput)
+	}
+	if !report.IsValidTheme(htmlTheme) {
+		slog.Error("unsupported HTML theme, use plain, light, or dark", "theme", htmlTheme)
+		os.Exit(exitBadInput)
+	}
+	switch groupBy {
+	case "", report.GroupByTicket, report.GroupByTag:
+	case report.GroupByDate:
+		if outputFormat != formatText || summaryOnly || templatePath != "" {
+			slog.Error("--group-by date only supports the text format without --summary-only or --template", "format", outputFormat)
+			os.Exit(exitBadInput)
+		}
+	default:
+		slog.Error("unsupported report grouping, use ticket, tag, or date", "group_by", groupBy)
+		os.Exit(exitBadInput)
+	}
+
+	return reportOptions(staleDays), textTemplate, htmlTemplate
+}
+
+// renderReport renders the categorized tasks in the selected format and writes
+// them to out, --output, or --output-dir, then copies, posts, and opens them as
+// requested. It exits with exitBlocked under --fail-on-blocker when any task is
+// blocked.
+func renderReport(out io.Writer, workData model.WorkData, dates []string, tasks model.CategorizedTasks, textReports []projectReport, textTemplate *template.Template, htmlTemplate *htmltemplate.Template, opts report.Options, redactOutput func(string) string) {
+	// JIRA info is only resolved when an output format needs ticket links
+	var jiraInfo map[string]jira.TicketInfo
+	// PR titles label PR links in text, Markdown, and HTML output. They are only
+	// fetched when GITHUB_TOKEN is set; otherwise PRs are labeled by their URL
+	var prInfo map[string]github.PRInfo
+
+	if outputDir != "" {
+		jiraInfo = loadJiraInfo(tasks)
+		prInfo = loadPRInfo(tasks)
+		if err := writeOutputDir(out, outputDir, workData, dates, tasks, textTemplate, htmlTemplate, textReports, jiraInfo, prInfo, opts, redactOutput); err != nil {
+			slog.Error("failed to write report files", "error", err, "output_dir", outputDir)
+			os.Exit(1)
+		}
+		if failOnBlocker && len(tasks.Blocked) > 0 {
+			os.Exit(exitBlocked)
+		}
+		return
+	}
+
+	// Render the report so it can be both printed and copied to the clipboard
+	var rendered bytes.Buffer
+	switch outputFormat {
+	case formatJSON:
+		jiraInfo = loadJiraInfo(tasks)
+		data, err := report.MarshalJSON(tasks, jiraInfo, opts)
+		if err != nil {
+			slog.Error("failed to marshal report as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(&rendered, string(data))
+	case formatSlack:
+		jiraInfo = loadJiraInfo(tasks)
+		title := fmt.Sprintf("Work Report (%s to %s)", dates[0], dates[len(dates)-1])
+		data, err := report.MarshalSlackBlocks(title, tasks, jiraInfo, opts)
+		if err != nil {
+			slog.Error("failed to marshal report as Slack blocks", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(&rendered, string(data))
+	case formatMarkdown:
+		if writeEmptyReportNote(&rendered, dates, tasks) {
+			break
+		}
+		jiraInfo = loadJiraInfo(tasks)
+		prInfo = loadPRInfo(tasks)
+		fmt.Fprintf(&rendered, "# Work Report (%s to %s)\n\n", dates[0], dates[len(dates)-1])
+		fmt.Fprintln(&rendered, "_Autogenerated by TaskLedger_")
+		report.PrintMarkdown(&rendered, tasks, jiraInfo, prInfo, opts)
+	case formatAsciiDoc:
+		if writeEmptyReportNote(&rendered, dates, tasks) {
+			break
+		}
+		jiraInfo = loadJiraInfo(tasks)
+		fmt.Fprintf(&rendered, "= Work Report (%s to %s)\n\n", dates[0], dates[len(dates)-1])
+		fmt.Fprintln(&rendered, "_Autogenerated by TaskLedger_")
+		report.PrintAsciiDoc(&rendered, tasks, jiraInfo, opts)
+	case formatConfluence:
+		if writeEmptyReportNote(&rendered, dates, tasks) {
+			break
+		}
+		jiraInfo = loadJiraInfo(tasks)
+		prInfo = loadPRInfo(tasks)
+		fmt.Fprintf(&rendered, "<h1>Work Report (%s to %s)</h1>\n", dates[0], dates[len(dates)-1])
+		fmt.Fprintln(&rendered, report.ConfluenceInfoMacro("Autogenerated by TaskLedger"))
+		report.PrintConfluence(&rendered, tasks, jiraInfo, prInfo, opts)
+	default:
+		// The summary shows ticket summaries instead of PR links, while custom
+		// templates may use either
+		if summaryOnly || templatePath != "" {
+			jiraInfo = loadJiraInfo(tasks)
+		}
+		if !summaryOnly {
+			prInfo = loadPRInfo(tasks)
+		}
+		if err := printTextReport(&rendered, textTemplate, dates, textReports, jiraInfo, prInfo, opts); err != nil {
+			slog.Error("failed to render report template", "error", err, "template", templatePath)
+			os.Exit(1)
+		}
+	}
+	writeHoursDetail(&rendered, opts.HoursDetail)
+	if opts.HTMLFooter != "" {
+		fmt.Fprintf(&rendered, "\n%s\n", opts.HTMLFooter)
+	}
+	if redact {
+		redacted := redactOutput(rendered.String())
+		rendered.Reset()
+		rendered.WriteString(redacted)
+	}
+
+	// Print the report to standard output, or to --output so that status
+	// messages stay out of the file
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, rendered.Bytes(), 0644); err != nil {
+			slog.Error("failed to write report to file", "error", err, "file", outputFile)
+			os.Exit(1)
+		}
+		fmt.Fprintf(statusWriter(out), "✅ Report saved to: %s\n", outputFile)
+	} else if outputFormat == formatText && useColor(out) {
+		// Only the terminal copy is colored; files, the clipboard, and Slack get plain text
+		// The template already rendered once without errors
+		var colored bytes.Buffer
+		printTextReport(report.NewColorWriter(&colored), textTemplate, dates, textReports, jiraInfo, prInfo, opts)
+		writeHoursDetail(report.NewColorWriter(&colored), opts.HoursDetail)
+		if opts.HTMLFooter != "" {
+			fmt.Fprintf(&colored, "\n%s\n", opts.HTMLFooter)
+		}
+		fmt.Fprint(out, redactOutput(colored.String()))
+	} else {
+		out.Write(rendered.Bytes())
+	}
+
+	if copyText {
+		if err := clipboard.CopyText(rendered.String()); err != nil {
+			fmt.Fprintf(out, "\n⚠️  Failed to copy to clipboard: %v\n", err)
+		} else {
+			fmt.Fprintln(statusWriter(out), "\n✅ Report copied to clipboard as text!")
+		}
+	}
+
+	webhookURL := slackWebhook
+	if webhookURL == "" {
+		webhookURL = os.Getenv("SLACK_WEBHOOK_URL")
+	}
+	if webhookURL != "" || dryRun {
+		sendSlackReport(out, webhookURL, rendered.Bytes())
+	}
 
 	// Handle HTML output options
-	if copyHTML || htmlFile != "" || showHTML || openHTML {
-		// Load pre-fetched JIRA summaries if provided
-		var jiraInfo map[string]jira.TicketInfo
-		if jiraSummaries != "" {
-			var err error
-			jiraInfo, err = jira.LoadSummariesFromFile(jiraSummaries)
-			if err != nil {
-				slog.Warn("failed to load JIRA summaries, will fetch from API", "error", err, "file", jiraSummaries)
+	if copyHTML || copyBoth || htmlFile != "" || showHTML || openHTML {
+		if jiraInfo == nil {
+			jiraInfo = loadJiraInfo(tasks)
+		}
+		if prInfo == nil {
+			prInfo = loadPRInfo(tasks)
+		}
+		htmlContent, err := report.GenerateHTMLWithTemplate(htmlTemplate, dates, tasks, jiraInfo, prInfo, htmlTheme, opts)
+		if err != nil {
+			slog.Error("failed to render HTML report template", "error", err, "template", htmlTmplPath)
+			os.Exit(1)
+		}
+		handleHTMLOutput(out, redactOutput(htmlContent), rendered.String())
+	}
+
+	if failOnBlocker && len(tasks.Blocked) > 0 {
+		os.Exit(exitBlocked)
+	}
+}
+
+// loadPRInfo fetches the titles and states of the PRs linked from tasks. Nothing
+// is fetched with --redact, since PR labels name the repository and number.
+func loadPRInfo(tasks model.CategorizedTasks) map[string]github.PRInfo {
+	if redact {
+		return nil
+	}
+	return github.ProcessPRs(report.CollectPRLinks(tasks))
+}
+
+// reportOptions returns the report options selected by the report command's
+// flags, with tasks flagged stale after staleDays.
+func reportOptions(staleDays int) report.Options {
+	return withSectionHeaders(report.Options{
+		IncludeEmptySections: includeEmpty,
+		CountDuplicates:      countDupes,
+		CollapseCompleted:    collapseDone,
+		MergeAcrossStatus:    mergeStatus,
+		FlatNonFeatureWork:   flatNonFeat,
+		IncludePrivate:       showPrivate,
+		StaleAfter:           staleDays,
+		PROrder:              prOrder,
+		ShowJiraStatus:       jiraStatus,
+		WrapWidth:            wrapWidth,
+	})
+}
+
+// withSectionHeaders applies the --header-* section titles to opts.
+func withSectionHeaders(opts report.Options) report.Options {
+	opts.HeaderCompleted = headerDone
+	opts.HeaderNextUp = headerNext
+	opts.HeaderBlocked = headerBlocked
+	return opts
+}
+
+// projectReport is the work data and categorized tasks of one project for
+// report --by-project, or of every project when Project is empty.
+type projectReport struct {
+	Project  string
+	WorkData model.WorkData
+	Tasks    model.CategorizedTasks
+}
+
+// categorizeReport categorizes the tasks on the given dates, grouping completed
+// work by tag with --group-by tag.
+func categorizeReport(workData model.WorkData, dates []string, opts report.Options) model.CategorizedTasks {
+	tasks := report.CategorizeTasks(workData, dates, opts)
+	if groupBy == report.GroupByTag {
+		tasks.Completed = report.GroupCompletedByTag(tasks.Completed)
+	}
+	return tasks
+}
+
+// projectReports categorizes the tasks of each project separately, leaving out
+// projects with nothing to report unless --include-empty-sections is set.
+func projectReports(workData model.WorkData, dates []string, opts report.Options) []projectReport {
+	projects, byProject := report.SplitByProject(workData, dates)
+
+	var reports []projectReport
+	for _, project := range projects {
+		tasks := categorizeReport(byProject[project], dates, opts)
+		if !includeEmpty && !report.HasEntries(tasks) {
+			continue
+		}
+		reports = append(reports, projectReport{Project: project, WorkData: byProject[project], Tasks: tasks})
+	}
+	return reports
+}
+
+// printTextReport writes each report rendered with tmpl, or only its tickets
+// with --summary-only, separated by blank lines. Pass a report.ColorWriter to
+// color them.
+func printTextReport(out io.Writer, tmpl *template.Template, dates []string, reports []projectReport, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts report.Options) error {
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		if writeEmptyReportNote(out, dates, r.Tasks) {
+			continue
+		}
+
+		if summaryOnly || groupBy == report.GroupByDate {
+			title := "Work Report"
+			if r.Project != "" {
+				title += " for " + r.Project
 			}
+			fmt.Fprintf(out, "%s (%s to %s)\n", title, dates[0], dates[len(dates)-1])
+			fmt.Fprintln(out, "=======Autogenerated by TaskLedger=======")
+			if summaryOnly {
+				report.PrintSummary(out, r.Tasks, jiraInfo, opts)
+			} else {
+				report.PrintByDate(out, r.WorkData, dates, prInfo, opts)
+			}
+			continue
 		}
-		htmlContent := report.GenerateHTML(dates, tasks.Completed, tasks.NextUp, tasks.Blocked, jiraInfo)
-		handleHTMLOutput(out, htmlContent)
+
+		err := report.ExecuteTextTemplate(out, tmpl, report.TemplateData{
+			Project:   r.Project,
+			StartDate: dates[0],
+			EndDate:   dates[len(dates)-1],
+			Dates:     dates,
+			Tasks:     r.Tasks,
+			JiraInfo:  jiraInfo,
+			PRInfo:    prInfo,
+		}, opts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEmptyReportNote writes a one-line note in place of a report with no
+// entries and reports whether it did. With --include-empty-sections the full
+// report is always rendered instead.
+func writeEmptyReportNote(out io.Writer, dates []string, tasks model.CategorizedTasks) bool {
+	if includeEmpty || report.HasEntries(tasks) {
+		return false
 	}
+	fmt.Fprintf(out, "No report entries from %s to %s.\n", dates[0], dates[len(dates)-1])
+	return true
+}
+
+// writeHoursDetail appends the --show-hours-detail section to a report in the
+// selected output format.
+func writeHoursDetail(out io.Writer, days []report.DayHours) {
+	switch outputFormat {
+	case formatMarkdown:
+		report.PrintHoursDetailMarkdown(out, days)
+	case formatAsciiDoc:
+		report.PrintHoursDetailAsciiDoc(out, days)
+	case formatConfluence:
+		report.PrintHoursDetailConfluence(out, days)
+	default:
+		report.PrintHoursDetail(out, days)
+	}
+}
+
+// prListEntry is a pull request listed by report --prs. Title and State are only
+// known when GITHUB_TOKEN is set.
+type prListEntry struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+// printPRList writes every pull request referenced by tasks for report --prs,
+// as a list in the text format or as a JSON array.
+func printPRList(out io.Writer, dates []string, tasks model.CategorizedTasks) error {
+	links := report.CollectAllPRLinks(tasks)
+	var prInfo map[string]github.PRInfo
+	if !redact {
+		prInfo = github.ProcessPRs(links)
+	}
+
+	entries := make([]prListEntry, 0, len(links))
+	for _, link := range links {
+		info := prInfo[link]
+		entries = append(entries, prListEntry{URL: link, Title: info.Title, State: info.State})
+	}
+
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "No pull requests from %s to %s.\n", dates[0], dates[len(dates)-1])
+		return nil
+	}
+	fmt.Fprintf(out, "Pull Requests (%s to %s)\n", dates[0], dates[len(dates)-1])
+	for _, entry := range entries {
+		line := "    • " + entry.URL
+		if entry.State != "" {
+			line += " [" + entry.State + "]"
+		}
+		if entry.Title != "" {
+			line += " " + entry.Title
+		}
+		fmt.Fprintln(out, line)
+	}
+	return nil
+}
+
+// useColor reports whether the text report written to out should be colored:
+// --no-color always wins, then --color, then NO_COLOR, and otherwise out must
+// be a terminal.
+func useColor(out io.Writer) bool {
+	switch {
+	case noColor:
+		return false
+	case forceColor:
+		return true
+	case os.Getenv("NO_COLOR") != "":
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// sendSlackReport posts the rendered report to a Slack incoming webhook. Block Kit
+// output from --format slack is sent as is; any other format is sent as text.
+// With --dry-run the payload is printed instead.
+func sendSlackReport(out io.Writer, webhookURL string, rendered []byte) {
+	payload := bytes.TrimSpace(rendered)
+	if outputFormat != formatSlack {
+		var err error
+		payload, err = slack.TextPayload(string(rendered))
+		if err != nil {
+			slog.Error("failed to build Slack payload", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	if dryRun {
+		fmt.Fprintln(out, "\nDry run: would send this payload to the Slack webhook:")
+		fmt.Fprintln(out, string(payload))
+		return
+	}
+
+	if err := slack.PostWebhook(webhookURL, payload); err != nil {
+		slog.Error("failed to post report to Slack", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("posted report to Slack webhook")
+}
+
+// loadJiraInfo resolves JIRA ticket info for the categorized tasks, preferring the
+// pre-fetched summaries file when provided and falling back to the JIRA API.
+func loadJiraInfo(tasks model.CategorizedTasks) map[string]jira.TicketInfo {
+	if jiraInfo, ok := loadJiraSummaries(); ok {
+		return jiraInfo
+	}
+	client := newCachedJiraClient()
+	defer saveJiraCache(client)
+	return client.ProcessTickets(report.CollectTickets(tasks))
+}
+
+// loadJiraSummaries loads the --jira-summaries file, reporting false when it is
+// not set or cannot be read so that tickets are fetched from the API instead.
+func loadJiraSummaries() (map[string]jira.TicketInfo, bool) {
+	if jiraSummaries == "" {
+		return nil, false
+	}
+	jiraInfo, err := jira.LoadSummariesFromFile(jiraSummaries)
+	if err != nil {
+		slog.Warn("failed to load JIRA summaries, will fetch from API", "error", err, "file", jiraSummaries)
+		return nil, false
+	}
+	return jiraInfo, true
+}
+
+// newCachedJiraClient returns newJiraClient with the on-disk cache attached
+// unless --no-jira-cache is set. The cache only saves API calls, which are made
+// only when a token or instance is configured. Call saveJiraCache when done.
+func newCachedJiraClient() *jira.Client {
+	client := newJiraClient()
+	if !noJiraCache && (client.Token != "" || len(client.Instances) > 0) {
+		client.Cache = openJiraCache()
+	}
+	return client
+}
+
+// saveJiraCache writes the client's cache to disk, if it has one, logging any failure.
+func saveJiraCache(client *jira.Client) {
+	if client.Cache == nil {
+		return
+	}
+	if err := client.Cache.Save(); err != nil {
+		slog.Warn("failed to save JIRA cache", "error", err)
+	}
+}
+
+// newJiraClient returns a JIRA client for the configured instances, using the
+// resolved token and the --jira-timeout, --jira-concurrency, and --jira-fields
+// flags. It uses its own copy of the shared HTTP client, so concurrent serve
+// requests never race. A token that cannot be resolved is logged, and the client
+// fetches without one.
+func newJiraClient() *jira.Client {
+	token, err := jira.ResolveToken()
+	if err != nil {
+		slog.Warn("JIRA summaries disabled", "error", err)
+	}
+	httpClient := *jira.HTTPClient
+	httpClient.Timeout = jiraTimeout
+	return &jira.Client{
+		BaseURL:     jira.BaseURL,
+		Token:       token,
+		Instances:   jira.Instances,
+		HTTPClient:  &httpClient,
+		Concurrency: jiraWorkers,
+		Fields:      jiraFields,
+	}
+}
+
+// openJiraCache opens the on-disk JIRA summary cache, returning nil (caching
+// disabled) if it cannot be opened.
+func openJiraCache() *jira.Cache {
+	path, err := jira.DefaultCachePath()
+	if err != nil {
+		slog.Warn("JIRA cache disabled", "error", err)
+		return nil
+	}
+	cache, err := jira.OpenCache(path, jiraCacheTTL)
+	if err != nil {
+		slog.Warn("JIRA cache disabled", "error", err, "path", path)
+		return nil
+	}
+	return cache
 }
 
 func runInitCommand(cmd *cobra.Command, args []string) {
+	filePath, err := singleWorkLogPath()
+	if err != nil {
+		slog.Error("cannot create work log file", "error", err)
+		os.Exit(1)
+	}
+
 	// Check if file already exists
-	if _, err := os.Stat(filePath); err == nil {
+	if _, err := os.Stat(filePath); err == nil && !forceInit {
 		fmt.Fprintf(cmd.OutOrStdout(), "File '%s' already exists. Overwrite? (y/N): ", filePath)
 		var response string
 		fmt.Scanln(&response)
@@ -185,47 +1321,72 @@ func runInitCommand(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Generate sample worklog data for today and yesterday
-	data, err := generateInitialWorklogYAML(time.Now())
-	if err != nil {
-		slog.Error("failed to generate worklog YAML", "error", err)
-		os.Exit(1)
-	}
-
-	// Write to file
-	err = os.WriteFile(filePath, data, 0644)
-	if err != nil {
-		slog.Error("failed to write worklog file", "error", err, "path", filePath)
-		os.Exit(1)
+	// Generate sample worklog data for today and yesterday. JSON has no comments,
+	// so a JSON work log gets the sample entries without the documentation.
+	now := time.Now()
+	if workLogFormat(filePath) == fileFormatJSON {
+		if err := saveWorkData(filePath, createInitialWorklog(now)); err != nil {
+			slog.Error("failed to write worklog file", "error", err, "path", filePath)
+			os.Exit(1)
+		}
+	} else {
+		data, err := generateInitialWorklogYAML(now)
+		if err != nil {
+			slog.Error("failed to generate worklog YAML", "error", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(filePath, data, 0644); err != nil {
+			slog.Error("failed to write worklog file", "error", err, "path", filePath)
+			os.Exit(1)
+		}
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "✅ Created %s with sample entries for today and yesterday.\n", filePath)
+	fmt.Fprintf(statusWriter(cmd.OutOrStdout()), "✅ Created %s with sample entries for today and yesterday.\n", filePath)
 	fmt.Fprintln(cmd.OutOrStdout(), "Edit the file to add your own work entries!")
 }
 
+// statusWriter returns where informational status messages, such as save and
+// clipboard confirmations, are written: out, or io.Discard with --quiet. Report
+// content, warnings, and errors are always written to out.
+func statusWriter(out io.Writer) io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	return out
+}
+
 // --- HTML Output Handling ---
 
-func handleHTMLOutput(out io.Writer, htmlContent string) {
-	// Save to file if requested
+func handleHTMLOutput(out io.Writer, htmlContent, textContent string) {
+	// Save to file if requested. --open-html alone saves to a temporary file so
+	// there is something to open.
+	savedPath := ""
 	if htmlFile != "" {
 		err := saveHTMLToFile(htmlContent, htmlFile)
 		if err != nil {
 			slog.Error("failed to save HTML to file", "error", err, "file", htmlFile)
 		} else {
-			fmt.Fprintf(out, "\n✅ HTML report saved to: %s\n", htmlFile)
-
-			// Open HTML file in browser if requested
-			if openHTML {
-				err := openHTMLInBrowser(htmlFile)
-				if err != nil {
-					fmt.Fprintf(out, "⚠️  Failed to open HTML file in browser: %v\n", err)
-				} else {
-					fmt.Fprintf(out, "🌐 Opened HTML report in default browser\n")
-				}
-			}
+			fmt.Fprintf(statusWriter(out), "\n✅ HTML report saved to: %s\n", htmlFile)
+			savedPath = htmlFile
 		}
 	} else if openHTML {
-		fmt.Fprintf(out, "\n💡 To use --open-html, you must also specify --html-file\n")
+		path, err := saveHTMLToTempFile(htmlContent)
+		if err != nil {
+			slog.Error("failed to save HTML to a temporary file", "error", err)
+		} else {
+			fmt.Fprintf(statusWriter(out), "\n✅ HTML report saved to temporary file: %s\n", path)
+			savedPath = path
+		}
+	}
+
+	// Open HTML file in browser if requested
+	if openHTML && savedPath != "" {
+		err := browserOpener(savedPath)
+		if err != nil {
+			fmt.Fprintf(out, "⚠️  Failed to open HTML file in browser: %v\n", err)
+		} else {
+			fmt.Fprintf(statusWriter(out), "🌐 Opened HTML report in default browser\n")
+		}
 	}
 
 	// Show HTML in console if requested
@@ -242,17 +1403,119 @@ func handleHTMLOutput(out io.Writer, htmlContent string) {
 			fmt.Fprintf(out, "\n⚠️  Failed to copy to clipboard: %v\n", err)
 			fmt.Fprintf(out, "💡 Try using --html-file to save to a file instead, or --show-html to display the HTML\n")
 		} else {
-			fmt.Fprintln(out, "\n✅ HTML report copied to clipboard!")
+			fmt.Fprintln(statusWriter(out), "\n✅ HTML report copied to clipboard!")
+		}
+	}
+
+	// Copy both flavors, with the rendered report as the plain text
+	if copyBoth {
+		if err := clipboard.Copy(htmlContent, textContent); err != nil {
+			fmt.Fprintf(out, "\n⚠️  Failed to copy to clipboard: %v\n", err)
+		} else {
+			fmt.Fprintln(statusWriter(out), "\n✅ Report copied to clipboard as HTML and text!")
 		}
 	}
 }
 
 // --- File Operations ---
 
+// outputDirFiles returns the names report --output-dir writes for the date
+// range: report files with the given extension, and the hours CSV, all named
+// after the first and last date so that runs for different ranges can share a
+// directory.
+func outputDirFiles(dates []string) (reportBase, hoursCSV string) {
+	dateRange := dates[0]
+	if last := dates[len(dates)-1]; last != dateRange {
+		dateRange += "_" + last
+	}
+	return "report-" + dateRange, "hours-" + dateRange + ".csv"
+}
+
+// writeOutputDir renders the report as text, HTML, and JSON, plus the daily
+// hours as CSV with --with-hours, and writes each to its file in dir, creating
+// dir when missing. Each path is printed once written. The text report is
+// rendered as for --format text, with the hours detail and total when requested.
+func writeOutputDir(out io.Writer, dir string, workData model.WorkData, dates []string, tasks model.CategorizedTasks, textTemplate *template.Template, htmlTemplate *htmltemplate.Template, textReports []projectReport, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts report.Options, redactOutput func(string) string) error {
+	reportBase, hoursCSV := outputDirFiles(dates)
+
+	var text bytes.Buffer
+	if err := printTextReport(&text, textTemplate, dates, textReports, jiraInfo, prInfo, opts); err != nil {
+		return fmt.Errorf("rendering the text report: %w", err)
+	}
+	report.PrintHoursDetail(&text, opts.HoursDetail)
+	if opts.HTMLFooter != "" {
+		fmt.Fprintf(&text, "\n%s\n", opts.HTMLFooter)
+	}
+
+	htmlContent, err := report.GenerateHTMLWithTemplate(htmlTemplate, dates, tasks, jiraInfo, prInfo, htmlTheme, opts)
+	if err != nil {
+		return fmt.Errorf("rendering the HTML report: %w", err)
+	}
+
+	data, err := report.MarshalJSON(tasks, jiraInfo, opts)
+	if err != nil {
+		return fmt.Errorf("marshaling the JSON report: %w", err)
+	}
+
+	type outputFile struct{ name, content string }
+	files := []outputFile{
+		{reportBase + ".txt", text.String()},
+		{reportBase + ".html", htmlContent},
+		{reportBase + ".json", string(data) + "\n"},
+	}
+	if withHours {
+		var csv bytes.Buffer
+		if err := hours.WriteCSV(&csv, workData, dates, hours.DailyTotals(workData, dates, hours.Options{})); err != nil {
+			return fmt.Errorf("writing the hours CSV: %w", err)
+		}
+		files = append(files, outputFile{hoursCSV, csv.String()})
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := os.WriteFile(path, []byte(redactOutput(file.content)), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(statusWriter(out), "✅ Saved: %s\n", path)
+	}
+	return nil
+}
+
 func saveHTMLToFile(htmlContent, filename string) error {
 	return os.WriteFile(filename, []byte(htmlContent), 0644)
 }
 
+// saveHTMLToTempFile writes the HTML report to a new file in the system temp
+// directory and returns its path. The file is not removed afterwards, since the
+// browser opens it asynchronously; the OS temp directory cleanup reclaims it.
+// A partially written file is removed when writing fails.
+func saveHTMLToTempFile(htmlContent string) (path string, err error) {
+	f, err := os.CreateTemp("", "taskledger-report-*.html")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.WriteString(htmlContent); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// browserOpener opens an HTML file in the default browser; tests override it.
+var browserOpener = openHTMLInBrowser
+
 func openHTMLInBrowser(filePath string) error {
 	var cmd *exec.Cmd
 
@@ -272,6 +1535,38 @@ func openHTMLInBrowser(filePath string) error {
 
 // --- Data Loading ---
 
+// expandPath expands a leading ~ to the home directory and then $VAR or ${VAR}
+// references, so --file values and config file paths can point at synced
+// directories. Paths without either are returned unchanged.
+func expandPath(path string) string {
+	return os.ExpandEnv(expandHome(path))
+}
+
+// expandHome replaces a leading ~ or ~/ with the current user's home directory.
+// The path is returned unchanged if the home directory cannot be determined.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path[1:], "/"))
+}
+
+// workLogFormat returns the format of the work log at filePath: the --file-format
+// value when set, otherwise json for a .json extension and yaml for anything else.
+func workLogFormat(filePath string) string {
+	if fileFormat != "" {
+		return fileFormat
+	}
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		return fileFormatJSON
+	}
+	return fileFormatYAML
+}
+
 func loadWorkData(filePath string) (model.WorkData, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -279,6 +1574,12 @@ func loadWorkData(filePath string) (model.WorkData, error) {
 	}
 
 	var workData model.WorkData
+	if workLogFormat(filePath) == fileFormatJSON {
+		if err := json.Unmarshal(data, &workData); err != nil {
+			return nil, fmt.Errorf("could not parse JSON from '%s': %w", filePath, err)
+		}
+		return workData, nil
+	}
 	err = yaml.Unmarshal(data, &workData)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse YAML from '%s': %w", filePath, err)
@@ -287,6 +1588,296 @@ func loadWorkData(filePath string) (model.WorkData, error) {
 	return workData, nil
 }
 
+// loadWorkDataLenient reads a work log file like loadWorkData, but decodes each
+// date independently so that a malformed day is skipped with a warning instead
+// of failing the whole file. The file must still parse as a map of dates.
+func loadWorkDataLenient(filePath string) (model.WorkData, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file '%s': %w", filePath, err)
+	}
+
+	workData := make(model.WorkData)
+	skip := func(date string, err error) {
+		slog.Warn("skipping day that failed to parse", "date", date, "error", err, "path", filePath)
+	}
+	if workLogFormat(filePath) == fileFormatJSON {
+		var days map[string]json.RawMessage
+		if err := json.Unmarshal(data, &days); err != nil {
+			return nil, fmt.Errorf("could not parse JSON from '%s': %w", filePath, err)
+		}
+		for date, raw := range days {
+			var dailyLog model.DailyLog
+			if err := json.Unmarshal(raw, &dailyLog); err != nil {
+				skip(date, err)
+				continue
+			}
+			workData[date] = dailyLog
+		}
+		return workData, nil
+	}
+
+	var days map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &days); err != nil {
+		return nil, fmt.Errorf("could not parse YAML from '%s': %w", filePath, err)
+	}
+	for date, node := range days {
+		var dailyLog model.DailyLog
+		if err := node.Decode(&dailyLog); err != nil {
+			skip(date, err)
+			continue
+		}
+		workData[date] = dailyLog
+	}
+	return workData, nil
+}
+
+// saveWorkData writes the work data back to a work log file in the format it was
+// read in. The file is replaced atomically, so a failed write leaves the previous
+// contents intact.
+func saveWorkData(filePath string, workData model.WorkData) error {
+	var data []byte
+	var err error
+	if workLogFormat(filePath) == fileFormatJSON {
+		data, err = json.MarshalIndent(workData, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(workData)
+	}
+	if err != nil {
+		return fmt.Errorf("could not encode work log: %w", err)
+	}
+	if err := writeFileAtomic(filePath, data); err != nil {
+		return fmt.Errorf("could not write file '%s': %w", filePath, err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// over path once it is complete.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// singleWorkLogPath returns the --file path for commands that write to the work
+// log, which only make sense for a single file.
+func singleWorkLogPath() (string, error) {
+	if len(filePaths) != 1 {
+		return "", fmt.Errorf("this command writes to a single work log file, pass exactly one --file (got %d)", len(filePaths))
+	}
+	return expandPath(filePaths[0]), nil
+}
+
+// loadAndMergeWorkData loads each work log file and merges them into one WorkData.
+// Entries for the same date are combined by appending work_log entries and tasks
+// in file order; only a day_type is overwritten, by the last file that sets it.
+// With --lenient, malformed days are skipped.
+func loadAndMergeWorkData(paths []string) (model.WorkData, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no work log file specified")
+	}
+
+	load := loadWorkData
+	if lenient {
+		load = loadWorkDataLenient
+	}
+
+	merged := make(model.WorkData)
+	for _, path := range paths {
+		workData, err := load(expandPath(path))
+		if err != nil {
+			return nil, err
+		}
+		for date, dailyLog := range workData {
+			existing := merged[date]
+			if dailyLog.DayType != "" {
+				existing.DayType = dailyLog.DayType
+			}
+			existing.WorkLogEntries = append(existing.WorkLogEntries, dailyLog.WorkLogEntries...)
+			existing.Tasks = append(existing.Tasks, dailyLog.Tasks...)
+			merged[date] = existing
+		}
+	}
+	return merged, nil
+}
+
+// selectDates returns the dates to operate on: the --dates list when it is set,
+// the last N dates present in the work log when last is set (ignoring calendar gaps), the dates from the --since
+// duration through the end date (today by default) when since is set, otherwise
+// the dates in the start/end range.
+func selectDates(workData model.WorkData, startStr, endStr, since string, last int) ([]string, error) {
+	if len(dateList) > 0 {
+		if startStr != "" || endStr != "" || since != "" || last != 0 || endExclusive {
+			return nil, fmt.Errorf("%w: --dates cannot be combined with --start-date, --end-date, --since, --last, or --end-exclusive", ErrBadDateRange)
+		}
+		return listedDates(workData, dateList)
+	}
+	if since != "" {
+		if startStr != "" || last != 0 {
+			return nil, fmt.Errorf("%w: --since cannot be combined with --start-date or --last", ErrBadDateRange)
+		}
+		sinceDate, err := sinceStartDate(since, nowFunc())
+		if err != nil {
+			return nil, err
+		}
+		if endStr == "" {
+			endStr = nowFunc().Format("2006-01-02")
+		} else if endExclusive {
+			if endStr, err = excludeEndDate(sinceDate, endStr); err != nil {
+				return nil, err
+			}
+		}
+		return getDatesInRange(workData, sinceDate, endStr)
+	}
+	if last == 0 {
+		if endExclusive && endStr != "" {
+			var err error
+			if endStr, err = excludeEndDate(startStr, endStr); err != nil {
+				return nil, err
+			}
+		}
+		return getDatesInRange(workData, startStr, endStr)
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("%w: --last must be a positive number of days, got %d", ErrBadDateRange, last)
+	}
+	if startStr != "" || endStr != "" || endExclusive {
+		return nil, fmt.Errorf("%w: --last cannot be combined with --start-date, --end-date, or --end-exclusive", ErrBadDateRange)
+	}
+
+	allDates, err := getDatesInRange(workData, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(allDates) > last {
+		allDates = allDates[len(allDates)-last:]
+	}
+	return allDates, nil
+}
+
+// listedDates returns the given dates in order without duplicates, skipping with
+// a warning those that have no entry in the work log.
+func listedDates(workData model.WorkData, list []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var dates []string
+	for _, date := range list {
+		date = strings.TrimSpace(date)
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("%w: invalid date %q in --dates, use YYYY-MM-DD", ErrBadDateRange, date)
+		}
+		if seen[date] {
+			continue
+		}
+		seen[date] = true
+		if _, exists := workData[date]; !exists {
+			slog.Warn("skipping date with no work log entry", "date", date)
+			continue
+		}
+		dates = append(dates, date)
+	}
+
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("%w for the listed dates", ErrNoData)
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+// excludeEndDate returns the day before endStr for --end-exclusive, so that a
+// [start, end) range such as one Monday to the next can be passed as is. A range
+// that would be empty, because the start is missing (the single-date shorthand)
+// or is the end date itself, is rejected.
+func excludeEndDate(startStr, endStr string) (string, error) {
+	now := nowFunc()
+	end, err := time.Parse("2006-01-02", resolveRelativeDate(endStr, now, false, weekStart))
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid end date format, use YYYY-MM-DD: %w", ErrBadDateRange, err)
+	}
+	if startStr == "" || resolveRelativeDate(startStr, now, true, weekStart) == end.Format("2006-01-02") {
+		return "", fmt.Errorf("%w: --end-exclusive needs a start date before the end date", ErrBadDateRange)
+	}
+	return end.AddDate(0, 0, -1).Format("2006-01-02"), nil
+}
+
+// sinceStartDate converts a --since duration such as 7d, 2w, or 1m into the
+// YYYY-MM-DD start date of a range that spans that long and ends today, so 7d
+// covers today and the six days before it.
+func sinceStartDate(value string, now time.Time) (string, error) {
+	start, err := spanAgo("--since", value, now)
+	if err != nil {
+		return "", err
+	}
+	return start.AddDate(0, 0, 1).Format("2006-01-02"), nil
+}
+
+// spanAgo returns the time a duration such as 7d, 2w, or 1m before now,
+// naming flag in the error for an invalid value.
+func spanAgo(flag, value string, now time.Time) (time.Time, error) {
+	invalid := fmt.Errorf("%w: invalid %s value %q, use a number followed by d, w, or m", ErrBadDateRange, flag, value)
+	if len(value) < 2 {
+		return time.Time{}, invalid
+	}
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count <= 0 {
+		return time.Time{}, invalid
+	}
+
+	switch value[len(value)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -count), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*count), nil
+	case 'm':
+		return now.AddDate(0, -count, 0), nil
+	default:
+		return time.Time{}, invalid
+	}
+}
+
+// parseStaleAfter converts a --stale-after value such as 7d, 2w, or 1m into a
+// number of days, accepting the same values as --since. An empty value is
+// zero, which turns stale detection off.
+func parseStaleAfter(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	now := nowFunc()
+	ago, err := spanAgo("--stale-after", value, now)
+	if err != nil {
+		return 0, err
+	}
+	return int(now.Sub(ago).Round(24*time.Hour) / (24 * time.Hour)), nil
+}
+
+// dateRangeExitCode maps an error from selectDates to the command's exit code.
+func dateRangeExitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrNoData):
+		return exitNoData
+	case errors.Is(err, ErrBadDateRange):
+		return exitBadInput
+	default:
+		return exitFailure
+	}
+}
+
 func getDatesInRange(workData model.WorkData, startStr, endStr string) ([]string, error) {
 	if startStr != "" && endStr == "" {
 		endStr = startStr
@@ -295,6 +1886,9 @@ func getDatesInRange(workData model.WorkData, startStr, endStr string) ([]string
 		startStr = endStr
 	}
 
+	startStr = resolveRelativeDate(startStr, nowFunc(), true, weekStart)
+	endStr = resolveRelativeDate(endStr, nowFunc(), false, weekStart)
+
 	if startStr == "" && endStr == "" {
 		var allDates []string
 		for date := range workData {
@@ -302,22 +1896,22 @@ func getDatesInRange(workData model.WorkData, startStr, endStr string) ([]string
 		}
 		sort.Strings(allDates)
 		if len(allDates) == 0 {
-			return nil, fmt.Errorf("no data found in the work log file")
+			return nil, fmt.Errorf("%w in the work log file", ErrNoData)
 		}
 		return allDates, nil
 	}
 
 	startDate, err := time.Parse("2006-01-02", startStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start date format, use YYYY-MM-DD: %w", err)
+		return nil, fmt.Errorf("%w: invalid start date format, use YYYY-MM-DD: %w", ErrBadDateRange, err)
 	}
 	endDate, err := time.Parse("2006-01-02", endStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid end date format, use YYYY-MM-DD: %w", err)
+		return nil, fmt.Errorf("%w: invalid end date format, use YYYY-MM-DD: %w", ErrBadDateRange, err)
 	}
 
 	if endDate.Before(startDate) {
-		return nil, fmt.Errorf("end date cannot be before start date")
+		return nil, fmt.Errorf("%w: end date cannot be before start date", ErrBadDateRange)
 	}
 
 	var datesInRange []string
@@ -329,17 +1923,86 @@ func getDatesInRange(workData model.WorkData, startStr, endStr string) ([]string
 	}
 
 	if len(datesInRange) == 0 {
-		return nil, fmt.Errorf("no data found for the specified date range")
+		return nil, fmt.Errorf("%w for the specified date range", ErrNoData)
 	}
 	sort.Strings(datesInRange)
 	return datesInRange, nil
 }
 
+// nowFunc returns the current time; tests override it to resolve relative dates deterministically.
+var nowFunc = time.Now
+
+// resolveRelativeDate converts a relative date keyword (today, yesterday, this-week,
+// last-week, this-month, last-month) into a YYYY-MM-DD date relative to now. Keywords
+// covering a range resolve to their first day when isStart is true and their last day
+// otherwise; weeks begin on weekStart. Any other value is returned unchanged.
+func resolveRelativeDate(value string, now time.Time, isStart bool, weekStart time.Weekday) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	thisWeek := model.StartOfWeek(today, weekStart)
+	firstOfMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	var first, last time.Time
+	switch value {
+	case "today":
+		first, last = today, today
+	case "yesterday":
+		first = today.AddDate(0, 0, -1)
+		last = first
+	case "this-week":
+		first, last = thisWeek, thisWeek.AddDate(0, 0, 6)
+	case "last-week":
+		first, last = thisWeek.AddDate(0, 0, -7), thisWeek.AddDate(0, 0, -1)
+	case "this-month":
+		first, last = firstOfMonth, firstOfMonth.AddDate(0, 1, -1)
+	case "last-month":
+		first, last = firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1)
+	default:
+		return value
+	}
+
+	if isStart {
+		return first.Format("2006-01-02")
+	}
+	return last.Format("2006-01-02")
+}
+
 // --- Init Command Helpers ---
 
+// worklogTemplateComment documents the worklog layout at the top of generated files.
+const worklogTemplateComment = `TaskLedger work log.
+Each top-level key is a date in YYYY-MM-DD format containing:
+  work_log: time entries with start_time/end_time, preferably 24-hour HH:MM
+            (HH:MM:SS and 12-hour times such as 1:30 PM are also accepted)
+  tasks:    work items; entries sharing a jira_ticket are tracked as one item across dates`
+
+// statusComment documents the valid task status values inline.
+var statusComment = strings.Join(model.Statuses, " | ")
+
 func generateInitialWorklogYAML(now time.Time) ([]byte, error) {
 	workData := createInitialWorklog(now)
-	return yaml.Marshal(workData)
+
+	var doc yaml.Node
+	if err := doc.Encode(workData); err != nil {
+		return nil, err
+	}
+	doc.HeadComment = worklogTemplateComment
+	annotateStatusValues(&doc)
+
+	return yaml.Marshal(&doc)
+}
+
+// annotateStatusValues adds a comment listing valid statuses next to every status field.
+func annotateStatusValues(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "status" {
+				node.Content[i+1].LineComment = statusComment
+			}
+		}
+	}
+	for _, child := range node.Content {
+		annotateStatusValues(child)
+	}
 }
 
 func createInitialWorklog(now time.Time) model.WorkData {
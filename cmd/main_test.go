@@ -2,22 +2,38 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 
+	"github.com/bryan-cox/taskledger/internal/hours"
+	"github.com/bryan-cox/taskledger/internal/jira"
 	"github.com/bryan-cox/taskledger/internal/model"
+	"github.com/bryan-cox/taskledger/internal/report"
 )
 
 // --- Test Setup ---
 
 func setupTests(t *testing.T) (string, func()) {
 	t.Helper()
+	// Keep a developer's tokens from sending tests to the real JIRA and GitHub APIs
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("JIRA_PAT", "")
 	content := []byte(`
 "2024-08-01":
   work_log:
@@ -107,11 +123,7 @@ func executeCommandText(t *testing.T, args ...string) string {
 	rootCmd.SetArgs(args)
 
 	// Reset flags to default values before each run
-	rootCmd.PersistentFlags().Set("file", "worklog.yml")
-	hoursCmd.Flags().Set("start-date", "")
-	hoursCmd.Flags().Set("end-date", "")
-	reportCmd.Flags().Set("start-date", "")
-	reportCmd.Flags().Set("end-date", "")
+	resetFlags(rootCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		t.Fatalf("command execution failed: %v", err)
@@ -120,80 +132,2556 @@ func executeCommandText(t *testing.T, args ...string) string {
 	return b.String()
 }
 
+// resetFlags restores every flag on the command tree to its default value so
+// that flag state does not leak between test runs.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.PersistentFlags().VisitAll(reset)
+	cmd.Flags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
 // --- Test Functions ---
 
-func TestHoursCommand(t *testing.T) {
+func TestHoursCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	// Reset slog to default for other tests
+	defer slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	t.Run("calculates hours and outputs text", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01")
+		expected := "Total hours worked from 2024-08-01 to 2024-08-01: 7.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestHoursCommandExpectedHours(t *testing.T) {
+	content := `
+2024-08-05:
+  work_log:
+    - start_time: "09:00"
+      end_time: "18:00"
+2024-08-06:
+  day_type: pto
+2024-08-07:
+  day_type: half
+  work_log:
+    - start_time: "09:00"
+      end_time: "13:00"
+2024-08-08:
+  day_type: holiday
+  work_log:
+    - start_time: "10:00"
+      end_time: "11:00"
+`
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+
+	t.Run("day types add the expected summary", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", path, "--start-date", "2024-08-05", "--end-date", "2024-08-08")
+		expected := "Total hours worked from 2024-08-05 to 2024-08-08: 14.00\n" +
+			"Logged: 14.00, expected: 12.00, delta: +2.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("expected hours sets the standard day", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", path, "--start-date", "2024-08-05", "--end-date", "2024-08-08", "--expected-hours", "10", "--duration-format", "hm")
+		if !strings.HasSuffix(output, "Logged: 14h 00m, expected: 15h 00m, delta: -1h 00m\n") {
+			t.Errorf("Expected a 15h expectation and -1h delta, got:\n%s", output)
+		}
+	})
+
+	t.Run("work days only print the total", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", path, "--start-date", "2024-08-05", "--end-date", "2024-08-05")
+		if expected := "Total hours worked from 2024-08-05 to 2024-08-05: 9.00\n"; output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestHoursCommandDecimalRoundingMatchesAcrossFormats(t *testing.T) {
+	// 7m30s is 0.125 hours, exactly half a cent
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, []byte(`
+2024-08-01:
+  work_log:
+    - start_time: "09:00:00"
+      end_time: "09:07:30"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+	args := []string{"hours", "--file", path, "--start-date", "2024-08-01"}
+
+	if output := executeCommandText(t, args...); output != "Total hours worked from 2024-08-01 to 2024-08-01: 0.13\n" {
+		t.Errorf("Expected 0.13 in the text output, got %q", output)
+	}
+	if output := executeCommandText(t, append(args, "--format", "csv")...); !strings.Contains(output, "2024-08-01,1,0.13\n") || !strings.Contains(output, "total,1,0.13\n") {
+		t.Errorf("Expected 0.13 in the CSV output, got:\n%s", output)
+	}
+	if output := executeCommandText(t, append(args, "--format", "json")...); !strings.Contains(output, `"total_hours": 0.13`) {
+		t.Errorf("Expected 0.13 in the JSON output, got:\n%s", output)
+	}
+}
+
+func TestHoursCommandRounding(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	// 2024-08-01 has two 3h30m entries
+	for mode, want := range map[string]string{"up": "8.00", "down": "6.00", "nearest": "8.00"} {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--rounding", "1h", "--round-mode", mode)
+		if expected := "Total hours worked from 2024-08-01 to 2024-08-01: " + want + "\n"; output != expected {
+			t.Errorf("--round-mode %s: expected %q, got %q", mode, expected, output)
+		}
+	}
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--rounding", "none", "--round-mode", "up")
+	if !strings.HasSuffix(output, ": 7.00\n") {
+		t.Errorf("Expected no rounding with --rounding none, got %q", output)
+	}
+}
+
+func TestParseRounding(t *testing.T) {
+	for value, want := range map[string]time.Duration{"none": 0, "": 0, "15m": 15 * time.Minute, "30m": 30 * time.Minute, "1h": time.Hour} {
+		if got, err := parseRounding(value); err != nil || got != want {
+			t.Errorf("parseRounding(%q) = %s, %v; want %s", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"quarter", "-15m", "0s"} {
+		if _, err := parseRounding(value); err == nil {
+			t.Errorf("parseRounding(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestResolveRelativeDate(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 8, 21, 15, 4, 0, 0, time.Local)
+	// Sunday, which belongs to the week starting the previous Monday
+	sunday := time.Date(2024, 8, 25, 9, 0, 0, 0, time.Local)
+	// Thursday, in a week that starts in July
+	monthBoundary := time.Date(2024, 8, 1, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value       string
+		now         time.Time
+		sundayStart bool
+		wantStart   string
+		wantEnd     string
+	}{
+		{value: "today", now: now, wantStart: "2024-08-21", wantEnd: "2024-08-21"},
+		{value: "yesterday", now: now, wantStart: "2024-08-20", wantEnd: "2024-08-20"},
+		{value: "this-week", now: now, wantStart: "2024-08-19", wantEnd: "2024-08-25"},
+		{value: "this-week", now: sunday, wantStart: "2024-08-19", wantEnd: "2024-08-25"},
+		{value: "last-week", now: now, wantStart: "2024-08-12", wantEnd: "2024-08-18"},
+		{value: "this-week", now: monthBoundary, wantStart: "2024-07-29", wantEnd: "2024-08-04"},
+		{value: "last-week", now: monthBoundary, wantStart: "2024-07-22", wantEnd: "2024-07-28"},
+		{value: "this-week", now: sunday, sundayStart: true, wantStart: "2024-08-25", wantEnd: "2024-08-31"},
+		{value: "this-week", now: monthBoundary, sundayStart: true, wantStart: "2024-07-28", wantEnd: "2024-08-03"},
+		{value: "last-week", now: monthBoundary, sundayStart: true, wantStart: "2024-07-21", wantEnd: "2024-07-27"},
+		{value: "this-month", now: now, wantStart: "2024-08-01", wantEnd: "2024-08-31"},
+		{value: "last-month", now: now, wantStart: "2024-07-01", wantEnd: "2024-07-31"},
+		{value: "last-month", now: time.Date(2024, 3, 31, 0, 0, 0, 0, time.Local), wantStart: "2024-02-01", wantEnd: "2024-02-29"},
+		{value: "2024-08-01", now: now, wantStart: "2024-08-01", wantEnd: "2024-08-01"},
+		{value: "", now: now, wantStart: "", wantEnd: ""},
+	}
+	for _, tt := range tests {
+		weekStart := time.Monday
+		name := tt.value + "@" + tt.now.Format("2006-01-02")
+		if tt.sundayStart {
+			weekStart = time.Sunday
+			name += "/sunday"
+		}
+		t.Run(name, func(t *testing.T) {
+			if got := resolveRelativeDate(tt.value, tt.now, true, weekStart); got != tt.wantStart {
+				t.Errorf("start: got %q, want %q", got, tt.wantStart)
+			}
+			if got := resolveRelativeDate(tt.value, tt.now, false, weekStart); got != tt.wantEnd {
+				t.Errorf("end: got %q, want %q", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestHoursCommandRelativeDates(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	// Saturday, 2024-08-03
+	nowFunc = func() time.Time { return time.Date(2024, 8, 3, 12, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { nowFunc = time.Now })
+
+	t.Run("yesterday", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "yesterday")
+		expected := "Total hours worked from 2024-08-02 to 2024-08-02: 6.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("keyword combined with an explicit date", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-02", "--end-date", "this-week")
+		expected := "Total hours worked from 2024-08-02 to 2024-08-03: 8.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestSinceStartDate(t *testing.T) {
+	now := time.Date(2024, 8, 3, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "1d", want: "2024-08-03"},
+		{value: "7d", want: "2024-07-28"},
+		{value: "2w", want: "2024-07-21"},
+		{value: "1m", want: "2024-07-04"},
+		{value: "0d", wantErr: true},
+		{value: "3y", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "week", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := sinceStartDate(tt.value, now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadDateRange) {
+					t.Errorf("Expected ErrBadDateRange, got %q and error %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sinceStartDate returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("sinceStartDate(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoursCommandSince(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	nowFunc = func() time.Time { return time.Date(2024, 8, 3, 15, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { nowFunc = time.Now })
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--since", "2d")
+	expected := "Total hours worked from 2024-08-02 to 2024-08-03: 8.00\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+
+	if _, err := selectDates(model.WorkData{"2024-08-02": {}}, "2024-08-01", "", "1d", 0); !errors.Is(err, ErrBadDateRange) {
+		t.Errorf("Expected ErrBadDateRange when combining --since with --start-date, got %v", err)
+	}
+}
+
+func TestSelectDatesLast(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {},
+		"2024-08-02": {},
+		"2024-08-19": {},
+	}
+
+	tests := []struct {
+		name      string
+		startDate string
+		last      int
+		want      []string
+		wantErr   bool
+	}{
+		{name: "most recent dates across a gap", last: 2, want: []string{"2024-08-02", "2024-08-19"}},
+		{name: "more than logged", last: 10, want: []string{"2024-08-01", "2024-08-02", "2024-08-19"}},
+		{name: "negative", last: -1, wantErr: true},
+		{name: "combined with start date", startDate: "2024-08-01", last: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectDates(workData, tt.startDate, "", "", tt.last)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadDateRange) {
+					t.Errorf("Expected ErrBadDateRange, got dates %v and error %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectDates returned error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Got dates %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectDatesList(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {},
+		"2024-08-05": {},
+		"2024-08-09": {},
+		"2024-08-10": {},
+	}
+
+	tests := []struct {
+		name      string
+		dates     []string
+		startDate string
+		last      int
+		exclusive bool
+		want      []string
+		wantErr   error
+	}{
+		{name: "sorted and deduplicated", dates: []string{"2024-08-09", "2024-08-01", "2024-08-05", "2024-08-01"}, want: []string{"2024-08-01", "2024-08-05", "2024-08-09"}},
+		{name: "missing dates are skipped", dates: []string{"2024-08-02", "2024-08-05", "2024-08-30"}, want: []string{"2024-08-05"}},
+		{name: "no listed date logged", dates: []string{"2024-08-02", "2024-08-03"}, wantErr: ErrNoData},
+		{name: "invalid date", dates: []string{"2024-08-01", "08/05/2024"}, wantErr: ErrBadDateRange},
+		{name: "combined with start date", dates: []string{"2024-08-01"}, startDate: "2024-08-01", wantErr: ErrBadDateRange},
+		{name: "combined with last", dates: []string{"2024-08-01"}, last: 2, wantErr: ErrBadDateRange},
+		{name: "combined with end-exclusive", dates: []string{"2024-08-01"}, exclusive: true, wantErr: ErrBadDateRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dateList = tt.dates
+			endExclusive = tt.exclusive
+			t.Cleanup(func() { dateList = nil; endExclusive = false })
+
+			got, err := selectDates(workData, tt.startDate, "", "", tt.last)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected %v, got dates %v and error %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectDates returned error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Got dates %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoursCommandDates(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Cleanup(func() { dateList = nil })
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--dates", "2024-08-03,2024-08-01,2024-08-10")
+	expected := "Total hours worked from 2024-08-01 to 2024-08-03: 9.00\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+}
+
+func TestSelectDatesEndExclusive(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {},
+		"2024-08-02": {},
+		"2024-08-03": {},
+	}
+	endExclusive = true
+	t.Cleanup(func() { endExclusive = false })
+
+	tests := []struct {
+		name      string
+		startDate string
+		endDate   string
+		last      int
+		want      []string
+		wantErr   bool
+	}{
+		{name: "drops the end date", startDate: "2024-08-01", endDate: "2024-08-03", want: []string{"2024-08-01", "2024-08-02"}},
+		{name: "end date after the last logged day", startDate: "2024-08-02", endDate: "2024-08-04", want: []string{"2024-08-02", "2024-08-03"}},
+		{name: "start date shorthand still selects that day", startDate: "2024-08-02", want: []string{"2024-08-02"}},
+		{name: "same start and end date", startDate: "2024-08-02", endDate: "2024-08-02", wantErr: true},
+		{name: "end date shorthand", endDate: "2024-08-02", wantErr: true},
+		{name: "combined with last", last: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectDates(workData, tt.startDate, tt.endDate, "", tt.last)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadDateRange) {
+					t.Errorf("Expected ErrBadDateRange, got dates %v and error %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectDates returned error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Got dates %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoursCommandEndExclusive(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("inclusive by default", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03")
+		expected := "Total hours worked from 2024-08-01 to 2024-08-03: 15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("--end-exclusive leaves out the end date", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--end-exclusive")
+		expected := "Total hours worked from 2024-08-01 to 2024-08-02: 13.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("report honors --end-exclusive", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--end-exclusive")
+		if !strings.Contains(output, "Work Report (2024-08-01 to 2024-08-02)") || strings.Contains(output, "SCR-3") {
+			t.Errorf("Expected the report to stop at 2024-08-02, got:\n%s", output)
+		}
+	})
+}
+
+func TestDateRangeExitCodes(t *testing.T) {
+	// When re-executed by the parent test, run the command so its exit code can be observed
+	if args := os.Getenv("TASKLEDGER_EXIT_TEST_ARGS"); args != "" {
+		rootCmd.SetArgs(strings.Split(args, " "))
+		rootCmd.Execute()
+		os.Exit(0)
+	}
+
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		args     string
+		wantCode int
+	}{
+		{name: "report with data", args: "report --file " + tmpFile + " --start-date 2024-08-01", wantCode: 0},
+		{name: "report without data in range", args: "report --file " + tmpFile + " --start-date 2023-01-01", wantCode: exitNoData},
+		{name: "hours without data in range", args: "hours --file " + tmpFile + " --start-date 2023-01-01", wantCode: exitNoData},
+		{name: "report with invalid date", args: "report --file " + tmpFile + " --start-date 2024-13-01", wantCode: exitBadInput},
+		{name: "hours with end before start", args: "hours --file " + tmpFile + " --start-date 2024-08-03 --end-date 2024-08-01", wantCode: exitBadInput},
+		{name: "report with missing file", args: "report --file " + filepath.Join(t.TempDir(), "missing.yml"), wantCode: exitFailure},
+		{name: "report with blockers and --fail-on-blocker", args: "report --file " + tmpFile + " --start-date 2024-08-01 --end-date 2024-08-03 --fail-on-blocker --quiet", wantCode: exitBlocked},
+		{name: "report without blockers and --fail-on-blocker", args: "report --file " + tmpFile + " --start-date 2024-08-01 --fail-on-blocker", wantCode: 0},
+		{name: "unknown log level", args: "hours --file " + tmpFile + " --log-level verbose", wantCode: exitBadInput},
+		{name: "append-clipboard with copy-html", args: "report --file " + tmpFile + " --append-clipboard --copy-html", wantCode: exitBadInput},
+		{name: "wrap with markdown", args: "report --file " + tmpFile + " --wrap 40 --format markdown", wantCode: exitBadInput},
+		{name: "stale-after without a unit", args: "report --file " + tmpFile + " --stale-after 7", wantCode: exitBadInput},
+		{name: "output-dir with output", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --output report.txt", wantCode: exitBadInput},
+		{name: "output-dir with format", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --format json", wantCode: exitBadInput},
+		{name: "output-dir with copy-html", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --copy-html", wantCode: exitBadInput},
+		{name: "output-dir with copy-text", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --copy-text", wantCode: exitBadInput},
+		{name: "output-dir with append-clipboard", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --append-clipboard", wantCode: exitBadInput},
+		{name: "output-dir with slack-webhook", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --slack-webhook http://127.0.0.1:1/hook", wantCode: exitBadInput},
+		{name: "output-dir with dry-run", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --dry-run", wantCode: exitBadInput},
+		{name: "output-dir with html-file", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --html-file report.html", wantCode: exitBadInput},
+		{name: "output-dir with show-html", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --show-html", wantCode: exitBadInput},
+		{name: "output-dir with open-html", args: "report --file " + tmpFile + " --output-dir " + t.TempDir() + " --open-html", wantCode: exitBadInput},
+		{name: "end-exclusive with dates", args: "report --file " + tmpFile + " --dates 2024-08-01 --end-exclusive", wantCode: exitBadInput},
+		{name: "end-exclusive with last", args: "report --file " + tmpFile + " --last 2 --end-exclusive", wantCode: exitBadInput},
+		{name: "hours with zero expected hours", args: "hours --file " + tmpFile + " --expected-hours 0", wantCode: exitBadInput},
+		{name: "hours with unknown time zone", args: "hours --file " + tmpFile + " --timezone Mars/Olympus_Mons", wantCode: exitBadInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestDateRangeExitCodes$")
+			cmd.Env = append(os.Environ(), "TASKLEDGER_EXIT_TEST_ARGS="+tt.args, "JIRA_PAT=")
+			err := cmd.Run()
+
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("Failed to run command: %v", err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("Exit code = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNewLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := newLogHandler(&buf, "debug", "text")
+	if err != nil {
+		t.Fatalf("newLogHandler returned error: %v", err)
+	}
+	slog.New(handler).Debug("fetching ticket", "ticket", "PROJ-1")
+	if got := buf.String(); !strings.Contains(got, "level=DEBUG msg=\"fetching ticket\" ticket=PROJ-1") {
+		t.Errorf("Expected a text debug record, got %q", got)
+	}
+
+	buf.Reset()
+	handler, err = newLogHandler(&buf, "WARN", "json")
+	if err != nil {
+		t.Fatalf("newLogHandler returned error: %v", err)
+	}
+	logger := slog.New(handler)
+	logger.Info("saved report")
+	logger.Warn("skipping empty task")
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a single JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "skipping empty task" {
+		t.Errorf("Unexpected record: %v", record)
+	}
+
+	for _, tt := range []struct{ level, format string }{{"verbose", "json"}, {"info", "logfmt"}} {
+		if _, err := newLogHandler(&buf, tt.level, tt.format); err == nil {
+			t.Errorf("Expected an error for --log-level %s --log-format %s", tt.level, tt.format)
+		}
+	}
+}
+
+func TestHoursCommandTimezone(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--timezone", "UTC")
+	expected := "Total hours worked from 2024-08-01 to 2024-08-01 (UTC): 7.00\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+}
+
+func TestHoursCommandICalFile(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	icsFile := filepath.Join(t.TempDir(), "worklog.ics")
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--ical-file", icsFile)
+	if expected := "Total hours worked from 2024-08-01 to 2024-08-01: 7.00\n"; output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+
+	data, err := os.ReadFile(icsFile)
+	if err != nil {
+		t.Fatalf("Failed to read iCalendar file: %v", err)
+	}
+	ics := string(data)
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("Expected a VCALENDAR, got:\n%s", ics)
+	}
+	if !strings.Contains(ics, "DTSTART:20240801T") {
+		t.Errorf("Expected events on 2024-08-01, got:\n%s", ics)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		style    string
+		want     string
+	}{
+		{name: "decimal", duration: 7*time.Hour + 30*time.Minute, style: durationDecimal, want: "7.50"},
+		{name: "decimal sub-hour", duration: 45 * time.Minute, style: durationDecimal, want: "0.75"},
+		{name: "decimal multi-day", duration: 50*time.Hour + 15*time.Minute, style: durationDecimal, want: "50.25"},
+		{name: "hm", duration: 7 * time.Hour, style: durationHM, want: "7h 00m"},
+		{name: "hm sub-hour", duration: 45 * time.Minute, style: durationHM, want: "0h 45m"},
+		{name: "hm multi-day", duration: 50*time.Hour + 15*time.Minute, style: durationHM, want: "50h 15m"},
+		{name: "iso8601", duration: 7*time.Hour + 30*time.Minute, style: durationISO8601, want: "PT7H30M"},
+		{name: "iso8601 whole hours", duration: 7 * time.Hour, style: durationISO8601, want: "PT7H"},
+		{name: "iso8601 sub-hour", duration: 45 * time.Minute, style: durationISO8601, want: "PT45M"},
+		{name: "iso8601 multi-day", duration: 50*time.Hour + 15*time.Minute, style: durationISO8601, want: "PT50H15M"},
+		{name: "iso8601 zero", duration: 0, style: durationISO8601, want: "PT0M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDuration(tt.duration, tt.style); got != tt.want {
+				t.Errorf("formatDuration(%v, %q) = %q, want %q", tt.duration, tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoursCommandDurationFormat(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--duration-format", "hm")
+	expected := "Total hours worked from 2024-08-01 to 2024-08-03: 15h 00m\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+
+	output = executeCommandText(t, "hours", "--file", tmpFile, "--group-by", "day", "--duration-format", "iso8601")
+	expected = "Hours worked by day from 2024-08-01 to 2024-08-03:\n" +
+		"  2024-08-01: PT7H\n" +
+		"  2024-08-02: PT6H\n" +
+		"  2024-08-03: PT2H\n" +
+		"Total: PT15H\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+}
+
+func TestHoursCommandLast(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--last", "2")
+	expected := "Total hours worked from 2024-08-02 to 2024-08-03: 8.00\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+}
+
+func TestLoadAndMergeWorkData(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "project-a.yml")
+	second := filepath.Join(dir, "project-b.yml")
+	if err := os.WriteFile(first, []byte(`
+"2024-08-01":
+  work_log:
+    - start_time: "09:00"
+      end_time: "12:00"
+  tasks:
+    - jira_ticket: "A-1"
+      description: "Project A work"
+      status: "completed"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+	if err := os.WriteFile(second, []byte(`
+"2024-08-01":
+  work_log:
+    - start_time: "13:00"
+      end_time: "15:00"
+  tasks:
+    - jira_ticket: "B-1"
+      description: "Project B work"
+      status: "completed"
+"2024-08-02":
+  work_log:
+    - start_time: "09:00"
+      end_time: "10:00"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+
+	t.Run("entries for a shared date are concatenated", func(t *testing.T) {
+		workData, err := loadAndMergeWorkData([]string{first, second})
+		if err != nil {
+			t.Fatalf("loadAndMergeWorkData failed: %v", err)
+		}
+		if len(workData) != 2 {
+			t.Fatalf("Expected 2 dates, got %d", len(workData))
+		}
+		shared := workData["2024-08-01"]
+		if len(shared.WorkLogEntries) != 2 || shared.WorkLogEntries[0].StartTime != "09:00" || shared.WorkLogEntries[1].StartTime != "13:00" {
+			t.Errorf("Unexpected merged work log: %+v", shared.WorkLogEntries)
+		}
+		if len(shared.Tasks) != 2 || shared.Tasks[0].JiraTicket != "A-1" || shared.Tasks[1].JiraTicket != "B-1" {
+			t.Errorf("Unexpected merged tasks: %+v", shared.Tasks)
+		}
+	})
+
+	t.Run("missing file is an error", func(t *testing.T) {
+		if _, err := loadAndMergeWorkData([]string{first, filepath.Join(dir, "missing.yml")}); err == nil {
+			t.Error("Expected an error for a missing file")
+		}
+	})
+
+	t.Run("repeated and comma-separated --file", func(t *testing.T) {
+		expected := "Total hours worked from 2024-08-01 to 2024-08-02: 6.00\n"
+		if output := executeCommandText(t, "hours", "--file", first, "--file", second); output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+		if output := executeCommandText(t, "hours", "--file", first+","+second); output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestLoadWorkDataLenient(t *testing.T) {
+	content := []byte(`
+"2024-08-01":
+  work_log:
+    - start_time: "09:00"
+      end_time: "12:00"
+  tasks:
+    - jira_ticket: "SCR-1"
+      description: "Good day one"
+      status: "completed"
+"2024-08-02":
+  work_log: "all day"
+  tasks:
+    - jira_ticket: "SCR-2"
+      description: "Broken day"
+      status: "completed"
+"2024-08-03":
+  work_log:
+    - start_time: "10:00"
+      end_time: "12:00"
+  tasks:
+    - jira_ticket: "SCR-3"
+      description: "Good day three"
+      status: "completed"
+"2024-08-04":
+  work_log:
+    - start_time: "13:00"
+      end_time: "14:00"
+  tasks:
+    - jira_ticket: "SCR-4"
+      description: "Good day four"
+      status: "completed"
+`)
+	tmpFile := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(tmpFile, content, 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+
+	if _, err := loadWorkData(tmpFile); err == nil {
+		t.Fatal("Expected the strict loader to reject the malformed day")
+	}
+
+	workData, err := loadWorkDataLenient(tmpFile)
+	if err != nil {
+		t.Fatalf("loadWorkDataLenient failed: %v", err)
+	}
+	if len(workData) != 3 {
+		t.Fatalf("Expected the 3 good days, got %d: %+v", len(workData), workData)
+	}
+	if _, ok := workData["2024-08-02"]; ok {
+		t.Error("Expected the malformed day to be skipped")
+	}
+	if got := workData["2024-08-03"].Tasks; len(got) != 1 || got[0].Description != "Good day three" {
+		t.Errorf("Unexpected tasks for 2024-08-03: %+v", got)
+	}
+
+	t.Run("report runs on the good days", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--lenient")
+		for _, want := range []string{"Good day one", "Good day three", "Good day four"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Report missing %q:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "Broken day") {
+			t.Errorf("Report should not include the malformed day:\n%s", output)
+		}
+	})
+
+	t.Run("the top level must still be a map of dates", func(t *testing.T) {
+		badFile := filepath.Join(t.TempDir(), "worklog.yml")
+		if err := os.WriteFile(badFile, []byte("- not a map\n"), 0644); err != nil {
+			t.Fatalf("Failed to write worklog: %v", err)
+		}
+		if _, err := loadWorkDataLenient(badFile); err == nil {
+			t.Error("Expected an error for a work log that is not a map of dates")
+		}
+	})
+}
+
+func TestLoadWorkDataJSON(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "worklog.yml")
+	if err := os.WriteFile(yamlFile, []byte(`
+"2024-08-01":
+  work_log:
+    - start_time: "22:00"
+      end_time: "01:00"
+      next_day: true
+      ticket: "PROJ-1"
+  tasks:
+    - jira_ticket: "PROJ-1"
+      description: "Overnight migration"
+      descriptions: ["Verified backups"]
+      status: "completed"
+      github_pr: "https://github.com/example/repo/pull/1"
+      upnext_description: "Clean up"
+      blocker: "Waiting on DBA"
+      qc_goal: "Reliability"
+      tags: ["oncall"]
+`), 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+	jsonContent := []byte(`{
+  "2024-08-01": {
+    "work_log": [
+      {"start_time": "22:00", "end_time": "01:00", "next_day": true, "ticket": "PROJ-1"}
+    ],
+    "tasks": [
+      {
+        "jira_ticket": "PROJ-1",
+        "description": "Overnight migration",
+        "descriptions": ["Verified backups"],
+        "status": "completed",
+        "github_pr": "https://github.com/example/repo/pull/1",
+        "upnext_description": "Clean up",
+        "blocker": "Waiting on DBA",
+        "qc_goal": "Reliability",
+        "tags": ["oncall"]
+      }
+    ]
+  }
+}`)
+	jsonFile := filepath.Join(dir, "worklog.json")
+	if err := os.WriteFile(jsonFile, jsonContent, 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+	exportFile := filepath.Join(dir, "worklog.export")
+	if err := os.WriteFile(exportFile, jsonContent, 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+
+	want, err := loadWorkData(yamlFile)
+	if err != nil {
+		t.Fatalf("Failed to load YAML worklog: %v", err)
+	}
+
+	t.Run("detected from the .json extension", func(t *testing.T) {
+		got, err := loadWorkData(jsonFile)
+		if err != nil {
+			t.Fatalf("Failed to load JSON worklog: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("JSON worklog differs from the equivalent YAML:\ngot:  %+v\nwant: %+v", got, want)
+		}
+	})
+
+	t.Run("--file-format overrides the extension", func(t *testing.T) {
+		expected := "Total hours worked from 2024-08-01 to 2024-08-01: 3.00\n"
+		if output := executeCommandText(t, "hours", "--file", exportFile, "--file-format", "json"); output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("JSON work logs are saved as JSON", func(t *testing.T) {
+		if err := saveWorkData(jsonFile, want); err != nil {
+			t.Fatalf("Failed to save JSON worklog: %v", err)
+		}
+		got, err := loadWorkData(jsonFile)
+		if err != nil {
+			t.Fatalf("Saved worklog is not valid JSON: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Round-tripped JSON worklog differs:\ngot:  %+v\nwant: %+v", got, want)
+		}
+	})
+}
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("WORKLOG_HOME", "/srv/sync")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "~/worklog.yml", want: filepath.Join(home, "worklog.yml")},
+		{path: "~", want: home},
+		{path: "$WORKLOG_HOME/worklog.yml", want: "/srv/sync/worklog.yml"},
+		{path: "${WORKLOG_HOME}/2024.yml", want: "/srv/sync/2024.yml"},
+		{path: "logs/worklog.yml", want: "logs/worklog.yml"},
+		{path: "~other/worklog.yml", want: "~other/worklog.yml"},
+	}
+	for _, tt := range tests {
+		if got := expandPath(tt.path); got != tt.want {
+			t.Errorf("expandPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	t.Run("--file is expanded before reading", func(t *testing.T) {
+		tmpFile, cleanup := setupTests(t)
+		defer cleanup()
+		t.Setenv("WORKLOG_HOME", filepath.Dir(tmpFile))
+
+		output := executeCommandText(t, "hours", "--file", "${WORKLOG_HOME}/"+filepath.Base(tmpFile), "--start-date", "2024-08-01")
+		expected := "Total hours worked from 2024-08-01 to 2024-08-01: 7.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestHoursCommandCSV(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("writes one row per date with a total row", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "csv")
+		expected := "date,entries,hours\n" +
+			"2024-08-01,2,7.00\n" +
+			"2024-08-02,1,6.00\n" +
+			"2024-08-03,1,2.00\n" +
+			"total,4,15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("unparseable times contribute zero hours", func(t *testing.T) {
+		content := []byte(`
+"2024-09-01":
+  work_log:
+    - start_time: "9am"
+      end_time: "12:00"
+"2024-09-02":
+  work_log:
+    - start_time: "09:00"
+      end_time: "10:30"
+`)
+		badFile := filepath.Join(t.TempDir(), "worklog.yml")
+		if err := os.WriteFile(badFile, content, 0644); err != nil {
+			t.Fatalf("Failed to write worklog: %v", err)
+		}
+
+		output := executeCommandText(t, "hours", "--file", badFile, "--format", "csv")
+		expected := "date,entries,hours\n" +
+			"2024-09-01,1,0.00\n" +
+			"2024-09-02,1,1.50\n" +
+			"total,2,1.50\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestHoursCommandMinDuration(t *testing.T) {
+	content := []byte(`
+"2024-09-01":
+  work_log:
+    - start_time: "09:00"
+      end_time: "09:02"
+    - start_time: "10:00"
+      end_time: "11:30"
+`)
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+
+	output := executeCommandText(t, "hours", "--file", path, "--min-duration", "5m", "--format", "csv")
+	expected := "date,entries,hours\n" +
+		"2024-09-01,1,1.50\n" +
+		"total,1,1.50\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+
+	output = executeCommandText(t, "hours", "--file", path)
+	if expected := "Total hours worked from 2024-09-01 to 2024-09-01: 1.53\n"; output != expected {
+		t.Errorf("Expected every entry without --min-duration, got %q", output)
+	}
+}
+
+func TestHoursCommandJSON(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "json")
+	var result struct {
+		TotalHours float64 `json:"total_hours"`
+		Days       []struct {
+			Date    string  `json:"date"`
+			Hours   float64 `json:"hours"`
+			Entries []struct {
+				Start string  `json:"start"`
+				End   string  `json:"end"`
+				Hours float64 `json:"hours"`
+			} `json:"entries"`
+		} `json:"days"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+
+	// Totals match the CSV output for the same range
+	if result.TotalHours != 15 {
+		t.Errorf("Expected total_hours 15, got %v", result.TotalHours)
+	}
+	if len(result.Days) != 3 || result.Days[0].Date != "2024-08-01" || result.Days[0].Hours != 7 || len(result.Days[0].Entries) != 2 {
+		t.Errorf("Unexpected days: %+v", result.Days)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", result.Warnings)
+	}
+}
+
+func TestHoursCommandGroupBy(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("text output lists each bucket and a total", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--group-by", "week")
+		expected := "Hours worked by week from 2024-08-01 to 2024-08-03:\n" +
+			"  2024-W31: 15.00\n" +
+			"Total: 15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("weeks starting sunday are labeled by their first date", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--group-by", "week", "--week-start", "sunday")
+		expected := "Hours worked by week from 2024-08-01 to 2024-08-03:\n" +
+			"  week of 2024-07-28: 15.00\n" +
+			"Total: 15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("csv output names the label column after the grouping", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--group-by", "month", "--format", "csv")
+		expected := "month,entries,hours\n" +
+			"2024-08,4,15.00\n" +
+			"total,4,15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestHoursCommandByTicket(t *testing.T) {
+	t.Run("breaks hours down by work_log ticket", func(t *testing.T) {
+		content := []byte(`
+"2024-09-01":
+  work_log:
+    - start_time: "09:00"
+      end_time: "11:30"
+      ticket: "PROJ-1"
+    - start_time: "13:00"
+      end_time: "14:00"
+`)
+		path := filepath.Join(t.TempDir(), "worklog.yml")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("Failed to write worklog: %v", err)
+		}
+
+		output := executeCommandText(t, "hours", "--file", path, "--by-ticket")
+		expected := "Hours worked by ticket from 2024-09-01 to 2024-09-01:\n" +
+			"  PROJ-1: 2.50\n" +
+			"  Unassigned: 1.00\n" +
+			"Total: 3.50\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("falls back to the total without ticketed entries", func(t *testing.T) {
+		tmpFile, cleanup := setupTests(t)
+		defer cleanup()
+
+		output := executeCommandText(t, "hours", "--file", tmpFile, "--by-ticket")
+		expected := "Total hours worked from 2024-08-01 to 2024-08-03: 15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestHoursCommandOvernight(t *testing.T) {
+	tests := []struct {
+		name     string
+		workLog  string
+		expected string
+	}{
+		{
+			name: "same-day shift",
+			workLog: `
+    - start_time: "09:00"
+      end_time: "17:30"`,
+			expected: "8.50",
+		},
+		{
+			name: "next_day shift wraps past midnight",
+			workLog: `
+    - start_time: "22:00"
+      end_time: "02:00"
+      next_day: true`,
+			expected: "4.00",
+		},
+		{
+			name: "end before start without next_day is skipped",
+			workLog: `
+    - start_time: "22:00"
+      end_time: "02:00"
+    - start_time: "09:00"
+      end_time: "10:00"`,
+			expected: "1.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte("\"2024-09-01\":\n  work_log:" + tt.workLog + "\n")
+			path := filepath.Join(t.TempDir(), "worklog.yml")
+			if err := os.WriteFile(path, content, 0644); err != nil {
+				t.Fatalf("Failed to write worklog: %v", err)
+			}
+
+			output := executeCommandText(t, "hours", "--file", path)
+			expected := "Total hours worked from 2024-09-01 to 2024-09-01: " + tt.expected + "\n"
+			if output != expected {
+				t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+			}
+		})
+	}
+}
+
+func TestHoursCommandOverlaps(t *testing.T) {
+	content := []byte(`
+"2024-09-01":
+  work_log:
+    - start_time: "11:00"
+      end_time: "13:00"
+    - start_time: "09:00"
+      end_time: "12:00"
+    - start_time: "13:00"
+      end_time: "14:00"
+`)
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+
+	t.Run("detects intersecting entries but not touching ones", func(t *testing.T) {
+		workData, err := loadWorkData(path)
+		if err != nil {
+			t.Fatalf("loadWorkData failed: %v", err)
+		}
+		overlaps := hours.FindOverlaps(workData, []string{"2024-09-01"}, hours.Options{})
+		if len(overlaps) != 1 {
+			t.Fatalf("Expected 1 overlap, got %d: %+v", len(overlaps), overlaps)
+		}
+		if overlaps[0].First.StartTime != "09:00" || overlaps[0].Second.StartTime != "11:00" {
+			t.Errorf("Unexpected overlap entries: %+v", overlaps[0])
+		}
+	})
+
+	t.Run("double counts overlaps by default", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", path)
+		expected := "Total hours worked from 2024-09-01 to 2024-09-01: 6.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("merges overlaps when requested", func(t *testing.T) {
+		output := executeCommandText(t, "hours", "--file", path, "--merge-overlaps")
+		expected := "Total hours worked from 2024-09-01 to 2024-09-01: 5.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+}
+
+func TestReportCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("generates a human-readable report", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03")
+
+		// Check for key parts of the text report
+		if !strings.Contains(output, "Work Report (2024-08-01 to 2024-08-03)") {
+			t.Error("Report missing correct title")
+		}
+		if !strings.Contains(output, "🦀 Thing I've been working on") {
+			t.Error("Report missing 'Thing I've been working on' section")
+		}
+		if !strings.Contains(output, "SCR-1:") {
+			t.Error("Report missing completed Jira ticket SCR-1")
+		}
+		if !strings.Contains(output, "◦ Set up the Go module and initial file structure.") {
+			t.Error("Report missing completed task description with bullet")
+		}
+		if !strings.Contains(output, ":starfleet: Thing I plan on working on next") {
+			t.Error("Report missing 'Thing I plan on working on next' section")
+		}
+		if !strings.Contains(output, "• SCR-2") {
+			t.Error("Report missing next up task with bullet")
+		}
+		if !strings.Contains(output, ":facepalm: Thing that is blocking me or that I could use some help / discussion about") {
+			t.Error("Report missing 'Blockers' section")
+		}
+		if !strings.Contains(output, "SCR-2") {
+			t.Error("Report missing blocked task ticket")
+		}
+		if !strings.Contains(output, "◦ Blocker: Waiting on final YAML structure.") {
+			t.Error("Report missing blocker description with bullet")
+		}
+		if !strings.Contains(output, "◦ Since 2024-08-02: Implement the structs and parsing logic for the worklog YAML.") {
+			t.Error("Report missing blocked task date and description")
+		}
+		// Check for GitHub PR integration
+		if !strings.Contains(output, "PR(s): https://github.com/example/repo/pull/123") {
+			t.Error("Report missing GitHub PR link")
+		}
+		// Check for non-feature work grouping (tasks without Jira tickets)
+		if !strings.Contains(output, "• Non-feature work") {
+			t.Error("Report should include 'Non-feature work' header for tasks without Jira tickets")
+		}
+		// Non-feature work items are now shown as individual sub-entries with first description as header
+		if !strings.Contains(output, "◦ Organized project documentation and created initial README.") {
+			t.Error("Report should include completed task with PR as its own sub-entry under Non-feature work")
+		}
+		if !strings.Contains(output, "◦ Updated team wiki with new development processes.") {
+			t.Error("Report should include completed task without PR as its own sub-entry under Non-feature work")
+		}
+		if !strings.Contains(output, "◦ Run linter and fix all warnings") {
+			t.Error("Report should include next up task that has no Jira ticket under Non-feature work")
+		}
+		// Check for PR links for tasks without Jira tickets
+		if !strings.Contains(output, "PR(s): https://github.com/example/repo/pull/456") {
+			t.Error("Report should include PR link for task that has no Jira ticket")
+		}
+	})
+}
+
+func TestReportCommandTicketFilter(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("exact key", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--ticket", "SCR-2")
+
+		if !strings.Contains(output, "• SCR-2") {
+			t.Errorf("Report missing filtered ticket SCR-2:\n%s", output)
+		}
+		if !strings.Contains(output, "◦ Blocker: Waiting on final YAML structure.") {
+			t.Errorf("Report missing blocker for SCR-2:\n%s", output)
+		}
+		for _, excluded := range []string{"SCR-1", "SCR-3", "PROJ-99", "Non-feature work"} {
+			if strings.Contains(output, excluded) {
+				t.Errorf("Report should not include %q:\n%s", excluded, output)
+			}
+		}
+	})
+
+	t.Run("full URL and repeated flag", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile,
+			"--ticket", "https://issues.redhat.com/browse/PROJ-99", "--ticket", "SCR-1")
+
+		for _, included := range []string{"SCR-1", "PROJ-99"} {
+			if !strings.Contains(output, included) {
+				t.Errorf("Report missing filtered ticket %q:\n%s", included, output)
+			}
+		}
+		for _, excluded := range []string{"SCR-2", "SCR-3", "Non-feature work"} {
+			if strings.Contains(output, excluded) {
+				t.Errorf("Report should not include %q:\n%s", excluded, output)
+			}
+		}
+	})
+}
+
+func TestReportCommandExcludeFilters(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		args     []string
+		included []string
+		excluded []string
+	}{
+		{
+			name:     "exclude tickets",
+			args:     []string{"--exclude-ticket", "https://issues.redhat.com/browse/PROJ-99", "--exclude-ticket", "SCR-1"},
+			included: []string{"SCR-2", "SCR-3", "Non-feature work"},
+			excluded: []string{"PROJ-99", "SCR-1"},
+		},
+		{
+			name:     "exclude statuses case-insensitively",
+			args:     []string{"--exclude-status", "In Progress"},
+			included: []string{"SCR-1", "PROJ-99"},
+			excluded: []string{"SCR-2", "SCR-3"},
+		},
+		{
+			name:     "include then exclude",
+			args:     []string{"--ticket", "SCR-1", "--ticket", "SCR-2", "--ticket", "SCR-3", "--exclude-ticket", "SCR-2", "--exclude-status", "IN PROGRESS"},
+			included: []string{"SCR-1"},
+			excluded: []string{"SCR-2", "SCR-3", "PROJ-99", "Non-feature work"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := executeCommandText(t, append([]string{"report", "--file", tmpFile}, tt.args...)...)
+			for _, included := range tt.included {
+				if !strings.Contains(output, included) {
+					t.Errorf("Report missing %q:\n%s", included, output)
+				}
+			}
+			for _, excluded := range tt.excluded {
+				if strings.Contains(output, excluded) {
+					t.Errorf("Report should not include %q:\n%s", excluded, output)
+				}
+			}
+		})
+	}
+}
+
+func TestReportCommandPRList(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("text output", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--prs")
+		expected := "Pull Requests (2024-08-01 to 2024-08-03)\n" +
+			"    • https://github.com/example/repo/pull/123\n" +
+			"    • https://github.com/example/repo/pull/456\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--prs", "--format", "json", "--start-date", "2024-08-02", "--end-date", "2024-08-02")
+		var entries []prListEntry
+		if err := json.Unmarshal([]byte(output), &entries); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		if len(entries) != 1 || entries[0] != (prListEntry{URL: "https://github.com/example/repo/pull/123"}) {
+			t.Errorf("Unexpected pull requests: %+v", entries)
+		}
+	})
+
+	t.Run("no pull requests", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--prs", "--start-date", "2024-08-03", "--end-date", "2024-08-03")
+		if output != "No pull requests from 2024-08-03 to 2024-08-03.\n" {
+			t.Errorf("Unexpected output: %q", output)
+		}
+		output = executeCommandText(t, "report", "--file", tmpFile, "--prs", "--format", "json", "--start-date", "2024-08-03", "--end-date", "2024-08-03")
+		if output != "[]\n" {
+			t.Errorf("Expected an empty JSON array, got %q", output)
+		}
+	})
+}
+
+func TestReportCommandRedact(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	originals := []string{"SCR-", "PROJ-99", "issues.redhat.com", "github.com"}
+	for _, format := range []string{"text", "json", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			output := executeCommandText(t, "report", "--file", tmpFile, "--redact", "--format", format, "--show-html")
+			for _, original := range originals {
+				if strings.Contains(output, original) {
+					t.Errorf("Redacted %s report leaks %q:\n%s", format, original, output)
+				}
+			}
+			for _, want := range []string{"TICKET-1", "PR-1", "Set up the Go module and initial file structure."} {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected %q in the redacted %s report:\n%s", want, format, output)
+				}
+			}
+		})
+	}
+
+	t.Run("mapping", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--redact", "--redact-map", "--prs")
+		for _, want := range []string{"TICKET-1\tSCR-1\n", "PR-1\thttps://github.com/example/repo/pull/456\n", "    • PR-1\n"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in the output:\n%s", want, output)
+			}
+		}
+	})
+}
+
+func TestReportCommandMergeAcrossStatus(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	sections := func(args ...string) (completed, nextUp []string) {
+		output := executeCommandText(t, append([]string{"report", "--file", tmpFile, "--format", "json"}, args...)...)
+		var result struct {
+			Completed []struct{ Ticket string } `json:"completed"`
+			NextUp    []struct{ Ticket string } `json:"next_up"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		for _, entry := range result.Completed {
+			completed = append(completed, entry.Ticket)
+		}
+		for _, entry := range result.NextUp {
+			nextUp = append(nextUp, entry.Ticket)
+		}
+		return completed, nextUp
+	}
+
+	completed, nextUp := sections()
+	if !slices.Contains(completed, "SCR-2") || !slices.Contains(nextUp, "SCR-2") {
+		t.Errorf("Expected SCR-2 in both sections by default, got completed %v and next up %v", completed, nextUp)
+	}
+
+	completed, nextUp = sections("--merge-same-ticket-across-status")
+	for _, ticket := range []string{"SCR-2", "SCR-3"} {
+		if slices.Contains(completed, ticket) || !slices.Contains(nextUp, ticket) {
+			t.Errorf("Expected %s only in next up, got completed %v and next up %v", ticket, completed, nextUp)
+		}
+	}
+	for _, ticket := range []string{"SCR-1", "PROJ-99"} {
+		// PROJ-99 is logged as a browse URL
+		if !slices.ContainsFunc(completed, func(c string) bool { return strings.HasSuffix(c, ticket) }) {
+			t.Errorf("Expected %s to stay completed, got %v", ticket, completed)
+		}
+	}
+}
+
+func TestReportCommandPROrder(t *testing.T) {
+	t.Setenv("JIRA_PAT", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	// pull/9 is logged first but sorts after pull/10 by URL
+	tmpFile := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(tmpFile, []byte(`
+2024-08-01:
+  tasks:
+    - jira_ticket: "PROJ-5"
+      status: "completed"
+      description: "First pass"
+      github_pr: "https://github.com/example/repo/pull/9"
+2024-08-02:
+  tasks:
+    - jira_ticket: "PROJ-5"
+      status: "completed"
+      description: "Follow-up"
+      github_pr: "https://github.com/example/repo/pull/10"
+    - jira_ticket: "PROJ-5"
+      status: "completed"
+      description: "Review fixes"
+      github_pr: "https://github.com/example/repo/pull/9"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+
+	const pr9, pr10 = "https://github.com/example/repo/pull/9", "https://github.com/example/repo/pull/10"
+	tests := []struct {
+		order    string
+		wantText string
+		wantHTML string
+	}{
+		{
+			order:    "url",
+			wantText: "PR(s): " + pr10 + "; " + pr9 + "\n",
+			wantHTML: `PR(s): <a href="` + pr10 + `">` + pr10 + `</a>; <a href="` + pr9 + `">` + pr9 + `</a></li>`,
+		},
+		{
+			order:    "chrono",
+			wantText: "PR(s): " + pr9 + "; " + pr10 + "\n",
+			wantHTML: `PR(s): <a href="` + pr9 + `">` + pr9 + `</a>; <a href="` + pr10 + `">` + pr10 + `</a></li>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-02", "--pr-order", tt.order, "--show-html")
+			if !strings.Contains(output, tt.wantText) {
+				t.Errorf("Expected text PR links %q, got:\n%s", tt.wantText, output)
+			}
+			if !strings.Contains(output, tt.wantHTML) {
+				t.Errorf("Expected HTML PR links %q, got:\n%s", tt.wantHTML, output)
+			}
+		})
+	}
+}
+
+func TestReportCommandNonFeatureGrouping(t *testing.T) {
+	t.Setenv("JIRA_PAT", "")
+	tmpFile := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(tmpFile, []byte(`
+2024-08-01:
+  tasks:
+    - jira_ticket: "PROJ-5"
+      status: "completed"
+      description: "Feature"
+    - status: "completed"
+      description: "Docs cleanup"
+    - jira_ticket: "NO-JIRA"
+      status: "completed"
+      description: "Triage"
+    - jira_ticket: "ABC-1"
+      status: "completed"
+      description: "Fix"
+    - status: "in progress"
+      description: "Waiting on legal"
+      blocker: "Review pending"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+	args := []string{"report", "--file", tmpFile, "--start-date", "2024-08-01"}
+
+	t.Run("grouped by default", func(t *testing.T) {
+		output := executeCommandText(t, args...)
+		expected := "    • ABC-1: \n" +
+			"        ◦ Fix\n" +
+			"    • PROJ-5: \n" +
+			"        ◦ Feature\n" +
+			"    • Non-feature work: \n" +
+			"        ◦ NO-JIRA\n" +
+			"            ▪ Triage\n" +
+			"        ◦ Docs cleanup\n"
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected grouped completed section:\n%s\nGot:\n%s", expected, output)
+		}
+		if blocked := "        ◦ Misc\n            ▪ Blocker: Review pending\n"; !strings.Contains(output, blocked) {
+			t.Errorf("Expected the blocked task under the non-feature group:\n%s\nGot:\n%s", blocked, output)
+		}
+	})
+
+	t.Run("flat", func(t *testing.T) {
+		output := executeCommandText(t, append(args, "--no-nonfeature-grouping")...)
+		expected := "    • ABC-1: \n" +
+			"        ◦ Fix\n" +
+			"    • PROJ-5: \n" +
+			"        ◦ Feature\n" +
+			"    • NO-JIRA: \n" +
+			"        ◦ Triage\n" +
+			"    • Docs cleanup: \n"
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected flat completed section:\n%s\nGot:\n%s", expected, output)
+		}
+		if strings.Contains(output, "Non-feature work") {
+			t.Errorf("Expected no non-feature group, got:\n%s", output)
+		}
+		if blocked := "    • Waiting on legal \n        ◦ Blocker: Review pending\n"; !strings.Contains(output, blocked) || strings.Contains(output, "Misc") {
+			t.Errorf("Expected the blocked task headed by its description:\n%s\nGot:\n%s", blocked, output)
+		}
+
+		output = executeCommandText(t, append(args, "--no-nonfeature-grouping", "--format", "markdown")...)
+		if !strings.Contains(output, "- **NO-JIRA**\n  - Triage\n- **Docs cleanup**\n") || strings.Contains(output, "Non-feature work") {
+			t.Errorf("Expected flat Markdown entries, got:\n%s", output)
+		}
+	})
+}
+
+func TestReportCommandWrap(t *testing.T) {
+	t.Setenv("JIRA_PAT", "")
+	tmpFile := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(tmpFile, []byte(`
+2024-08-01:
+  tasks:
+    - jira_ticket: "PROJ-5"
+      status: "completed"
+      description: "Added retries to the token refresh path"
+    - jira_ticket: "NO-JIRA"
+      status: "completed"
+      description: "Rotated the staging certificates before expiry"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--wrap", "33")
+	expected := "    • PROJ-5: \n" +
+		"        ◦ Added retries to the\n" +
+		"          token refresh path\n" +
+		"    • Non-feature work: \n" +
+		"        ◦ NO-JIRA\n" +
+		"            ▪ Rotated the staging\n" +
+		"              certificates before\n" +
+		"              expiry\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected wrapped completed section:\n%s\nGot:\n%s", expected, output)
+	}
+}
+
+func TestReportCommandStaleAfter(t *testing.T) {
+	t.Setenv("JIRA_PAT", "")
+	tmpFile := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(tmpFile, []byte(`
+2024-08-01:
+  tasks:
+    - jira_ticket: "PROJ-1"
+      status: "in progress"
+      description: "Started the parser"
+2024-08-11:
+  tasks:
+    - jira_ticket: "PROJ-2"
+      status: "in progress"
+      description: "Started the cache"
+`), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+	args := []string{"report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-11"}
+
+	output := executeCommandText(t, append(args, "--stale-after", "7d")...)
+	if !strings.HasSuffix(output, "\n⏳ Possibly stale\n    • PROJ-1: 10 days since 2024-08-01\n") {
+		t.Errorf("Expected PROJ-1 as possibly stale, got:\n%s", output)
+	}
+
+	output = executeCommandText(t, append(args, "--stale-after", "2w", "--format", "markdown")...)
+	if strings.Contains(output, "Possibly stale") {
+		t.Errorf("Expected no stale tickets at 2w, got:\n%s", output)
+	}
+
+	output = executeCommandText(t, "stats", "--file", tmpFile, "--stale-after", "7d")
+	if !strings.HasSuffix(output, "  Possibly stale tickets: 1\n    PROJ-1: 10 days since 2024-08-01\n") {
+		t.Errorf("Expected PROJ-1 in the stats, got:\n%s", output)
+	}
+}
+
+func TestParseStaleAfter(t *testing.T) {
+	nowFunc = func() time.Time { return time.Date(2024, 8, 10, 12, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { nowFunc = time.Now })
+
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "7d", want: 7},
+		{value: "2w", want: 14},
+		{value: "1m", want: 31},
+		{value: "7", wantErr: true},
+		{value: "0d", wantErr: true},
+		{value: "d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseStaleAfter(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseStaleAfter(%q) = %d, %v; want %d, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestReportCommandTags(t *testing.T) {
+	content := []byte(`
+"2024-08-01":
+  tasks:
+    - jira_ticket: "PROJ-1"
+      description: "Reviewed the parser."
+      status: "completed"
+      tags: ["review"]
+    - jira_ticket: "PROJ-2"
+      description: "Sprint planning."
+      status: "completed"
+      tags: ["meeting"]
+    - jira_ticket: "PROJ-3"
+      description: "Shipped the feature."
+      status: "completed"
+`)
+	tmpFile := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(tmpFile, content, 0o644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--tag", "review", "--tag", "oncall")
+		if !strings.Contains(output, "• PROJ-1: ") {
+			t.Errorf("Report missing tagged ticket PROJ-1:\n%s", output)
+		}
+		for _, excluded := range []string{"PROJ-2", "PROJ-3"} {
+			if strings.Contains(output, excluded) {
+				t.Errorf("Report should not include %q:\n%s", excluded, output)
+			}
+		}
+	})
+
+	t.Run("group by tag", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--group-by", "tag")
+		for _, want := range []string{
+			"    • #meeting: \n        ◦ PROJ-2: Sprint planning.\n",
+			"    • #review: \n        ◦ PROJ-1: Reviewed the parser.\n",
+			"    • PROJ-3: \n        ◦ Shipped the feature.\n",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Report missing %q:\n%s", want, output)
+			}
+		}
+	})
+}
+
+func TestReportCommandGroupByDate(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--group-by", "date")
+
+	// Split the report into the part under each date header, in order
+	days := strings.Split(output, "\n📅 ")
+	if len(days) != 4 {
+		t.Fatalf("Expected 3 date headers, got:\n%s", output)
+	}
+	want := map[string][]string{
+		"2024-08-01": {"    • SCR-1 [completed] Set up the Go module and initial file structure.\n", "    • [completed] Organized project documentation and created initial README.\n"},
+		"2024-08-02": {"    • SCR-2 [in progress] ", "        ◦ Blocker: Waiting on final YAML structure.\n", "    • PROJ-99 [completed] ", "        ◦ PR: https://github.com/example/repo/pull/123\n"},
+		"2024-08-03": {"    • SCR-3 [in progress] "},
+	}
+	for i, date := range []string{"2024-08-01", "2024-08-02", "2024-08-03"} {
+		day := days[i+1]
+		if !strings.HasPrefix(day, date+"\n") {
+			t.Errorf("Expected header %d to be %s, got:\n%s", i+1, date, day)
+			continue
+		}
+		for _, entry := range want[date] {
+			if !strings.Contains(day, entry) {
+				t.Errorf("Expected %q under %s, got:\n%s", entry, date, day)
+			}
+		}
+	}
+	if strings.Contains(days[1], "SCR-2") || strings.Contains(days[3], "SCR-1") {
+		t.Errorf("Expected each task only under its own date, got:\n%s", output)
+	}
+}
+
+func TestReportCommandMarkdownFormat(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "markdown")
+
+	expected := []string{
+		"# Work Report (2024-08-01 to 2024-08-03)",
+		"## 🦀 Things I've been working on",
+		"- **[SCR-1](https://issues.redhat.com/browse/SCR-1)**",
+		"  - Set up the Go module and initial file structure.",
+		"  - PR(s): [https://github.com/example/repo/pull/123](https://github.com/example/repo/pull/123)",
+		"- **Non-feature work**",
+		"  - Organized project documentation and created initial README.",
+		"## ⭐ Things I plan on working on next",
+		"- **[SCR-2](https://issues.redhat.com/browse/SCR-2)**",
+		"  - Continue working on YAML parsing logic",
+		"  - Run linter and fix all warnings",
+		"## 🚫 Things that are blocking me",
+		"  - Blocker: Waiting on final YAML structure.",
+		"  - Since 2024-08-02: Implement the structs and parsing logic for the worklog YAML.",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Markdown report missing %q\nGot:\n%s", want, output)
+		}
+	}
+
+	// Slack-specific text output should not leak into markdown
+	if strings.Contains(output, ":starfleet:") || strings.Contains(output, "•") {
+		t.Errorf("Markdown report should not contain text-format markers\nGot:\n%s", output)
+	}
+}
+
+func TestReportCommandOutputFile(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	outPath := filepath.Join(t.TempDir(), "report.md")
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "markdown", "-o", outPath)
+
+	if output != "✅ Report saved to: "+outPath+"\n" {
+		t.Errorf("Expected only the confirmation on stdout, got:\n%s", output)
+	}
+
+	content, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("Failed to read report file: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "# Work Report (2024-08-01 to 2024-08-01)") {
+		t.Errorf("Unexpected report file content:\n%s", content)
+	}
+	if strings.Contains(string(content), "saved to") {
+		t.Errorf("Status messages leaked into the report file:\n%s", content)
+	}
+}
+
+func TestReportCommandOutputDir(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	dir := filepath.Join(t.TempDir(), "weekly", "2024-08")
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--output-dir", dir, "--with-hours")
+
+	for _, tt := range []struct {
+		name    string
+		content string
+	}{
+		{name: "report-2024-08-01_2024-08-03.txt", content: "Work Report (2024-08-01 to 2024-08-03)"},
+		{name: "report-2024-08-01_2024-08-03.html", content: "<h1>Work Report (2024-08-01 to 2024-08-03)</h1>"},
+		{name: "report-2024-08-01_2024-08-03.json", content: `"completed": [`},
+		{name: "hours-2024-08-01_2024-08-03.csv", content: "date,entries,hours"},
+	} {
+		path := filepath.Join(dir, tt.name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", tt.name, err)
+			continue
+		}
+		if !strings.Contains(string(content), tt.content) {
+			t.Errorf("Expected %q in %s, got:\n%s", tt.content, tt.name, content)
+		}
+		if !strings.Contains(output, "✅ Saved: "+path+"\n") {
+			t.Errorf("Expected %s to be reported, got:\n%s", path, output)
+		}
+	}
+	if strings.Contains(output, "Work Report") {
+		t.Errorf("Expected only the saved paths on stdout, got:\n%s", output)
+	}
+
+	// Without --with-hours there is no CSV, and a single day is named once
+	dir = t.TempDir()
+	executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--output-dir", dir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to list %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"report-2024-08-01.html", "report-2024-08-01.json", "report-2024-08-01.txt"}; !slices.Equal(names, want) {
+		t.Errorf("Expected files %v, got %v", want, names)
+	}
+}
+
+func TestReportCommandOpenHTML(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	var opened []string
+	browserOpener = func(path string) error {
+		opened = append(opened, path)
+		return nil
+	}
+	t.Cleanup(func() { browserOpener = openHTMLInBrowser })
+
+	t.Run("without --html-file uses a temporary file", func(t *testing.T) {
+		opened = nil
+		executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--open-html")
+
+		if len(opened) != 1 {
+			t.Fatalf("Expected one file to be opened, got %v", opened)
+		}
+		defer os.Remove(opened[0])
+		content, err := os.ReadFile(opened[0])
+		if err != nil {
+			t.Fatalf("Failed to read temporary HTML file: %v", err)
+		}
+		if !strings.Contains(string(content), "SCR-1") {
+			t.Errorf("Temporary HTML file is missing the report:\n%s", content)
+		}
+	})
+
+	t.Run("with --html-file opens that file", func(t *testing.T) {
+		opened = nil
+		htmlPath := filepath.Join(t.TempDir(), "report.html")
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--open-html", "--html-file", htmlPath)
+
+		if len(opened) != 1 || opened[0] != htmlPath {
+			t.Errorf("Expected %s to be opened, got %v", htmlPath, opened)
+		}
+		if !strings.Contains(output, "✅ HTML report saved to: "+htmlPath) {
+			t.Errorf("Missing save confirmation:\n%s", output)
+		}
+	})
+}
+
+func TestReportCommandQuiet(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	browserOpener = func(string) error { return nil }
+	t.Cleanup(func() { browserOpener = openHTMLInBrowser })
+
+	htmlPath := filepath.Join(t.TempDir(), "report.html")
+	args := []string{"report", "--file", tmpFile, "--start-date", "2024-08-01", "--html-file", htmlPath, "--open-html"}
+	confirmations := []string{"✅ HTML report saved to:", "🌐 Opened HTML report in default browser"}
+
+	output := executeCommandText(t, args...)
+	for _, want := range confirmations {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q without --quiet, got:\n%s", want, output)
+		}
+	}
+
+	output = executeCommandText(t, append(args, "--quiet")...)
+	for _, unwanted := range confirmations {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected no %q with --quiet, got:\n%s", unwanted, output)
+		}
+	}
+	if !strings.Contains(output, "Set up the Go module and initial file structure.") {
+		t.Errorf("Expected the report body with --quiet, got:\n%s", output)
+	}
+	if content, err := os.ReadFile(htmlPath); err != nil || !strings.Contains(string(content), "SCR-1") {
+		t.Errorf("Expected the HTML report to still be saved with --quiet, got %q (%v)", content, err)
+	}
+
+	reportFile := filepath.Join(t.TempDir(), "report.txt")
+	if output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--output", reportFile, "-q"); output != "" {
+		t.Errorf("Expected no output with --output and -q, got:\n%s", output)
+	}
+}
+
+func TestReportCommandColor(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	args := []string{"report", "--file", tmpFile, "--start-date", "2024-08-01"}
+	plain := executeCommandText(t, args...)
+
+	tests := []struct {
+		name      string
+		flags     []string
+		noColor   string
+		wantColor bool
+	}{
+		{name: "not a terminal", wantColor: false},
+		{name: "forced", flags: []string{"--color"}, wantColor: true},
+		{name: "forced despite NO_COLOR", flags: []string{"--color"}, noColor: "1", wantColor: true},
+		{name: "no-color wins", flags: []string{"--color", "--no-color"}, wantColor: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			output := executeCommandText(t, append(args, tt.flags...)...)
+
+			if got := strings.Contains(output, "\033["); got != tt.wantColor {
+				t.Errorf("Colored = %v, want %v:\n%q", got, tt.wantColor, output)
+			}
+			if !tt.wantColor && output != plain {
+				t.Errorf("Uncolored output differs from the plain report:\ngot:  %q\nwant: %q", output, plain)
+			}
+		})
+	}
+}
+
+func TestReportCommandWithHours(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("appends the total for the report range", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--with-hours")
+		if !strings.HasSuffix(output, "\nTotal hours: 15.00\n") {
+			t.Errorf("Expected the total hours line at the end, got:\n%s", output)
+		}
+	})
+
+	t.Run("honors --duration-format", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-02", "--with-hours", "--duration-format", "hm", "--format", "markdown")
+		if !strings.HasSuffix(output, "\nTotal hours: 6h 00m\n") {
+			t.Errorf("Expected the total hours line in hm format, got:\n%s", output)
+		}
+	})
+
+	t.Run("renders an HTML footer", func(t *testing.T) {
+		htmlPath := filepath.Join(t.TempDir(), "report.html")
+		executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--with-hours", "--html-file", htmlPath)
+		content, err := os.ReadFile(htmlPath)
+		if err != nil {
+			t.Fatalf("Failed to read HTML report: %v", err)
+		}
+		if !strings.Contains(string(content), "<p><small>Total hours: 7.00</small></p></body></html>") {
+			t.Errorf("Expected the total hours footer, got:\n%s", content)
+		}
+	})
+
+	t.Run("is left out by default", func(t *testing.T) {
+		if output := executeCommandText(t, "report", "--file", tmpFile); strings.Contains(output, "Total hours") {
+			t.Errorf("Expected no total hours line, got:\n%s", output)
+		}
+	})
+}
+
+func TestReportCommandTemplate(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	templateFile := filepath.Join(t.TempDir(), "standup.tmpl")
+	custom := `Standup {{.EndDate}}
+Shipped:
+{{range sortedTickets .Tasks.Completed}}{{if not (isNonFeature . (index $.Tasks.Completed .))}}- {{.}}
+{{end}}{{end}}Blocked:
+{{range .Tasks.Blocked}}- {{.JiraTicket}}: {{.Blocker}}
+{{end}}`
+	if err := os.WriteFile(templateFile, []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-02", "--template", templateFile)
+	expected := "Standup 2024-08-02\n" +
+		"Shipped:\n" +
+		"- PROJ-99\n" +
+		"- SCR-2\n" +
+		"Blocked:\n" +
+		"- SCR-2: Waiting on final YAML structure.\n"
+	if output != expected {
+		t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+	}
+}
+
+func TestReportCommandHTMLTemplate(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	dir := t.TempDir()
+	templateFile := filepath.Join(dir, "wiki.html.tmpl")
+	custom := `<h2>Shipped {{.StartDate}}</h2>{{range sortedTickets .Tasks.Completed}}{{if not (isNonFeature . (index $.Tasks.Completed .))}}<p>{{.}}</p>{{end}}{{end}}`
+	if err := os.WriteFile(templateFile, []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	htmlPath := filepath.Join(dir, "report.html")
+	executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--html-template", templateFile, "--html-file", htmlPath)
+	content, err := os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatalf("Failed to read HTML report: %v", err)
+	}
+	expected := "<h2>Shipped 2024-08-01</h2><p>SCR-1</p>"
+	if string(content) != expected {
+		t.Errorf("Expected HTML:\n%q\nGot:\n%q", expected, content)
+	}
+}
+
+func TestReportCommandByProject(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("prints a sub-report per project", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-02", "--by-project")
+
+		var titles []string
+		for _, line := range strings.Split(output, "\n") {
+			if strings.HasPrefix(line, "Work Report") {
+				titles = append(titles, line)
+			}
+		}
+		want := []string{
+			"Work Report for PROJ (2024-08-02 to 2024-08-02)",
+			"Work Report for SCR (2024-08-02 to 2024-08-02)",
+			"Work Report for Other (2024-08-02 to 2024-08-02)",
+		}
+		if !reflect.DeepEqual(titles, want) {
+			t.Fatalf("Expected sub-reports %v, got %v in:\n%s", want, titles, output)
+		}
+
+		// Each ticket only shows up in its own project's sub-report
+		proj, rest, _ := strings.Cut(output, want[1])
+		scr, other, _ := strings.Cut(rest, want[2])
+		if !strings.Contains(proj, "PROJ-99") || strings.Contains(proj, "SCR-2") {
+			t.Errorf("Unexpected PROJ sub-report:\n%s", proj)
+		}
+		if !strings.Contains(scr, "SCR-2") || !strings.Contains(scr, "Blocker: Waiting on final YAML structure.") || strings.Contains(scr, "PROJ-99") {
+			t.Errorf("Unexpected SCR sub-report:\n%s", scr)
+		}
+		if strings.Contains(other, "SCR-2") || strings.Contains(other, "PROJ-99") || !strings.Contains(other, "Non-feature work") {
+			t.Errorf("Unexpected Other sub-report:\n%s", other)
+		}
+	})
+
+	t.Run("explicit project values", func(t *testing.T) {
+		content := []byte(`
+"2024-08-01":
+  tasks:
+    - jira_ticket: "SCR-1"
+      description: "Parser"
+      status: "completed"
+      project: "Compiler"
+    - jira_ticket: "PROJ-1"
+      description: "Lexer"
+      status: "completed"
+      project: "Compiler"
+`)
+		path := filepath.Join(t.TempDir(), "worklog.yml")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("Failed to write worklog: %v", err)
+		}
+
+		output := executeCommandText(t, "report", "--file", path, "--by-project")
+		if strings.Count(output, "Work Report") != 1 || !strings.Contains(output, "Work Report for Compiler (2024-08-01 to 2024-08-01)") {
+			t.Errorf("Expected a single Compiler sub-report, got:\n%s", output)
+		}
+	})
+}
+
+func TestReportCommandSectionHeaders(t *testing.T) {
 	tmpFile, cleanup := setupTests(t)
 	defer cleanup()
 
-	// Reset slog to default for other tests
-	defer slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	headers := []string{"--header-completed", "Done", "--header-nextup", "Up next", "--header-blocked", "Help wanted & blockers"}
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		return executeCommandText(t, append(append([]string{"report", "--file", tmpFile, "--start-date", "2024-08-02"}, headers...), args...)...)
+	}
 
-	t.Run("calculates hours and outputs text", func(t *testing.T) {
-		output := executeCommandText(t, "hours", "--file", tmpFile, "--start-date", "2024-08-01")
-		expected := "Total hours worked from 2024-08-01 to 2024-08-01: 7.00\n"
-		if output != expected {
+	t.Run("text", func(t *testing.T) {
+		output := run(t)
+		for _, want := range []string{"\nDone\n", "\nUp next\n", "\nHelp wanted & blockers\n"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected header %q, got:\n%s", want, output)
+			}
+		}
+		for _, old := range []string{report.TextHeaderCompleted, report.TextHeaderNextUp, report.TextHeaderBlocked} {
+			if strings.Contains(output, old) {
+				t.Errorf("Expected default header %q to be replaced, got:\n%s", old, output)
+			}
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		output := run(t, "--format", "markdown")
+		for _, want := range []string{"## Done\n", "## Up next\n", "## Help wanted & blockers\n"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected header %q, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		htmlPath := filepath.Join(t.TempDir(), "report.html")
+		run(t, "--html-file", htmlPath)
+		content, err := os.ReadFile(htmlPath)
+		if err != nil {
+			t.Fatalf("Failed to read HTML report: %v", err)
+		}
+		for _, want := range []string{"<h2>Done</h2>", "<h2>Up next</h2>", "<h2>Help wanted &amp; blockers</h2>"} {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("Expected header %q, got:\n%s", want, content)
+			}
+		}
+	})
+}
+
+func TestReportCommandSummaryOnly(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--summary-only")
+
+	for _, want := range []string{"Work Report (2024-08-01 to 2024-08-03)", "    • SCR-1\n", "    • PROJ-99\n", "    • SCR-3\n", "    • Non-feature work\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"Set up the Go module", "Continue working on YAML parsing logic", "Waiting on final YAML structure", "PR(s):", "https://github.com/example/repo/pull/123"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected summary to omit %q, got:\n%s", unwanted, output)
+		}
+	}
+}
+
+func TestReportCommandPrivateTasks(t *testing.T) {
+	content := []byte(`
+"2024-08-01":
+  work_log:
+    - start_time: "09:00"
+      end_time: "12:00"
+  tasks:
+    - jira_ticket: "PROJ-1"
+      description: "Shipped the fix"
+      status: "completed"
+    - jira_ticket: "HR-1"
+      description: "Benefits enrollment"
+      status: "in progress"
+      upnext_description: "Submit forms"
+      blocker: "Waiting on HR"
+      private: true
+`)
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("Failed to write worklog: %v", err)
+	}
+
+	t.Run("private tasks are hidden by default", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", path)
+		if !strings.Contains(output, "Shipped the fix") {
+			t.Errorf("Expected the public task, got:\n%s", output)
+		}
+		for _, hidden := range []string{"HR-1", "Benefits enrollment", "Submit forms", "Waiting on HR"} {
+			if strings.Contains(output, hidden) {
+				t.Errorf("Expected %q to be hidden, got:\n%s", hidden, output)
+			}
+		}
+	})
+
+	t.Run("--include-private shows them", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", path, "--include-private")
+		for _, want := range []string{"Benefits enrollment", "Submit forms", "Blocker: Waiting on HR"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q with --include-private, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("private tasks still count toward hours", func(t *testing.T) {
+		expected := "Total hours worked from 2024-08-01 to 2024-08-01: 3.00\n"
+		if output := executeCommandText(t, "hours", "--file", path); output != expected {
 			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
 		}
 	})
 }
 
-func TestReportCommand(t *testing.T) {
+func TestReportCommandEmptySections(t *testing.T) {
 	tmpFile, cleanup := setupTests(t)
 	defer cleanup()
 
-	t.Run("generates a human-readable report", func(t *testing.T) {
-		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03")
+	t.Run("omitted by default", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01")
+		if !strings.Contains(output, report.TextHeaderCompleted) {
+			t.Errorf("Report missing completed section:\n%s", output)
+		}
+		for _, header := range []string{report.TextHeaderNextUp, report.TextHeaderBlocked} {
+			if strings.Contains(output, header) {
+				t.Errorf("Report should not include empty section %q:\n%s", header, output)
+			}
+		}
+	})
 
-		// Check for key parts of the text report
-		if !strings.Contains(output, "Work Report (2024-08-01 to 2024-08-03)") {
-			t.Error("Report missing correct title")
+	t.Run("included on request", func(t *testing.T) {
+		for _, format := range []string{"text", "markdown"} {
+			output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", format, "--include-empty-sections")
+			for _, header := range []string{"been working on", "working on next", "blocking me"} {
+				if !strings.Contains(output, header) {
+					t.Errorf("%s report missing section %q:\n%s", format, header, output)
+				}
+			}
 		}
-		if !strings.Contains(output, "🦀 Thing I've been working on") {
-			t.Error("Report missing 'Thing I've been working on' section")
+	})
+
+	t.Run("no entries at all", func(t *testing.T) {
+		emptyFile := filepath.Join(t.TempDir(), "worklog.yml")
+		content := "\"2024-08-05\":\n  work_log:\n    - start_time: \"09:00\"\n      end_time: \"10:00\"\n"
+		if err := os.WriteFile(emptyFile, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write work log: %v", err)
 		}
-		if !strings.Contains(output, "SCR-1:") {
-			t.Error("Report missing completed Jira ticket SCR-1")
+
+		output := executeCommandText(t, "report", "--file", emptyFile)
+		if expected := "No report entries from 2024-08-05 to 2024-08-05.\n"; output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
 		}
-		if !strings.Contains(output, "◦ Set up the Go module and initial file structure.") {
-			t.Error("Report missing completed task description with bullet")
+
+		output = executeCommandText(t, "report", "--file", emptyFile, "--include-empty-sections")
+		if !strings.HasPrefix(output, "Work Report (2024-08-05 to 2024-08-05)") || !strings.Contains(output, report.TextHeaderBlocked) {
+			t.Errorf("Expected the full report with empty sections, got:\n%s", output)
 		}
-		if !strings.Contains(output, ":starfleet: Thing I plan on working on next") {
-			t.Error("Report missing 'Thing I plan on working on next' section")
+	})
+}
+
+func TestReportCommandMatchesReportPackage(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03")
+
+	workData, err := loadWorkData(tmpFile)
+	if err != nil {
+		t.Fatalf("loadWorkData failed: %v", err)
+	}
+	dates := []string{"2024-08-01", "2024-08-02", "2024-08-03"}
+	tasks := report.CategorizeTasks(workData, dates, report.Options{})
+
+	var want bytes.Buffer
+	report.PrintCompletedTasks(&want, tasks.Completed, nil, report.Options{})
+	report.PrintNextUpTasks(&want, tasks.NextUp, nil, report.Options{})
+	report.PrintBlockedTasks(&want, tasks.Blocked, report.Options{})
+	report.PrintQCGoals(&want, tasks.ByQCGoal, report.Options{})
+
+	if !strings.HasSuffix(output, want.String()) {
+		t.Errorf("report command output differs from internal/report rendering\nGot:\n%s\nWant suffix:\n%s", output, want.String())
+	}
+}
+
+func TestReportCommandAsciiDocFormat(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "adoc")
+
+	expected := []string{
+		"= Work Report (2024-08-01 to 2024-08-03)",
+		"== 🦀 Things I've been working on",
+		"* *link:https://issues.redhat.com/browse/SCR-1[SCR-1]*",
+		"** Set up the Go module and initial file structure.",
+		"** PR(s): link:https://github.com/example/repo/pull/123[https://github.com/example/repo/pull/123]",
+		"* *Non-feature work*",
+		"** Organized project documentation and created initial README.",
+		"== ⭐ Things I plan on working on next",
+		"* *link:https://issues.redhat.com/browse/SCR-2[SCR-2]*",
+		"** Continue working on YAML parsing logic",
+		"== 🚫 Things that are blocking me",
+		"** Blocker: Waiting on final YAML structure.",
+		"** Since 2024-08-02: Implement the structs and parsing logic for the worklog YAML.",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("AsciiDoc report missing %q\nGot:\n%s", want, output)
 		}
-		if !strings.Contains(output, "• SCR-2") {
-			t.Error("Report missing next up task with bullet")
+	}
+
+	if strings.Contains(output, "•") || strings.Contains(output, "## ") {
+		t.Errorf("AsciiDoc report should not contain text or Markdown markers\nGot:\n%s", output)
+	}
+}
+
+func TestReportCommandConfluenceFormat(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "confluence")
+
+	expected := []string{
+		"<h1>Work Report (2024-08-01 to 2024-08-03)</h1>",
+		`<ac:structured-macro ac:name="info">`,
+		"<h2>🦀 Things I've been working on</h2>",
+		`<li><strong><a href="https://issues.redhat.com/browse/SCR-1">SCR-1</a></strong><ul><li>Set up the Go module and initial file structure.</li>`,
+		`<li>PR(s): <a href="https://github.com/example/repo/pull/123">https://github.com/example/repo/pull/123</a></li>`,
+		"<li><strong>Non-feature work</strong><ul>",
+		"<h2>⭐ Things I plan on working on next</h2>",
+		"<li>Continue working on YAML parsing logic</li>",
+		"<h2>🚫 Things that are blocking me</h2>",
+		" Blocker: Waiting on final YAML structure.</li>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Confluence report missing %q\nGot:\n%s", want, output)
 		}
-		if !strings.Contains(output, ":facepalm: Thing that is blocking me or that I could use some help / discussion about") {
-			t.Error("Report missing 'Blockers' section")
+	}
+
+	if strings.Contains(output, "•") || strings.Contains(output, "&nbsp;") || strings.Contains(output, "<br/>") {
+		t.Errorf("Confluence report should not contain text or Slack HTML markers\nGot:\n%s", output)
+	}
+}
+
+func TestReportCommandShowHoursDetail(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	args := []string{"report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03"}
+	if output := executeCommandText(t, args...); strings.Contains(output, "Hours worked") {
+		t.Errorf("Expected no hours detail without --show-hours-detail, got:\n%s", output)
+	}
+
+	output := executeCommandText(t, append(args, "--show-hours-detail")...)
+	expected := []string{
+		"⏱️ Hours worked",
+		"    • 2024-08-01: 09:00-12:30, 13:30-17:00 (total 7.00)",
+		"    • 2024-08-02: 10:00-16:00 (total 6.00)",
+		"    • 2024-08-03: 11:00-13:00 (total 2.00)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Report missing %q\nGot:\n%s", want, output)
 		}
-		if !strings.Contains(output, "SCR-2") {
-			t.Error("Report missing blocked task ticket")
+	}
+
+	output = executeCommandText(t, append(args, "--show-hours-detail", "--format", "markdown", "--duration-format", "hm")...)
+	if !strings.Contains(output, "- **2024-08-02**: 10:00-16:00 (total 6h 00m)") {
+		t.Errorf("Expected the hours detail in Markdown, got:\n%s", output)
+	}
+}
+
+func TestReportCommandSlackFormat(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "slack")
+
+	var payload struct {
+		Text   string `json:"text"`
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("Slack output is not valid JSON: %v\n%s", err, output)
+	}
+	if payload.Text != "Work Report (2024-08-01 to 2024-08-03)" {
+		t.Errorf("Unexpected fallback text %q", payload.Text)
+	}
+	if len(payload.Blocks) == 0 || payload.Blocks[0].Type != "header" {
+		t.Fatalf("Expected a header block first, got %+v", payload.Blocks)
+	}
+	if !strings.Contains(output, "<https://issues.redhat.com/browse/SCR-1|SCR-1>") {
+		t.Errorf("Slack output missing JIRA mrkdwn link\nGot:\n%s", output)
+	}
+}
+
+func TestReportCommandSlackWebhook(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+	t.Setenv("SLACK_WEBHOOK_URL", "")
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, string(body))
+	}))
+	defer server.Close()
+
+	t.Run("posts Block Kit payload", func(t *testing.T) {
+		requests = nil
+		executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "slack", "--slack-webhook", server.URL)
+		if len(requests) != 1 || !strings.Contains(requests[0], `"blocks"`) {
+			t.Errorf("Expected one Block Kit request, got %q", requests)
 		}
-		if !strings.Contains(output, "◦ Blocker: Waiting on final YAML structure.") {
-			t.Error("Report missing blocker description with bullet")
+	})
+
+	t.Run("posts text payload from the environment", func(t *testing.T) {
+		requests = nil
+		t.Setenv("SLACK_WEBHOOK_URL", server.URL)
+		executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01")
+		var payload map[string]string
+		if len(requests) != 1 || json.Unmarshal([]byte(requests[0]), &payload) != nil || !strings.Contains(payload["text"], "Work Report") {
+			t.Errorf("Expected one text request, got %q", requests)
 		}
-		// Check for GitHub PR integration
-		if !strings.Contains(output, "PR(s): https://github.com/example/repo/pull/123") {
-			t.Error("Report missing GitHub PR link")
+	})
+
+	t.Run("dry run does not send", func(t *testing.T) {
+		requests = nil
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--slack-webhook", server.URL, "--dry-run")
+		if len(requests) != 0 {
+			t.Errorf("Expected no requests during a dry run, got %q", requests)
 		}
-		// Check for non-feature work grouping (tasks without Jira tickets)
-		if !strings.Contains(output, "• Non-feature work") {
-			t.Error("Report should include 'Non-feature work' header for tasks without Jira tickets")
+		if !strings.Contains(output, "Dry run: would send this payload to the Slack webhook:") || !strings.Contains(output, `{"text":"Work Report`) {
+			t.Errorf("Expected the payload in dry run output\nGot:\n%s", output)
 		}
-		// Non-feature work items are now shown as individual sub-entries with first description as header
-		if !strings.Contains(output, "◦ Organized project documentation and created initial README.") {
-			t.Error("Report should include completed task with PR as its own sub-entry under Non-feature work")
+	})
+}
+
+func TestReportCommandJiraBaseURL(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("flag overrides the default instance", func(t *testing.T) {
+		t.Setenv("JIRA_BASE_URL", "")
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "markdown", "--jira-base-url", "https://jira.example.com/")
+		if !strings.Contains(output, "[SCR-1](https://jira.example.com/browse/SCR-1)") {
+			t.Errorf("Expected link on configured JIRA host, got:\n%s", output)
 		}
-		if !strings.Contains(output, "◦ Updated team wiki with new development processes.") {
-			t.Error("Report should include completed task without PR as its own sub-entry under Non-feature work")
+	})
+
+	t.Run("environment variable is used when flag is absent", func(t *testing.T) {
+		t.Setenv("JIRA_BASE_URL", "https://jira.env.example.com")
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "markdown")
+		if !strings.Contains(output, "[SCR-1](https://jira.env.example.com/browse/SCR-1)") {
+			t.Errorf("Expected link on JIRA_BASE_URL host, got:\n%s", output)
 		}
-		if !strings.Contains(output, "◦ Run linter and fix all warnings") {
-			t.Error("Report should include next up task that has no Jira ticket under Non-feature work")
+	})
+
+	t.Run("defaults to Red Hat JIRA", func(t *testing.T) {
+		t.Setenv("JIRA_BASE_URL", "")
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "markdown")
+		if !strings.Contains(output, "[SCR-1](https://issues.redhat.com/browse/SCR-1)") {
+			t.Errorf("Expected link on default JIRA host, got:\n%s", output)
 		}
-		// Check for PR links for tasks without Jira tickets
-		if !strings.Contains(output, "PR(s): https://github.com/example/repo/pull/456") {
-			t.Error("Report should include PR link for task that has no Jira ticket")
+	})
+}
+
+func TestNewJiraClient(t *testing.T) {
+	t.Setenv("JIRA_PAT", "test-token")
+	jiraWorkers = 3
+	jiraFields = []string{"customfield_10002"}
+	jiraTimeout = 2 * time.Second
+	t.Cleanup(func() { resetFlags(rootCmd) })
+
+	client := newJiraClient()
+	if client.Token != "test-token" || client.Concurrency != 3 || !slices.Equal(client.Fields, jiraFields) {
+		t.Errorf("Expected the client to use the token and --jira-* flags, got %+v", client)
+	}
+	if client.HTTPClient.Timeout != 2*time.Second || client.HTTPClient == jira.HTTPClient {
+		t.Errorf("Expected a copy of the shared HTTP client with the --jira-timeout, got %+v", client.HTTPClient)
+	}
+}
+
+func TestReportCommandJSONFormat(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	type entry struct {
+		Ticket       string   `json:"ticket"`
+		Key          string   `json:"key"`
+		URL          string   `json:"url"`
+		Descriptions []string `json:"descriptions"`
+		PRs          []string `json:"prs"`
+		Dates        []string `json:"dates"`
+		Blocker      string   `json:"blocker"`
+	}
+	type jsonReport struct {
+		Completed []entry `json:"completed"`
+		NextUp    []entry `json:"next_up"`
+		Blocked   []entry `json:"blocked"`
+	}
+
+	t.Run("serializes all sections", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--end-date", "2024-08-03", "--format", "json")
+
+		var got jsonReport
+		if err := json.Unmarshal([]byte(output), &got); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+
+		var scr1 *entry
+		for i := range got.Completed {
+			if got.Completed[i].Key == "SCR-1" {
+				scr1 = &got.Completed[i]
+			}
+		}
+		if scr1 == nil {
+			t.Fatalf("Completed section missing SCR-1: %+v", got.Completed)
+		}
+		if scr1.URL != "https://issues.redhat.com/browse/SCR-1" {
+			t.Errorf("Unexpected URL for SCR-1: %s", scr1.URL)
+		}
+		if len(scr1.Dates) != 1 || scr1.Dates[0] != "2024-08-01" {
+			t.Errorf("Expected SCR-1 dates [2024-08-01], got %v", scr1.Dates)
+		}
+		if len(scr1.Descriptions) != 1 || scr1.Descriptions[0] != "Set up the Go module and initial file structure." {
+			t.Errorf("Unexpected SCR-1 descriptions: %v", scr1.Descriptions)
+		}
+
+		if len(got.NextUp) == 0 {
+			t.Error("Expected next up entries")
+		}
+		if len(got.Blocked) != 1 || got.Blocked[0].Key != "SCR-2" || got.Blocked[0].Blocker != "Waiting on final YAML structure." ||
+			len(got.Blocked[0].Dates) != 1 || got.Blocked[0].Dates[0] != "2024-08-02" {
+			t.Errorf("Unexpected blocked section: %+v", got.Blocked)
+		}
+		for _, e := range got.Completed {
+			if strings.HasPrefix(e.Ticket, "__noticket_") || strings.HasPrefix(e.Ticket, "https://") {
+				t.Errorf("Synthetic grouping key leaked into JSON ticket field: %q", e.Ticket)
+			}
+		}
+	})
+
+	t.Run("empty sections serialize as empty arrays", func(t *testing.T) {
+		output := executeCommandText(t, "report", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "json")
+
+		if !strings.Contains(output, `"next_up": []`) {
+			t.Errorf("Expected empty next_up array, got:\n%s", output)
+		}
+		if !strings.Contains(output, `"blocked": []`) {
+			t.Errorf("Expected empty blocked array, got:\n%s", output)
+		}
+		if strings.Contains(output, "null") {
+			t.Errorf("JSON output should not contain null values:\n%s", output)
 		}
 	})
 }
@@ -574,6 +3062,66 @@ func TestGenerateInitialWorklogYAML(t *testing.T) {
 	})
 }
 
+func TestInitCommandTemplate(t *testing.T) {
+	t.Run("documents status values inline", func(t *testing.T) {
+		yamlData, err := generateInitialWorklogYAML(time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("generateInitialWorklogYAML failed: %v", err)
+		}
+		yamlString := string(yamlData)
+
+		if !strings.Contains(yamlString, "# completed | in progress | not started") {
+			t.Errorf("Generated YAML should document valid status values, got:\n%s", yamlString)
+		}
+		if !strings.HasPrefix(yamlString, "# TaskLedger work log.") {
+			t.Errorf("Generated YAML should start with a documentation comment, got:\n%s", yamlString)
+		}
+	})
+
+	t.Run("--force overwrites an existing file without prompting", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "worklog.yml")
+		if err := os.WriteFile(path, []byte("old: content\n"), 0644); err != nil {
+			t.Fatalf("Failed to write existing file: %v", err)
+		}
+
+		output := executeCommandText(t, "init", "--file", path, "--force")
+		if strings.Contains(output, "Overwrite?") {
+			t.Errorf("--force should not prompt, got:\n%s", output)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read worklog: %v", err)
+		}
+		if strings.Contains(string(data), "old: content") {
+			t.Error("Existing file was not overwritten")
+		}
+		var workData model.WorkData
+		if err := yaml.Unmarshal(data, &workData); err != nil {
+			t.Fatalf("Overwritten file is not valid YAML: %v", err)
+		}
+		if len(workData) != 2 {
+			t.Errorf("Expected 2 dates in generated worklog, got %d", len(workData))
+		}
+	})
+
+	t.Run("writes JSON for a .json file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "worklog.json")
+		executeCommandText(t, "init", "--file", path, "--force")
+
+		workData, err := loadWorkData(path)
+		if err != nil {
+			t.Fatalf("Generated JSON worklog could not be loaded: %v", err)
+		}
+		if len(workData) != 2 {
+			t.Errorf("Expected 2 dates in generated worklog, got %d", len(workData))
+		}
+		if issues := workData.Validate(); len(issues) > 0 {
+			t.Errorf("Generated JSON worklog is invalid: %v", issues)
+		}
+	})
+}
+
 func TestInitCommandDataValidation(t *testing.T) {
 	fixedDate := time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC)
 
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+var searchRegex bool
+
+var searchCmd = &cobra.Command{
+	Use:   "search QUERY",
+	Short: "Find tasks by keyword.",
+	Long:  `Searches task descriptions, upnext descriptions, blockers, and JIRA tickets for a case-insensitive substring (or a regular expression with --regex) and prints each matching task with its date and ticket.`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runSearchCommand,
+}
+
+func init() {
+	searchCmd.Flags().StringVar(&startDate, "start-date", "", "Start date (YYYY-MM-DD, or today, yesterday, this-week, last-week, this-month, last-month).")
+	searchCmd.Flags().StringVar(&endDate, "end-date", "", "End date (YYYY-MM-DD, or a relative keyword like --start-date).")
+	searchCmd.Flags().BoolVar(&searchRegex, "regex", false, "Treat the query as a case-insensitive regular expression.")
+	searchCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for search results (text, json).")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+// searchMatch is a task that matched a search query.
+type searchMatch struct {
+	Date    string   `json:"date"`
+	Ticket  string   `json:"ticket"`
+	Status  string   `json:"status"`
+	Matches []string `json:"matches"`
+}
+
+func runSearchCommand(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("unsupported search format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+
+	matcher, err := newSearchMatcher(args[0], searchRegex)
+	if err != nil {
+		slog.Error("invalid search query", "error", err, "query", args[0])
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		os.Exit(1)
+	}
+
+	dates, err := getDatesInRange(workData, startDate, endDate)
+	if err != nil {
+		slog.Error("failed to process date range", "error", err, "start_date", startDate, "end_date", endDate)
+		os.Exit(dateRangeExitCode(err))
+	}
+
+	matches := searchTasks(workData, dates, matcher)
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal search results as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+	printSearchMatches(out, args[0], matches)
+}
+
+// newSearchMatcher returns a function reporting whether text matches the query,
+// either as a case-insensitive substring or as a case-insensitive regular expression.
+func newSearchMatcher(query string, useRegex bool) (func(string) bool, error) {
+	if useRegex {
+		re, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return re.MatchString, nil
+	}
+
+	needle := strings.ToLower(query)
+	return func(text string) bool {
+		return strings.Contains(strings.ToLower(text), needle)
+	}, nil
+}
+
+// searchTasks returns the tasks on the given dates with a description, upnext
+// description, blocker, or JIRA ticket matching the query, in date order. Each
+// match lists the fields that matched, labeled as in the text output.
+func searchTasks(workData model.WorkData, dates []string, matches func(string) bool) []searchMatch {
+	sortedDates := append([]string(nil), dates...)
+	sort.Strings(sortedDates)
+
+	results := []searchMatch{}
+	for _, date := range sortedDates {
+		for _, task := range workData[date].Tasks {
+			var fields []string
+			for _, desc := range task.GetDescriptions() {
+				if matches(desc) {
+					fields = append(fields, desc)
+				}
+			}
+			if task.UpnextDescription != "" && matches(task.UpnextDescription) {
+				fields = append(fields, "Next: "+task.UpnextDescription)
+			}
+			if task.Blocker != "" && matches(task.Blocker) {
+				fields = append(fields, "Blocker: "+task.Blocker)
+			}
+			if len(fields) == 0 && !(task.JiraTicket != "" && matches(task.JiraTicket)) {
+				continue
+			}
+
+			// A ticket-only match still shows what the task was about
+			if len(fields) == 0 {
+				fields = task.GetDescriptions()
+			}
+			results = append(results, searchMatch{
+				Date:    date,
+				Ticket:  task.JiraTicket,
+				Status:  task.Status,
+				Matches: append([]string{}, fields...),
+			})
+		}
+	}
+	return results
+}
+
+// printSearchMatches prints search results as compact text bullets.
+func printSearchMatches(out io.Writer, query string, matches []searchMatch) {
+	if len(matches) == 0 {
+		fmt.Fprintf(out, "No tasks match %q.\n", query)
+		return
+	}
+
+	for _, match := range matches {
+		header := match.Date
+		if match.Ticket != "" {
+			header += " " + match.Ticket
+		}
+		if match.Status != "" {
+			header += " (" + match.Status + ")"
+		}
+		fmt.Fprintf(out, "• %s\n", header)
+		for _, field := range match.Matches {
+			fmt.Fprintf(out, "    ◦ %s\n", field)
+		}
+	}
+}
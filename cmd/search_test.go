@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSearchCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("substring across fields", func(t *testing.T) {
+		output := executeCommandText(t, "search", "--file", tmpFile, "yaml")
+		expected := "• 2024-08-02 SCR-2 (in progress)\n" +
+			"    ◦ Implement the structs and parsing logic for the worklog YAML.\n" +
+			"    ◦ Next: Continue working on YAML parsing logic\n" +
+			"    ◦ Blocker: Waiting on final YAML structure.\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("ticket match shows descriptions", func(t *testing.T) {
+		output := executeCommandText(t, "search", "--file", tmpFile, "proj-99")
+		expected := "• 2024-08-02 PROJ-99 (completed)\n" +
+			"    ◦ Provided feedback on the new database schema.\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("regex scoped to a date range", func(t *testing.T) {
+		output := executeCommandText(t, "search", "--file", tmpFile, "--regex", "^(set up|building)", "--start-date", "2024-08-03", "--format", "json")
+
+		var matches []searchMatch
+		if err := json.Unmarshal([]byte(output), &matches); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		if len(matches) != 1 || matches[0].Ticket != "SCR-3" || matches[0].Date != "2024-08-03" {
+			t.Errorf("Unexpected matches: %+v", matches)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		output := executeCommandText(t, "search", "--file", tmpFile, "kubernetes")
+		if output != "No tasks match \"kubernetes\".\n" {
+			t.Errorf("Unexpected output %q", output)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+	"github.com/bryan-cox/taskledger/internal/report"
+)
+
+var serveAddr string
+
+// serveShutdownTimeout bounds how long in-flight requests may take to finish
+// once the server is asked to stop.
+const serveShutdownTimeout = 5 * time.Second
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the report over HTTP.",
+	Long: `Starts a local HTTP server with the HTML report at / and the JSON report at /report.json.
+The worklog is read on every request, so edits show up on the next reload. Pass ?start=&end= (YYYY-MM-DD or a relative keyword) to choose the date range; all dates are shown by default.`,
+	Run: runServeCommand,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on.")
+	serveCmd.Flags().StringVar(&htmlTheme, "theme", report.ThemePlain, "HTML theme (plain, light, dark). plain keeps Slack-friendly unstyled markup.")
+	serveCmd.Flags().StringVar(&headerDone, "header-completed", "", "Title of the completed section in every format (defaults to the built-in title).")
+	serveCmd.Flags().StringVar(&headerNext, "header-nextup", "", "Title of the next up section in every format (defaults to the built-in title).")
+	serveCmd.Flags().StringVar(&headerBlocked, "header-blocked", "", "Title of the blocked section in every format (defaults to the built-in title).")
+	serveCmd.Flags().BoolVar(&showPrivate, "include-private", false, "Include tasks marked private: true, which are otherwise left out of the report.")
+	serveCmd.Flags().StringVar(&jiraSummaries, "jira-summaries", "", "Path to JSON file with pre-fetched JIRA ticket summaries.")
+	serveCmd.Flags().StringSliceVar(&jiraFields, "jira-fields", nil, "Extra JIRA field IDs to fetch, e.g. customfield_10002,customfield_12310 (comma-separated). Shown in HTML output.")
+	serveCmd.Flags().IntVar(&jiraWorkers, "jira-concurrency", jira.DefaultConcurrency, "Maximum number of JIRA tickets to fetch in parallel.")
+	serveCmd.Flags().DurationVar(&jiraCacheTTL, "jira-cache-ttl", jira.DefaultCacheTTL, "How long cached JIRA ticket summaries stay fresh.")
+	serveCmd.Flags().DurationVar(&jiraTimeout, "jira-timeout", jira.DefaultTimeout, "Timeout for each JIRA API request.")
+	serveCmd.Flags().BoolVar(&noJiraCache, "no-jira-cache", false, "Always fetch JIRA ticket summaries from the API, bypassing the on-disk cache.")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServeCommand(cmd *cobra.Command, args []string) {
+	if !report.IsValidTheme(htmlTheme) {
+		slog.Error("unsupported HTML theme, use plain, light, or dark", "theme", htmlTheme)
+		os.Exit(exitBadInput)
+	}
+	if jiraWorkers < 1 {
+		slog.Error("--jira-concurrency must be at least 1", "jira_concurrency", jiraWorkers)
+		os.Exit(exitBadInput)
+	}
+	if jiraTimeout <= 0 {
+		slog.Error("--jira-timeout must be positive", "jira_timeout", jiraTimeout)
+		os.Exit(exitBadInput)
+	}
+	// The token is resolved and the cache opened once, rather than on every request
+	jiraClient := newCachedJiraClient()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	server := &http.Server{
+		Addr:              serveAddr,
+		Handler:           newServeHandler(jiraClient),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving the report at http://%s/ (press Ctrl+C to stop)\n", serveAddr)
+
+	select {
+	case err := <-serveErr:
+		slog.Error("server failed", "error", err, "addr", serveAddr)
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("server did not shut down cleanly", "error", err)
+		os.Exit(1)
+	}
+	saveJiraCache(jiraClient)
+	fmt.Fprintln(cmd.OutOrStdout(), "Server stopped")
+}
+
+// newServeHandler returns the handler for the serve command: the HTML report at
+// / and the JSON report at /report.json. Every request shares jiraClient.
+func newServeHandler(jiraClient *jira.Client) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		serveHTMLReport(w, r, jiraClient)
+	})
+	mux.HandleFunc("GET /report.json", func(w http.ResponseWriter, r *http.Request) {
+		serveJSONReport(w, r, jiraClient)
+	})
+	return mux
+}
+
+func serveHTMLReport(w http.ResponseWriter, r *http.Request, jiraClient *jira.Client) {
+	dates, tasks, ok := loadServedReport(w, r)
+	if !ok {
+		return
+	}
+	jiraInfo := serveJiraInfo(jiraClient, tasks)
+	prInfo := github.ProcessPRs(report.CollectPRLinks(tasks))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, report.GenerateHTML(dates, tasks, jiraInfo, prInfo, htmlTheme, serveOptions()))
+}
+
+func serveJSONReport(w http.ResponseWriter, r *http.Request, jiraClient *jira.Client) {
+	_, tasks, ok := loadServedReport(w, r)
+	if !ok {
+		return
+	}
+	data, err := report.MarshalJSON(tasks, serveJiraInfo(jiraClient, tasks), serveOptions())
+	if err != nil {
+		slog.Error("failed to marshal report as JSON", "error", err)
+		http.Error(w, "failed to marshal report", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, string(data))
+}
+
+// serveJiraInfo resolves JIRA ticket info for the served tasks like loadJiraInfo,
+// but through the server's shared client.
+func serveJiraInfo(jiraClient *jira.Client, tasks model.CategorizedTasks) map[string]jira.TicketInfo {
+	if jiraInfo, ok := loadJiraSummaries(); ok {
+		return jiraInfo
+	}
+	return jiraClient.ProcessTickets(report.CollectTickets(tasks))
+}
+
+// serveOptions returns the report options selected by the serve command's flags.
+func serveOptions() report.Options {
+	return withSectionHeaders(report.Options{IncludePrivate: showPrivate})
+}
+
+// loadServedReport reads the work log and categorizes the tasks in the range
+// given by the start and end query parameters. On failure it writes an error
+// response and returns false: 404 for a range with no entries, 400 for an
+// invalid range, and 500 when the work log cannot be read.
+func loadServedReport(w http.ResponseWriter, r *http.Request) ([]string, model.CategorizedTasks, bool) {
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		http.Error(w, "failed to load work log", http.StatusInternalServerError)
+		return nil, model.CategorizedTasks{}, false
+	}
+
+	query := r.URL.Query()
+	dates, err := getDatesInRange(workData, query.Get("start"), query.Get("end"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrNoData):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrBadDateRange):
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return nil, model.CategorizedTasks{}, false
+	}
+	return dates, report.CategorizeTasks(workData, dates, serveOptions()), true
+}
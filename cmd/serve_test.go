@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+)
+
+func TestServeHandler(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	filePaths = []string{tmpFile}
+	jiraWorkers = 1
+	t.Cleanup(func() { resetFlags(rootCmd) })
+	handler := newServeHandler(newJiraClient())
+
+	get := func(t *testing.T, target string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("serves the HTML report for every date by default", func(t *testing.T) {
+		rec := get(t, "/")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("Expected an HTML content type, got %q", ct)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "Work Report (2024-08-01 to 2024-08-03)") {
+			t.Errorf("Expected the full date range in the title, got:\n%s", body)
+		}
+	})
+
+	t.Run("honors the start and end query parameters", func(t *testing.T) {
+		rec := get(t, "/?start=2024-08-02&end=2024-08-02")
+		body := rec.Body.String()
+		if !strings.Contains(body, "Work Report (2024-08-02 to 2024-08-02)") {
+			t.Errorf("Expected the requested range in the title, got:\n%s", body)
+		}
+		if strings.Contains(body, "SCR-1") {
+			t.Errorf("Expected SCR-1 from 2024-08-01 to be left out, got:\n%s", body)
+		}
+	})
+
+	t.Run("serves the JSON report", func(t *testing.T) {
+		rec := get(t, "/report.json?start=2024-08-01")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body)
+		}
+		var doc struct {
+			Completed []struct {
+				Key string `json:"key"`
+			} `json:"completed"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+			t.Fatalf("Failed to parse JSON report: %v\n%s", err, rec.Body)
+		}
+		if len(doc.Completed) == 0 || doc.Completed[0].Key != "SCR-1" {
+			t.Errorf("Expected SCR-1 to be completed on 2024-08-01, got %+v", doc.Completed)
+		}
+	})
+
+	t.Run("maps date range errors to status codes", func(t *testing.T) {
+		tests := map[string]int{
+			"/?start=2025-01-01":                      http.StatusNotFound,
+			"/report.json?start=not-a-date":           http.StatusBadRequest,
+			"/?start=2024-08-03&end=2024-08-01":       http.StatusBadRequest,
+			"/report.json?start=2024-08-01&end=later": http.StatusBadRequest,
+		}
+		for target, want := range tests {
+			if rec := get(t, target); rec.Code != want {
+				t.Errorf("GET %s: expected status %d, got %d", target, want, rec.Code)
+			}
+		}
+	})
+
+	t.Run("handles concurrent requests", func(t *testing.T) {
+		var wg sync.WaitGroup
+		codes := make([]int, 8)
+		for i := range codes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				target := "/"
+				if i%2 == 1 {
+					target = "/report.json"
+				}
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+				codes[i] = rec.Code
+			}()
+		}
+		wg.Wait()
+		for i, code := range codes {
+			if code != http.StatusOK {
+				t.Errorf("Request %d: expected status 200, got %d", i, code)
+			}
+		}
+	})
+
+	t.Run("unknown paths are not found", func(t *testing.T) {
+		if rec := get(t, "/missing"); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestServeHandlerSharesJiraClient(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	var requests atomic.Int32
+	jiraServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.Header.Get("Authorization"); got != "Bearer helper-token" {
+			t.Errorf("Unexpected Authorization header %q", got)
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		fmt.Fprintf(w, `{"key": %q, "fields": {"summary": "Summary of %s"}}`, key, key)
+	}))
+	defer jiraServer.Close()
+
+	// The token command records each run, so resolving it per request shows up
+	runs := filepath.Join(t.TempDir(), "runs")
+	filePaths = []string{tmpFile}
+	noJiraCache = true
+	jira.TokenCommand = "echo run >> " + runs + " && echo helper-token"
+	if err := jira.SetBaseURL(jiraServer.URL); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+	t.Cleanup(func() {
+		resetFlags(rootCmd)
+		jira.TokenCommand = ""
+		jira.SetBaseURL(jira.DefaultBaseURL)
+	})
+
+	handler := newServeHandler(newCachedJiraClient())
+	for _, target := range []string{"/", "/report.json", "/"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d", target, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "Summary of SCR-1") {
+			t.Errorf("GET %s: expected the fetched JIRA summary, got:\n%s", target, rec.Body)
+		}
+	}
+
+	data, err := os.ReadFile(runs)
+	if err != nil {
+		t.Fatalf("Expected the token command to run: %v", err)
+	}
+	if got := strings.Count(string(data), "run"); got != 1 {
+		t.Errorf("Expected the token command to run once, ran %d times", got)
+	}
+	if requests.Load() == 0 {
+		t.Error("Expected the shared client to fetch from JIRA")
+	}
+}
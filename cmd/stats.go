@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/hours"
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+	"github.com/bryan-cox/taskledger/internal/report"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize activity over a date range.",
+	Long:  `Prints the number of distinct JIRA tickets touched, task counts by status, PRs referenced per active day and the GitHub repositories they belong to, blocked tickets, and total hours worked over a date range.`,
+	Run:   runStatsCommand,
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&startDate, "start-date", "", "Start date (YYYY-MM-DD, or today, yesterday, this-week, last-week, this-month, last-month).")
+	statsCmd.Flags().StringVar(&endDate, "end-date", "", "End date (YYYY-MM-DD, or a relative keyword like --start-date).")
+	statsCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for stats (text, json).")
+	statsCmd.Flags().StringVar(&staleAfter, "stale-after", "", "Also list tickets whose latest task is still in progress more than this long before the end of the range (e.g. 7d, 2w, 1m).")
+
+	rootCmd.AddCommand(statsCmd)
+}
+
+// activityStats summarizes the work log over a date range.
+type activityStats struct {
+	StartDate      string         `json:"start_date"`
+	EndDate        string         `json:"end_date"`
+	JiraTickets    int            `json:"jira_tickets"`
+	Tasks          int            `json:"tasks"`
+	TasksByStatus  map[string]int `json:"tasks_by_status"`
+	PRs            int            `json:"prs"`
+	ActiveDays     int            `json:"active_days"`
+	PRsPerDay      float64        `json:"prs_per_active_day"`
+	Repos          int            `json:"repos"`
+	BlockedTickets int            `json:"blocked_tickets"`
+	Hours          float64        `json:"hours"`
+	StaleTickets   []staleTicket  `json:"stale_tickets,omitempty"`
+}
+
+// staleTicket is a ticket listed by stats --stale-after.
+type staleTicket struct {
+	Ticket    string `json:"ticket"`
+	Date      string `json:"date"`
+	DaysSince int    `json:"days_since"`
+}
+
+func runStatsCommand(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("unsupported stats format", "format", outputFormat)
+		os.Exit(1)
+	}
+	staleDays, err := parseStaleAfter(staleAfter)
+	if err != nil {
+		slog.Error("invalid --stale-after", "error", err)
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		os.Exit(1)
+	}
+
+	dates, err := getDatesInRange(workData, startDate, endDate)
+	if err != nil {
+		slog.Error("failed to process date range", "error", err, "start_date", startDate, "end_date", endDate)
+		os.Exit(1)
+	}
+
+	stats := collectStats(workData, dates, staleDays)
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal stats as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	fmt.Fprintf(out, "Activity from %s to %s\n", stats.StartDate, stats.EndDate)
+	fmt.Fprintf(out, "  JIRA tickets touched: %d\n", stats.JiraTickets)
+	fmt.Fprintf(out, "  Tasks: %d (%s: %d, %s: %d, %s: %d)\n", stats.Tasks,
+		model.StatusCompleted, stats.TasksByStatus[model.StatusCompleted],
+		model.StatusInProgress, stats.TasksByStatus[model.StatusInProgress],
+		model.StatusNotStarted, stats.TasksByStatus[model.StatusNotStarted])
+	fmt.Fprintf(out, "  PRs referenced: %d (%.2f per active day over %d days)\n", stats.PRs, stats.PRsPerDay, stats.ActiveDays)
+	fmt.Fprintf(out, "  Repositories touched: %d\n", stats.Repos)
+	fmt.Fprintf(out, "  Blocked tickets: %d\n", stats.BlockedTickets)
+	fmt.Fprintf(out, "  Hours worked: %.2f\n", stats.Hours)
+	if staleDays > 0 {
+		fmt.Fprintf(out, "  Possibly stale tickets: %d\n", len(stats.StaleTickets))
+		for _, ticket := range stats.StaleTickets {
+			fmt.Fprintf(out, "    %s: %d days since %s\n", ticket.Ticket, ticket.DaysSince, ticket.Date)
+		}
+	}
+}
+
+// collectStats computes activity statistics for the given dates. Tickets and PRs
+// are counted once no matter how many tasks reference them, and statuses are
+// counted case-insensitively. Active days are dates with a task or work_log
+// entry; repositories are parsed from GitHub PR URLs, skipping any other link.
+// Stale tickets are those report.CategorizeTasks lists as stale after staleDays.
+func collectStats(workData model.WorkData, dates []string, staleDays int) activityStats {
+	stats := activityStats{
+		StartDate: dates[0],
+		EndDate:   dates[len(dates)-1],
+		TasksByStatus: map[string]int{
+			model.StatusCompleted:  0,
+			model.StatusInProgress: 0,
+			model.StatusNotStarted: 0,
+		},
+	}
+
+	tickets := make(map[string]bool)
+	prs := make(map[string]bool)
+	repos := make(map[string]bool)
+	for _, date := range dates {
+		if len(workData[date].Tasks) > 0 || len(workData[date].WorkLogEntries) > 0 {
+			stats.ActiveDays++
+		}
+		for _, task := range workData[date].Tasks {
+			stats.Tasks++
+			stats.TasksByStatus[strings.ToLower(task.Status)]++
+			if ticketID := jira.ExtractTicketID(task.JiraTicket); ticketID != "" {
+				tickets[ticketID] = true
+			}
+			if task.GithubPR != "" {
+				prs[task.GithubPR] = true
+				if repo, ok := github.RepoFromPRURL(task.GithubPR); ok {
+					repos[strings.ToLower(repo)] = true
+				}
+			}
+		}
+	}
+	stats.JiraTickets = len(tickets)
+	stats.PRs = len(prs)
+	stats.Repos = len(repos)
+	if stats.ActiveDays > 0 {
+		stats.PRsPerDay = math.Round(float64(stats.PRs)/float64(stats.ActiveDays)*100) / 100
+	}
+	tasks := report.CategorizeTasks(workData, dates, report.Options{StaleAfter: staleDays})
+	stats.BlockedTickets = len(tasks.Blocked)
+	for _, task := range tasks.Stale {
+		ticket := task.JiraTicket
+		if ticket == "" {
+			ticket = "Misc"
+		}
+		stats.StaleTickets = append(stats.StaleTickets, staleTicket{Ticket: ticket, Date: task.Date, DaysSince: task.DaysSince})
+	}
+
+	totals := hours.DailyTotals(workData, dates, hours.Options{})
+	stats.Hours = hours.Total(totals, dates).Hours()
+	return stats
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatsCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+
+	t.Run("text output", func(t *testing.T) {
+		output := executeCommandText(t, "stats", "--file", tmpFile)
+		expected := "Activity from 2024-08-01 to 2024-08-03\n" +
+			"  JIRA tickets touched: 4\n" +
+			"  Tasks: 7 (completed: 4, in progress: 2, not started: 1)\n" +
+			"  PRs referenced: 2 (0.67 per active day over 3 days)\n" +
+			"  Repositories touched: 1\n" +
+			"  Blocked tickets: 1\n" +
+			"  Hours worked: 15.00\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("json output for a single day", func(t *testing.T) {
+		output := executeCommandText(t, "stats", "--file", tmpFile, "--start-date", "2024-08-01", "--format", "json")
+
+		var stats activityStats
+		if err := json.Unmarshal([]byte(output), &stats); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		if stats.StartDate != "2024-08-01" || stats.EndDate != "2024-08-01" {
+			t.Errorf("Unexpected date range: %s to %s", stats.StartDate, stats.EndDate)
+		}
+		if stats.JiraTickets != 1 || stats.Tasks != 2 || stats.PRs != 1 || stats.BlockedTickets != 0 || stats.Hours != 7 {
+			t.Errorf("Unexpected stats: %+v", stats)
+		}
+		if stats.ActiveDays != 1 || stats.PRsPerDay != 1 || stats.Repos != 1 {
+			t.Errorf("Unexpected PR metrics: %+v", stats)
+		}
+		if stats.TasksByStatus["completed"] != 2 {
+			t.Errorf("Expected 2 completed tasks, got %+v", stats.TasksByStatus)
+		}
+	})
+}
+
+func TestStatsCommandPRMetrics(t *testing.T) {
+	content := `
+2024-08-01:
+  tasks:
+    - jira_ticket: "PROJ-1"
+      status: "completed"
+      github_pr: "https://github.com/example/api/pull/1"
+    - jira_ticket: "PROJ-2"
+      status: "completed"
+      github_pr: "https://github.com/Example/API/pull/2"
+2024-08-02:
+  tasks:
+    - jira_ticket: "PROJ-3"
+      status: "in progress"
+      github_pr: "https://github.com/example/web/pull/3"
+    - jira_ticket: "PROJ-4"
+      status: "in progress"
+      github_pr: "https://gitlab.com/example/web/-/merge_requests/4"
+2024-08-03:
+  tasks: []
+2024-08-05:
+  work_log:
+    - start_time: "09:00"
+      end_time: "10:00"
+`
+	path := filepath.Join(t.TempDir(), "worklog.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write work log: %v", err)
+	}
+
+	output := executeCommandText(t, "stats", "--file", path, "--format", "json")
+	var stats activityStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+	// The GitLab link counts as a PR but not as a GitHub repository, and
+	// 2024-08-03 has nothing logged so it is not an active day
+	if stats.PRs != 4 || stats.ActiveDays != 3 || stats.PRsPerDay != 1.33 || stats.Repos != 2 {
+		t.Errorf("Expected 4 PRs over 3 active days in 2 repositories, got %+v", stats)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+var streakCmd = &cobra.Command{
+	Use:   "streak",
+	Short: "Show how many consecutive days you have logged work.",
+	Long:  `Prints the current streak of consecutive days with at least one work_log entry or task, and the longest streak in the work log. The current streak still counts when today isn't logged yet but yesterday was.`,
+	Run:   runStreakCommand,
+}
+
+func init() {
+	streakCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format for the streaks (text, json).")
+
+	rootCmd.AddCommand(streakCmd)
+}
+
+// streak is a run of consecutive logged days. Start and End are empty for a
+// streak of zero days.
+type streak struct {
+	Days  int    `json:"days"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// streakStats holds the current and longest streaks of logged days.
+type streakStats struct {
+	Current streak `json:"current"`
+	Longest streak `json:"longest"`
+}
+
+func runStreakCommand(cmd *cobra.Command, args []string) {
+	if outputFormat != formatText && outputFormat != formatJSON {
+		slog.Error("unsupported streak format", "format", outputFormat)
+		os.Exit(exitBadInput)
+	}
+
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		os.Exit(1)
+	}
+
+	stats := computeStreaks(workData, nowFunc())
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal streaks as JSON", "error", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	fmt.Fprintf(out, "Current streak: %s\n", formatStreak(stats.Current))
+	fmt.Fprintf(out, "Longest streak: %s\n", formatStreak(stats.Longest))
+}
+
+// formatStreak renders a streak as "3 days (2024-08-01 to 2024-08-03)".
+func formatStreak(s streak) string {
+	switch s.Days {
+	case 0:
+		return "0 days"
+	case 1:
+		return fmt.Sprintf("1 day (%s)", s.Start)
+	default:
+		return fmt.Sprintf("%d days (%s to %s)", s.Days, s.Start, s.End)
+	}
+}
+
+// computeStreaks finds the runs of consecutive logged days in workData. A day is
+// logged when it has at least one work_log entry or task; dates that don't parse
+// and dates after today, such as planned entries, are ignored. The current streak is the run ending today, or yesterday when
+// today isn't logged yet, and is zero otherwise.
+func computeStreaks(workData model.WorkData, now time.Time) streakStats {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+
+	var days []time.Time
+	for date, dailyLog := range workData {
+		if len(dailyLog.WorkLogEntries) == 0 && len(dailyLog.Tasks) == 0 {
+			continue
+		}
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil || day.After(today) {
+			continue
+		}
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	var stats streakStats
+	for i := 0; i < len(days); {
+		// Extend the run while each day follows the previous one
+		j := i + 1
+		for j < len(days) && days[j].Equal(days[j-1].AddDate(0, 0, 1)) {
+			j++
+		}
+		run := streak{Days: j - i, Start: days[i].Format("2006-01-02"), End: days[j-1].Format("2006-01-02")}
+		if run.Days > stats.Longest.Days {
+			stats.Longest = run
+		}
+		if last := days[j-1]; last.Equal(today) || last.Equal(yesterday) {
+			stats.Current = run
+		}
+		i = j
+	}
+	return stats
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestComputeStreaks(t *testing.T) {
+	logged := model.DailyLog{WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "17:00"}}}
+	tasksOnly := model.DailyLog{Tasks: []model.Task{{Description: "Planning"}}}
+	workData := model.WorkData{
+		"2024-07-01": logged,
+		"2024-07-02": logged,
+		"2024-07-03": tasksOnly,
+		"2024-07-04": logged,
+		"2024-07-10": logged,
+		"2024-07-11": {}, // an empty entry doesn't count
+		"2024-08-01": logged,
+		"2024-08-02": logged,
+		"not-a-date": logged,
+	}
+
+	tests := []struct {
+		name string
+		now  string
+		want streakStats
+	}{
+		{
+			name: "today logged",
+			now:  "2024-08-02",
+			want: streakStats{
+				Current: streak{Days: 2, Start: "2024-08-01", End: "2024-08-02"},
+				Longest: streak{Days: 4, Start: "2024-07-01", End: "2024-07-04"},
+			},
+		},
+		{
+			name: "today not logged yet but yesterday was",
+			now:  "2024-08-03",
+			want: streakStats{
+				Current: streak{Days: 2, Start: "2024-08-01", End: "2024-08-02"},
+				Longest: streak{Days: 4, Start: "2024-07-01", End: "2024-07-04"},
+			},
+		},
+		{
+			name: "streak broken by a gap",
+			now:  "2024-08-04",
+			want: streakStats{
+				Longest: streak{Days: 4, Start: "2024-07-01", End: "2024-07-04"},
+			},
+		},
+		{
+			name: "future entries are ignored",
+			now:  "2024-07-03",
+			want: streakStats{
+				Current: streak{Days: 3, Start: "2024-07-01", End: "2024-07-03"},
+				Longest: streak{Days: 3, Start: "2024-07-01", End: "2024-07-03"},
+			},
+		},
+		{
+			name: "current streak is the longest",
+			now:  "2024-07-05",
+			want: streakStats{
+				Current: streak{Days: 4, Start: "2024-07-01", End: "2024-07-04"},
+				Longest: streak{Days: 4, Start: "2024-07-01", End: "2024-07-04"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, _ := time.ParseInLocation("2006-01-02", tt.now, time.Local)
+			if got := computeStreaks(workData, now.Add(20*time.Hour)); got != tt.want {
+				t.Errorf("computeStreaks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := computeStreaks(model.WorkData{}, time.Now()); got != (streakStats{}) {
+		t.Errorf("Expected no streaks for an empty work log, got %+v", got)
+	}
+}
+
+func TestStreakCommand(t *testing.T) {
+	tmpFile, cleanup := setupTests(t)
+	defer cleanup()
+	nowFunc = func() time.Time { return time.Date(2024, 8, 4, 9, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { nowFunc = time.Now })
+
+	t.Run("text output", func(t *testing.T) {
+		output := executeCommandText(t, "streak", "--file", tmpFile)
+		expected := "Current streak: 3 days (2024-08-01 to 2024-08-03)\n" +
+			"Longest streak: 3 days (2024-08-01 to 2024-08-03)\n"
+		if output != expected {
+			t.Errorf("Expected output:\n%q\nGot:\n%q", expected, output)
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		nowFunc = func() time.Time { return time.Date(2024, 8, 10, 9, 0, 0, 0, time.Local) }
+		output := executeCommandText(t, "streak", "--file", tmpFile, "--format", "json")
+
+		var stats streakStats
+		if err := json.Unmarshal([]byte(output), &stats); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		want := streakStats{Longest: streak{Days: 3, Start: "2024-08-01", End: "2024-08-03"}}
+		if stats != want {
+			t.Errorf("Expected %+v, got %+v", want, stats)
+		}
+	})
+}
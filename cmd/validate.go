@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the worklog file for errors.",
+	Long:  `Validates every entry in the worklog file, reporting unparseable times, work logs that end before they start, unknown task statuses, and malformed date keys. Exits non-zero if any problems are found.`,
+	Run:   runValidateCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidateCommand(cmd *cobra.Command, args []string) {
+	workData, err := loadAndMergeWorkData(filePaths)
+	if err != nil {
+		slog.Error("failed to load work log file", "error", err, "path", strings.Join(filePaths, ","))
+		os.Exit(1)
+	}
+
+	out := cmd.OutOrStdout()
+	workLogName := strings.Join(filePaths, ", ")
+	problems := workData.Validate()
+	if len(problems) == 0 {
+		entries, tasks := 0, 0
+		for _, dailyLog := range workData {
+			entries += len(dailyLog.WorkLogEntries)
+			tasks += len(dailyLog.Tasks)
+		}
+		fmt.Fprintf(out, "✅ %s is valid (%d dates, %d work log entries, %d tasks)\n", workLogName, len(workData), entries, tasks)
+		return
+	}
+
+	dates := make(map[string]bool)
+	for _, problem := range problems {
+		fmt.Fprintln(out, problem)
+		dates[problem.Date] = true
+	}
+	fmt.Fprintf(out, "\n❌ Found %d problem(s) across %d date(s) in %s\n", len(problems), len(dates), workLogName)
+	os.Exit(1)
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCommand(t *testing.T) {
+	t.Run("valid worklog has no problems", func(t *testing.T) {
+		tmpFile, cleanup := setupTests(t)
+		defer cleanup()
+
+		output := executeCommandText(t, "validate", "--file", tmpFile)
+		if !strings.Contains(output, "is valid (3 dates, 4 work log entries, 7 tasks)") {
+			t.Errorf("Unexpected validate output:\n%s", output)
+		}
+	})
+}
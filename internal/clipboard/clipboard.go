@@ -2,6 +2,8 @@
 package clipboard
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"os/exec"
 	"runtime"
@@ -22,60 +24,205 @@ func CopyHTML(htmlContent string) error {
 	}
 }
 
-func copyHTMLLinux(htmlContent string) error {
-	// Try different clipboard tools in order of preference
-	htmlTools := [][]string{
+// Copy puts htmlContent and text on the clipboard together as text/html and
+// text/plain alternatives, so the application pasting picks the flavor it
+// prefers. When no tool can set both at once it falls back to copying the HTML
+// alone, then the text alone.
+func Copy(htmlContent, text string) error {
+	switch runtime.GOOS {
+	case "linux":
+		return runFirst(linuxCopySteps(htmlContent, text, isCommandAvailable))
+	case "darwin":
+		return runFirst([]copyStep{
+			{args: []string{"osascript", "-"}, content: macOSScript(htmlContent, text)},
+			{args: []string{"pbcopy"}, content: text},
+		})
+	case "windows":
+		return runFirst([]copyStep{
+			{args: []string{"powershell", "-NoProfile", "-Command", "-"}, content: windowsScript(htmlContent, text)},
+			{args: []string{"clip"}, content: text},
+		})
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+}
+
+// CopyText attempts to copy plain text content to the system clipboard.
+func CopyText(content string) error {
+	switch runtime.GOOS {
+	case "linux":
+		return copyTextLinux(content)
+	case "darwin":
+		return runWithStdin(content, "pbcopy")
+	case "windows":
+		return runWithStdin(content, "clip")
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+}
+
+// Linux clipboard tools in order of preference.
+var (
+	linuxHTMLTools = [][]string{
 		{"wl-copy", "--type", "text/html"},                        // Wayland HTML
 		{"xclip", "-selection", "clipboard", "-t", "text/html"},   // X11 HTML
 		{"xsel", "--clipboard", "--input", "--type", "text/html"}, // X11 alternative HTML
 	}
+	linuxTextTools = [][]string{
+		{"wl-copy"},                          // Wayland
+		{"xclip", "-selection", "clipboard"}, // X11
+		{"xsel", "--clipboard", "--input"},   // X11 alternative
+	}
+)
 
-	for _, tool := range htmlTools {
-		if isCommandAvailable(tool[0]) {
-			cmd := exec.Command(tool[0], tool[1:]...)
-			cmd.Stdin = strings.NewReader(htmlContent)
-			if err := cmd.Run(); err == nil {
-				return nil
-			}
+// copyStep is one attempt of a clipboard fallback chain: a command line and the
+// content piped to its standard input.
+type copyStep struct {
+	args    []string
+	content string
+}
+
+// linuxCopySteps returns the commands Copy tries on Linux, in order. CopyQ sets
+// both flavors in one call, taking them as arguments; wl-copy, xclip, and xsel
+// set a single type, so they are tried with the HTML and then with the plain
+// text. Tools available reports as missing are left out.
+func linuxCopySteps(htmlContent, text string, available func(string) bool) []copyStep {
+	var steps []copyStep
+	if available("copyq") {
+		steps = append(steps, copyStep{args: []string{"copyq", "copy", "text/html", htmlContent, "text/plain", text}})
+	}
+	for _, tool := range linuxHTMLTools {
+		if available(tool[0]) {
+			steps = append(steps, copyStep{args: tool, content: htmlContent})
+		}
+	}
+	for _, tool := range linuxTextTools {
+		if available(tool[0]) {
+			steps = append(steps, copyStep{args: tool, content: text})
 		}
 	}
+	return steps
+}
+
+// runFirst runs each step in order until one succeeds, returning the last error
+// when none do.
+func runFirst(steps []copyStep) error {
+	err := fmt.Errorf("no suitable clipboard tool found (tried: copyq, wl-copy, xclip, xsel)")
+	for _, step := range steps {
+		if err = runWithStdin(step.content, step.args[0], step.args[1:]...); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func copyHTMLLinux(htmlContent string) error {
+	if copyWithFirstAvailable(linuxHTMLTools, htmlContent) {
+		return nil
+	}
 
 	// Fallback: try to copy as plain text
-	textTools := [][]string{
-		{"wl-copy"},                          // Wayland
-		{"xclip", "-selection", "clipboard"}, // X11
-		{"xsel", "--clipboard", "--input"},   // X11 alternative
+	return copyTextLinux(htmlContent)
+}
+
+func copyTextLinux(content string) error {
+	if copyWithFirstAvailable(linuxTextTools, content) {
+		return nil
 	}
+	return fmt.Errorf("no suitable clipboard tool found (tried: wl-copy, xclip, xsel)")
+}
 
-	for _, tool := range textTools {
+// copyWithFirstAvailable pipes content to each installed tool in order, reporting
+// whether any of them succeeded.
+func copyWithFirstAvailable(tools [][]string, content string) bool {
+	for _, tool := range tools {
 		if isCommandAvailable(tool[0]) {
-			cmd := exec.Command(tool[0], tool[1:]...)
-			cmd.Stdin = strings.NewReader(htmlContent)
-			if err := cmd.Run(); err == nil {
-				return nil
+			if err := runWithStdin(content, tool[0], tool[1:]...); err == nil {
+				return true
 			}
 		}
 	}
+	return false
+}
 
-	return fmt.Errorf("no suitable clipboard tool found (tried: wl-copy, xclip, xsel)")
+// runWithStdin runs a command with content on its standard input.
+func runWithStdin(content string, name string, args ...string) error {
+	if !isCommandAvailable(name) {
+		return fmt.Errorf("clipboard tool %s not found", name)
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(content)
+	return cmd.Run()
 }
 
 func copyHTMLMacOS(htmlContent string) error {
-	// Use osascript to copy HTML with formatting
-	script := fmt.Sprintf(`osascript -e 'set the clipboard to "%s" as «class HTML»'`,
-		strings.ReplaceAll(htmlContent, `"`, `\"`))
+	// Feed the script to osascript on stdin so no shell quoting is involved
+	return runWithStdin(macOSHTMLScript(htmlContent), "osascript", "-")
+}
 
-	cmd := exec.Command("sh", "-c", script)
-	return cmd.Run()
+// macOSHTMLScript builds an AppleScript that sets the clipboard to htmlContent with
+// HTML formatting. The content is hex-encoded as an «data HTML…» literal so quotes,
+// backslashes, newlines, and «» characters cannot break out of the script.
+func macOSHTMLScript(htmlContent string) string {
+	return fmt.Sprintf("set the clipboard to «data HTML%s»", strings.ToUpper(hex.EncodeToString([]byte(htmlContent))))
+}
+
+// macOSScript builds an AppleScript that sets the clipboard to a record holding
+// htmlContent as HTML and text as UTF-8 plain text. Both are hex-encoded «data»
+// literals, like in macOSHTMLScript.
+func macOSScript(htmlContent, text string) string {
+	return fmt.Sprintf("set the clipboard to {«class HTML»:«data HTML%s», «class utf8»:«data utf8%s»}",
+		strings.ToUpper(hex.EncodeToString([]byte(htmlContent))), strings.ToUpper(hex.EncodeToString([]byte(text))))
 }
 
 func copyHTMLWindows(htmlContent string) error {
-	script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms; [System.Windows.Forms.Clipboard]::SetText(@"
-%s
-"@, [System.Windows.Forms.TextDataFormat]::Html)`, htmlContent)
+	// Feed the script to PowerShell on stdin so it is not limited by command line length
+	return runWithStdin(windowsHTMLScript(htmlContent), "powershell", "-NoProfile", "-Command", "-")
+}
 
-	cmd := exec.Command("powershell", "-Command", script)
-	return cmd.Run()
+// windowsHTMLScript builds a PowerShell script that sets the clipboard to
+// htmlContent in the CF_HTML format. The CF_HTML document is base64-encoded so
+// quotes and "@ sequences in the content cannot break out of the script.
+func windowsHTMLScript(htmlContent string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(cfHTML(htmlContent)))
+	return "Add-Type -AssemblyName System.Windows.Forms\n" +
+		"$html = [System.Text.Encoding]::UTF8.GetString([System.Convert]::FromBase64String('" + encoded + "'))\n" +
+		"[System.Windows.Forms.Clipboard]::SetText($html, [System.Windows.Forms.TextDataFormat]::Html)\n"
+}
+
+// windowsScript builds a PowerShell script that puts htmlContent in the CF_HTML
+// format and text as Unicode text in one data object, so both are on the
+// clipboard at once. Both are base64-encoded, like in windowsHTMLScript.
+func windowsScript(htmlContent, text string) string {
+	encodedHTML := base64.StdEncoding.EncodeToString([]byte(cfHTML(htmlContent)))
+	encodedText := base64.StdEncoding.EncodeToString([]byte(text))
+	return "Add-Type -AssemblyName System.Windows.Forms\n" +
+		"$data = New-Object System.Windows.Forms.DataObject\n" +
+		"$data.SetData([System.Windows.Forms.DataFormats]::Html, [System.Text.Encoding]::UTF8.GetString([System.Convert]::FromBase64String('" + encodedHTML + "')))\n" +
+		"$data.SetData([System.Windows.Forms.DataFormats]::UnicodeText, [System.Text.Encoding]::UTF8.GetString([System.Convert]::FromBase64String('" + encodedText + "')))\n" +
+		"[System.Windows.Forms.Clipboard]::SetDataObject($data, $true)\n"
+}
+
+// cfHTMLHeader is the CF_HTML description header. Each offset is a zero-padded
+// 10 digit byte count from the start of the data, so the header length is fixed.
+const cfHTMLHeader = "Version:0.9\r\nStartHTML:%010d\r\nEndHTML:%010d\r\nStartFragment:%010d\r\nEndFragment:%010d\r\n"
+
+// cfHTML wraps an HTML fragment in the Windows CF_HTML clipboard format: a header
+// giving the byte offsets of the HTML document and of the fragment within it,
+// followed by the document with the fragment between StartFragment/EndFragment
+// comments.
+func cfHTML(fragment string) string {
+	const (
+		prefix = "<html>\r\n<body>\r\n<!--StartFragment-->"
+		suffix = "<!--EndFragment-->\r\n</body>\r\n</html>"
+	)
+	headerLen := len(fmt.Sprintf(cfHTMLHeader, 0, 0, 0, 0))
+	startHTML := headerLen
+	startFragment := startHTML + len(prefix)
+	endFragment := startFragment + len(fragment)
+	endHTML := endFragment + len(suffix)
+
+	return fmt.Sprintf(cfHTMLHeader, startHTML, endHTML, startFragment, endFragment) + prefix + fragment + suffix
 }
 
 func isCommandAvailable(name string) bool {
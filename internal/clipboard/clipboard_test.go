@@ -0,0 +1,158 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMacOSHTMLScript(t *testing.T) {
+	content := "<p class=\"summary\">Fix \"quoted\" 'text' with \\backslashes\\</p>\n<p>«guillemets» & $(not a subshell)</p>\n"
+
+	script := macOSHTMLScript(content)
+
+	const prefix, suffix = "set the clipboard to «data HTML", "»"
+	if !strings.HasPrefix(script, prefix) || !strings.HasSuffix(script, suffix) {
+		t.Fatalf("Unexpected script: %q", script)
+	}
+	encoded := strings.TrimSuffix(strings.TrimPrefix(script, prefix), suffix)
+	if strings.Trim(encoded, "0123456789ABCDEF") != "" {
+		t.Fatalf("Script payload contains characters other than hex digits: %q", encoded)
+	}
+
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode script payload: %v", err)
+	}
+	if string(decoded) != content {
+		t.Errorf("Round-tripped content differs:\ngot:  %q\nwant: %q", decoded, content)
+	}
+}
+
+func TestCFHTML(t *testing.T) {
+	fragment := "<p>Fixed \"quoted\" text, «guillemets» and a \"@ here-string terminator</p>"
+
+	data := cfHTML(fragment)
+
+	offsets := make(map[string]int)
+	for _, line := range strings.Split(data, "\r\n")[1:5] {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || len(value) != 10 {
+			t.Fatalf("Malformed header line %q", line)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			t.Fatalf("Header line %q has a non-numeric offset: %v", line, err)
+		}
+		offsets[name] = n
+	}
+	if !strings.HasPrefix(data, "Version:0.9\r\n") {
+		t.Errorf("Expected a Version header, got %q", data)
+	}
+
+	if got := data[offsets["StartHTML"]:]; !strings.HasPrefix(got, "<html>") {
+		t.Errorf("StartHTML points at %q, want <html>", got)
+	}
+	if offsets["EndHTML"] != len(data) || !strings.HasSuffix(data, "</html>") {
+		t.Errorf("EndHTML = %d, want %d (end of data)", offsets["EndHTML"], len(data))
+	}
+	if got := data[offsets["StartFragment"]:offsets["EndFragment"]]; got != fragment {
+		t.Errorf("Fragment offsets select %q, want %q", got, fragment)
+	}
+	if got := data[:offsets["StartFragment"]]; !strings.HasSuffix(got, "<!--StartFragment-->") {
+		t.Errorf("StartFragment does not follow the StartFragment comment: %q", got)
+	}
+}
+
+func TestWindowsHTMLScript(t *testing.T) {
+	content := "<p>Ends early?\n\"@\nWrite-Host 'injected'</p>"
+
+	script := windowsHTMLScript(content)
+
+	if strings.Contains(script, "injected") || strings.Contains(script, `"@`) {
+		t.Fatalf("Script contains raw content:\n%s", script)
+	}
+	const prefix = "FromBase64String('"
+	start := strings.Index(script, prefix)
+	if start < 0 {
+		t.Fatalf("Script does not decode a base64 payload:\n%s", script)
+	}
+	encoded := script[start+len(prefix):]
+	encoded = encoded[:strings.Index(encoded, "'")]
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode script payload: %v", err)
+	}
+	if string(decoded) != cfHTML(content) {
+		t.Errorf("Payload is not the CF_HTML document:\ngot:  %q\nwant: %q", decoded, cfHTML(content))
+	}
+}
+
+func TestLinuxCopySteps(t *testing.T) {
+	const htmlContent, text = "<p>report</p>", "report"
+
+	tests := []struct {
+		name      string
+		installed []string
+		want      [][]string
+	}{
+		{
+			name:      "copyq sets both flavors first",
+			installed: []string{"copyq", "wl-copy", "xclip", "xsel"},
+			want: [][]string{
+				{"copyq", "copy", "text/html", htmlContent, "text/plain", text},
+				{"wl-copy", "--type", "text/html", htmlContent},
+				{"xclip", "-selection", "clipboard", "-t", "text/html", htmlContent},
+				{"xsel", "--clipboard", "--input", "--type", "text/html", htmlContent},
+				{"wl-copy", text},
+				{"xclip", "-selection", "clipboard", text},
+				{"xsel", "--clipboard", "--input", text},
+			},
+		},
+		{
+			name:      "single-type tool falls back from HTML to text",
+			installed: []string{"xclip"},
+			want: [][]string{
+				{"xclip", "-selection", "clipboard", "-t", "text/html", htmlContent},
+				{"xclip", "-selection", "clipboard", text},
+			},
+		},
+		{
+			name:      "no tools",
+			installed: nil,
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			available := func(name string) bool { return slices.Contains(tt.installed, name) }
+			// Record each step as its command line followed by the piped content
+			var got [][]string
+			for _, step := range linuxCopySteps(htmlContent, text, available) {
+				call := append([]string{}, step.args...)
+				if step.content != "" {
+					call = append(call, step.content)
+				}
+				got = append(got, call)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linuxCopySteps() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMacOSScript(t *testing.T) {
+	script := macOSScript("<p>\"quoted\"</p>", "«text»")
+
+	want := "set the clipboard to {«class HTML»:«data HTML" + strings.ToUpper(hex.EncodeToString([]byte("<p>\"quoted\"</p>"))) +
+		"», «class utf8»:«data utf8" + strings.ToUpper(hex.EncodeToString([]byte("«text»"))) + "»}"
+	if script != want {
+		t.Errorf("macOSScript() = %q, want %q", script, want)
+	}
+}
@@ -0,0 +1,229 @@
+// Package github provides GitHub integration for fetching pull request status.
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/workerpool"
+)
+
+// DefaultAPIBaseURL is the GitHub REST API base URL.
+const DefaultAPIBaseURL = "https://api.github.com"
+
+// APIBaseURL is the GitHub REST API base URL used for requests.
+var APIBaseURL = DefaultAPIBaseURL
+
+// DefaultTimeout is the default timeout for a single GitHub API request.
+const DefaultTimeout = 10 * time.Second
+
+// HTTPClient is shared by all GitHub API requests so keep-alive connections are
+// reused across fetches. Its Timeout may be changed before fetching.
+var HTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// Pull request states reported by PRInfo.State.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+// PRInfo holds information about a GitHub pull request.
+type PRInfo struct {
+	Owner  string
+	Repo   string
+	Number int
+	Title  string
+	State  string
+	URL    string
+}
+
+// apiResponse represents the response from the GitHub pulls API.
+type apiResponse struct {
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Merged bool   `json:"merged"`
+}
+
+// prURLRegex matches pull request URLs such as https://github.com/owner/repo/pull/123.
+var prURLRegex = regexp.MustCompile(`github\.com/([^/\s]+)/([^/\s]+)/pull/(\d+)`)
+
+// ParsePRURL extracts the owner, repository, and pull request number from a GitHub PR URL.
+func ParsePRURL(prURL string) (owner, repo string, number int, ok bool) {
+	matches := prURLRegex.FindStringSubmatch(prURL)
+	if len(matches) < 4 {
+		return "", "", 0, false
+	}
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return "", "", 0, false
+	}
+	return matches[1], matches[2], number, true
+}
+
+// RepoFromPRURL returns the "owner/repo" a GitHub PR URL belongs to, or false
+// for non-GitHub and malformed URLs.
+func RepoFromPRURL(prURL string) (string, bool) {
+	owner, repo, _, ok := ParsePRURL(prURL)
+	if !ok {
+		return "", false
+	}
+	return owner + "/" + repo, true
+}
+
+// cacheTTL is how long a fetched pull request is reused, so a long-running
+// serve process picks up title and state changes while a single run still
+// fetches each pull request only once.
+var cacheTTL = 10 * time.Minute
+
+// cachedPR is a fetched pull request along with the time it was fetched.
+type cachedPR struct {
+	info      PRInfo
+	fetchedAt time.Time
+}
+
+// fetched caches pull requests resolved through the API by URL, so a link that
+// appears in several outputs of one run is only fetched once.
+var fetched = struct {
+	sync.Mutex
+	prs map[string]cachedPR
+}{prs: make(map[string]cachedPR)}
+
+// resetCache forgets every cached pull request.
+func resetCache() {
+	fetched.Lock()
+	defer fetched.Unlock()
+	fetched.prs = make(map[string]cachedPR)
+}
+
+// FetchPR fetches the title and state of a GitHub pull request using the API.
+// Successful fetches are cached for cacheTTL.
+func FetchPR(prURL string) (PRInfo, error) {
+	fetched.Lock()
+	cached, ok := fetched.prs[prURL]
+	if ok && time.Since(cached.fetchedAt) >= cacheTTL {
+		delete(fetched.prs, prURL)
+		ok = false
+	}
+	fetched.Unlock()
+	if ok {
+		return cached.info, nil
+	}
+
+	owner, repo, number, ok := ParsePRURL(prURL)
+	if !ok {
+		return PRInfo{URL: prURL}, fmt.Errorf("not a GitHub pull request URL: %s", prURL)
+	}
+	pr := PRInfo{
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		URL:    prURL,
+	}
+
+	// Check if a GitHub token is available
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		// Return PR info without title or state if no token is available
+		return pr, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", APIBaseURL, owner, repo, number)
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return pr, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return pr, fmt.Errorf("failed to fetch pull request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return pr, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+
+	var ghResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ghResp); err != nil {
+		return pr, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	pr.Title = ghResp.Title
+	pr.State = ghResp.State
+	if ghResp.Merged {
+		pr.State = StateMerged
+	}
+
+	fetched.Lock()
+	fetched.prs[prURL] = cachedPR{info: pr, fetchedAt: time.Now()}
+	fetched.Unlock()
+	return pr, nil
+}
+
+// DefaultConcurrency is the default number of pull requests fetched in parallel.
+const DefaultConcurrency = 5
+
+// Concurrency is the maximum number of pull requests ProcessPRs fetches in parallel.
+var Concurrency = DefaultConcurrency
+
+// ProcessPRs fetches information for each GitHub pull request URL, keyed by URL.
+// Links that are not GitHub pull requests are skipped. Pull requests are fetched
+// concurrently by a bounded pool of Concurrency workers.
+func ProcessPRs(prURLs []string) map[string]PRInfo {
+	seen := make(map[string]bool)
+	var links []string
+	for _, prURL := range prURLs {
+		if seen[prURL] {
+			continue
+		}
+		seen[prURL] = true
+		if _, _, _, ok := ParsePRURL(prURL); ok {
+			links = append(links, prURL)
+		}
+	}
+	sort.Strings(links)
+
+	return workerpool.Map(links, Concurrency, func(prURL string) PRInfo {
+		info, err := FetchPR(prURL)
+		if err != nil {
+			slog.Warn("failed to fetch GitHub pull request", "url", prURL, "error", err)
+		}
+		return info
+	})
+}
+
+// Label returns a short label for a pull request link such as
+// "repo#123: Fix caching bug" when its title is known, or the URL otherwise.
+func Label(prURL string, prInfo map[string]PRInfo) string {
+	info, exists := prInfo[prURL]
+	if !exists || info.Title == "" {
+		return prURL
+	}
+	return fmt.Sprintf("%s#%d: %s", info.Repo, info.Number, info.Title)
+}
+
+// FormatPRHTML formats a pull request link as HTML, labeled as by Label and
+// followed by a state badge such as [merged] or [open] when the state is known.
+func FormatPRHTML(prURL string, prInfo map[string]PRInfo) string {
+	link := fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(prURL), html.EscapeString(Label(prURL, prInfo)))
+
+	info, exists := prInfo[prURL]
+	if !exists || info.State == "" {
+		return link
+	}
+	return fmt.Sprintf(`%s [%s]`, link, html.EscapeString(info.State))
+}
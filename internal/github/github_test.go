@@ -0,0 +1,180 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParsePRURL(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantOwner  string
+		wantRepo   string
+		wantNumber int
+		wantOK     bool
+	}{
+		{input: "https://github.com/example/repo/pull/123", wantOwner: "example", wantRepo: "repo", wantNumber: 123, wantOK: true},
+		{input: "https://github.com/example/repo/pull/7/files", wantOwner: "example", wantRepo: "repo", wantNumber: 7, wantOK: true},
+		{input: "https://github.com/example/repo/issues/5", wantOK: false},
+		{input: "https://gitlab.com/example/repo/-/merge_requests/1", wantOK: false},
+	}
+	for _, tt := range tests {
+		owner, repo, number, ok := ParsePRURL(tt.input)
+		if ok != tt.wantOK || owner != tt.wantOwner || repo != tt.wantRepo || number != tt.wantNumber {
+			t.Errorf("ParsePRURL(%q) = (%q, %q, %d, %v), want (%q, %q, %d, %v)",
+				tt.input, owner, repo, number, ok, tt.wantOwner, tt.wantRepo, tt.wantNumber, tt.wantOK)
+		}
+	}
+}
+
+func TestRepoFromPRURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantRepo string
+		wantOK   bool
+	}{
+		{input: "https://github.com/example/repo/pull/123", wantRepo: "example/repo", wantOK: true},
+		{input: "github.com/openshift/hypershift/pull/7/files", wantRepo: "openshift/hypershift", wantOK: true},
+		{input: "https://github.com/example/repo", wantOK: false},
+		{input: "https://github.com/example/repo/pull/abc", wantOK: false},
+		{input: "https://gitlab.com/example/repo/-/merge_requests/1", wantOK: false},
+		{input: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		repo, ok := RepoFromPRURL(tt.input)
+		if repo != tt.wantRepo || ok != tt.wantOK {
+			t.Errorf("RepoFromPRURL(%q) = (%q, %v), want (%q, %v)", tt.input, repo, ok, tt.wantRepo, tt.wantOK)
+		}
+	}
+}
+
+func TestProcessPRs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Unexpected Authorization header %q", got)
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/repos/example/repo/pulls/") {
+		case "1":
+			fmt.Fprint(w, `{"title": "Add feature", "state": "closed", "merged": true}`)
+		case "2":
+			fmt.Fprint(w, `{"title": "Fix bug", "state": "open", "merged": false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	APIBaseURL = server.URL
+	t.Cleanup(func() { APIBaseURL = DefaultAPIBaseURL })
+	t.Cleanup(resetCache)
+
+	merged := "https://github.com/example/repo/pull/1"
+	open := "https://github.com/example/repo/pull/2"
+	missing := "https://github.com/example/repo/pull/3"
+	prInfo := ProcessPRs([]string{merged, open, missing, "https://example.com/not-a-pr"})
+
+	if len(prInfo) != 3 {
+		t.Fatalf("Expected 3 pull requests, got %d: %+v", len(prInfo), prInfo)
+	}
+	if got := prInfo[merged]; got.State != StateMerged || got.Title != "Add feature" {
+		t.Errorf("Unexpected merged PR info: %+v", got)
+	}
+	if got := prInfo[open]; got.State != StateOpen || got.Title != "Fix bug" {
+		t.Errorf("Unexpected open PR info: %+v", got)
+	}
+	if got := prInfo[missing]; got.State != "" || got.Number != 3 {
+		t.Errorf("Expected basic info for a failed fetch, got %+v", got)
+	}
+
+	wantBadge := `<a href="https://github.com/example/repo/pull/1">repo#1: Add feature</a> [merged]`
+	if got := FormatPRHTML(merged, prInfo); got != wantBadge {
+		t.Errorf("FormatPRHTML = %q, want %q", got, wantBadge)
+	}
+	wantPlain := `<a href="https://github.com/example/repo/pull/3">https://github.com/example/repo/pull/3</a>`
+	if got := FormatPRHTML(missing, prInfo); got != wantPlain {
+		t.Errorf("FormatPRHTML = %q, want %q", got, wantPlain)
+	}
+}
+
+func TestFetchPRCachesResults(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"title": "Fix caching bug", "state": "open", "merged": false}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	APIBaseURL = server.URL
+	t.Cleanup(func() { APIBaseURL = DefaultAPIBaseURL })
+	t.Cleanup(resetCache)
+
+	prURL := "https://github.com/example/repo/pull/123"
+	ProcessPRs([]string{prURL})
+	prInfo := ProcessPRs([]string{prURL})
+
+	if requests != 1 {
+		t.Errorf("Expected 1 API request for a repeated pull request, got %d", requests)
+	}
+	if got := Label(prURL, prInfo); got != "repo#123: Fix caching bug" {
+		t.Errorf("Label = %q, want %q", got, "repo#123: Fix caching bug")
+	}
+}
+
+func TestFetchPRRefetchesExpiredResults(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"title": "Fix caching bug", "state": "open", "merged": false}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	APIBaseURL = server.URL
+	ttl := cacheTTL
+	cacheTTL = 0
+	t.Cleanup(func() {
+		APIBaseURL = DefaultAPIBaseURL
+		cacheTTL = ttl
+	})
+	t.Cleanup(resetCache)
+
+	prURL := "https://github.com/example/repo/pull/123"
+	ProcessPRs([]string{prURL})
+	ProcessPRs([]string{prURL})
+
+	if requests != 2 {
+		t.Errorf("Expected an expired pull request to be fetched again, got %d API requests", requests)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	prURL := "https://github.com/example/repo/pull/9"
+	prInfo := map[string]PRInfo{prURL: {Owner: "example", Repo: "repo", Number: 9, Title: "Add docs"}}
+
+	if got := Label(prURL, prInfo); got != "repo#9: Add docs" {
+		t.Errorf("Label = %q, want %q", got, "repo#9: Add docs")
+	}
+	if got := Label(prURL, nil); got != prURL {
+		t.Errorf("Expected the URL without PR info, got %q", got)
+	}
+	if got := Label(prURL, map[string]PRInfo{prURL: {Repo: "repo", Number: 9}}); got != prURL {
+		t.Errorf("Expected the URL without a title, got %q", got)
+	}
+}
+
+func TestFetchPRWithoutToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+
+	info, err := FetchPR("https://github.com/example/repo/pull/42")
+	if err != nil {
+		t.Fatalf("FetchPR returned error: %v", err)
+	}
+	if info.Number != 42 || info.State != "" || info.Title != "" {
+		t.Errorf("Expected basic info without a token, got %+v", info)
+	}
+}
@@ -0,0 +1,438 @@
+// Package hours provides work log duration calculations and exports.
+package hours
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// Supported groupings for bucketed hour totals.
+const (
+	GroupByDay   = "day"
+	GroupByWeek  = "week"
+	GroupByMonth = "month"
+)
+
+// Supported directions for rounding work log entries.
+const (
+	RoundUp      = "up"
+	RoundDown    = "down"
+	RoundNearest = "nearest"
+)
+
+// Bucket holds the hours worked within one day, week, or calendar month.
+type Bucket struct {
+	Label    string
+	Entries  int
+	Duration time.Duration
+}
+
+// Options controls how work log durations are calculated.
+type Options struct {
+	// MergeOverlaps merges overlapping work log entries within a day before
+	// summing, so overlapping time is only counted once.
+	MergeOverlaps bool
+	// Location is the time zone work log times are recorded in. When set, entries
+	// are anchored to their date in that zone so days with a DST transition count
+	// elapsed time rather than wall-clock time. Nil treats times as zone-less.
+	Location *time.Location
+	// MinDuration is the shortest work log entry DropShortEntries keeps. Zero
+	// keeps every entry.
+	MinDuration time.Duration
+	// Rounding rounds each work log entry to a multiple of this duration, in the
+	// RoundMode direction, before it is summed. With MergeOverlaps the merged
+	// ranges are rounded instead. Zero leaves durations as logged.
+	Rounding  time.Duration
+	RoundMode string
+}
+
+// entryDuration returns the length of a parsed work log entry, rounded as opts
+// asks.
+func (opts Options) entryDuration(iv interval) time.Duration {
+	return roundDuration(iv.end.Sub(iv.start), opts.Rounding, opts.RoundMode)
+}
+
+// roundDuration rounds d to a multiple of to: up, down, or to the nearest
+// multiple with halves rounded up. A non-positive to, or an unknown mode, leaves
+// d unchanged.
+func roundDuration(d time.Duration, to time.Duration, mode string) time.Duration {
+	if to <= 0 {
+		return d
+	}
+	switch mode {
+	case RoundUp:
+		if rem := d % to; rem != 0 {
+			return d - rem + to
+		}
+		return d
+	case RoundDown:
+		return d - d%to
+	case RoundNearest:
+		return d.Round(to)
+	default:
+		return d
+	}
+}
+
+// Overlap describes two work log entries on the same date whose time ranges intersect.
+type Overlap struct {
+	Date   string
+	First  model.WorkLog
+	Second model.WorkLog
+}
+
+// interval is a parsed work log entry.
+type interval struct {
+	start time.Time
+	end   time.Time
+	entry model.WorkLog
+}
+
+// DailyTotals calculates the total duration worked for each of the given dates.
+// Every date is present in the result; entries with unparseable times, or that end
+// before they start without next_day set, are skipped with a warning so they
+// contribute zero hours.
+func DailyTotals(workData model.WorkData, dates []string, opts Options) map[string]time.Duration {
+	totals := make(map[string]time.Duration, len(dates))
+	for _, date := range dates {
+		totals[date] = 0
+		dailyLog, exists := workData[date]
+		if !exists {
+			continue
+		}
+
+		intervals, invalid := parseIntervals(date, dailyLog.WorkLogEntries, opts.Location)
+		for _, logEntry := range invalid {
+			slog.Warn("invalid time entry, skipping", "date", date, "entry", logEntry)
+		}
+		if opts.MergeOverlaps {
+			intervals = mergeIntervals(intervals)
+		}
+		for _, iv := range intervals {
+			totals[date] += opts.entryDuration(iv)
+		}
+	}
+	return totals
+}
+
+// DropShortEntries returns a copy of workData in which work log entries on the
+// given dates lasting less than opts.MinDuration are removed, so they count toward
+// neither totals nor entry counts. Entries with unparseable times are kept so they
+// are still reported as invalid.
+func DropShortEntries(workData model.WorkData, dates []string, opts Options) model.WorkData {
+	filtered := make(model.WorkData, len(workData))
+	for date, dailyLog := range workData {
+		filtered[date] = dailyLog
+	}
+	if opts.MinDuration <= 0 {
+		return filtered
+	}
+
+	for _, date := range dates {
+		dailyLog, exists := workData[date]
+		if !exists {
+			continue
+		}
+		var kept []model.WorkLog
+		for _, logEntry := range dailyLog.WorkLogEntries {
+			intervals, _ := parseIntervals(date, []model.WorkLog{logEntry}, opts.Location)
+			if len(intervals) == 1 && intervals[0].end.Sub(intervals[0].start) < opts.MinDuration {
+				slog.Debug("work log entry shorter than minimum duration, skipping", "date", date, "entry", logEntry, "min_duration", opts.MinDuration)
+				continue
+			}
+			kept = append(kept, logEntry)
+		}
+		dailyLog.WorkLogEntries = kept
+		filtered[date] = dailyLog
+	}
+	return filtered
+}
+
+// FindOverlaps returns every pair of intersecting work log entries on the given dates,
+// with times anchored in opts.Location. Entries that merely touch (one ends when
+// the next starts) do not overlap.
+func FindOverlaps(workData model.WorkData, dates []string, opts Options) []Overlap {
+	var overlaps []Overlap
+	for _, date := range dates {
+		intervals, _ := parseIntervals(date, workData[date].WorkLogEntries, opts.Location)
+		sortIntervals(intervals)
+
+		if len(intervals) == 0 {
+			continue
+		}
+
+		// Track the entry that extends furthest so far; any later entry starting
+		// before it ends intersects it.
+		furthest := intervals[0]
+		for _, iv := range intervals[1:] {
+			if iv.start.Before(furthest.end) {
+				overlaps = append(overlaps, Overlap{Date: date, First: furthest.entry, Second: iv.entry})
+			}
+			if iv.end.After(furthest.end) {
+				furthest = iv
+			}
+		}
+	}
+	return overlaps
+}
+
+// parseIntervals parses work log entries, returning the valid intervals and the
+// entries whose times could not be parsed. Entries marked next_day end on the
+// following day; any other entry that ends before it starts is invalid. When loc
+// is set, times are placed on the given date in that zone.
+func parseIntervals(date string, entries []model.WorkLog, loc *time.Location) ([]interval, []model.WorkLog) {
+	day, dayErr := time.Parse("2006-01-02", date)
+
+	var intervals []interval
+	var invalid []model.WorkLog
+	for _, logEntry := range entries {
+		start, err1 := model.ParseWorkTime(logEntry.StartTime)
+		end, err2 := model.ParseWorkTime(logEntry.EndTime)
+		if err1 != nil || err2 != nil {
+			invalid = append(invalid, logEntry)
+			continue
+		}
+		if loc != nil && dayErr == nil {
+			endDay := day
+			if logEntry.NextDay {
+				endDay = day.AddDate(0, 0, 1)
+			}
+			start = time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+			end = time.Date(endDay.Year(), endDay.Month(), endDay.Day(), end.Hour(), end.Minute(), end.Second(), 0, loc)
+		} else if logEntry.NextDay {
+			end = end.Add(24 * time.Hour)
+		}
+		if end.Before(start) {
+			invalid = append(invalid, logEntry)
+			continue
+		}
+		intervals = append(intervals, interval{start: start, end: end, entry: logEntry})
+	}
+	return intervals, invalid
+}
+
+// sortIntervals sorts intervals by start time.
+func sortIntervals(intervals []interval) {
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+}
+
+// mergeIntervals combines overlapping intervals into single continuous ranges.
+func mergeIntervals(intervals []interval) []interval {
+	if len(intervals) == 0 {
+		return intervals
+	}
+	sorted := append([]interval(nil), intervals...)
+	sortIntervals(sorted)
+
+	merged := []interval{sorted[0]}
+	for _, iv := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if iv.start.Before(last.end) {
+			if iv.end.After(last.end) {
+				last.end = iv.end
+			}
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+// trimOverlaps returns the intervals in start order with each one's start moved
+// past the time already covered by earlier intervals. An interval covered
+// entirely is kept with zero length so its entry is still counted.
+func trimOverlaps(intervals []interval) []interval {
+	trimmed := append([]interval(nil), intervals...)
+	sortIntervals(trimmed)
+
+	var covered time.Time
+	for i := range trimmed {
+		iv := &trimmed[i]
+		if i > 0 && iv.start.Before(covered) {
+			iv.start = covered
+			if iv.end.Before(covered) {
+				iv.end = covered
+			}
+		}
+		if i == 0 || iv.end.After(covered) {
+			covered = iv.end
+		}
+	}
+	return trimmed
+}
+
+// GroupTotals buckets the daily totals by day, week, or calendar month (e.g.
+// 2024-08), in date order. Weeks starting on Monday are labeled by ISO week (e.g.
+// 2024-W31); weeks starting on any other day are labeled by their first date.
+// Dates without work log entries are omitted.
+func GroupTotals(workData model.WorkData, dates []string, totals map[string]time.Duration, groupBy string, weekStart time.Weekday) ([]Bucket, error) {
+	var buckets []Bucket
+	for _, date := range dates {
+		entries := len(workData[date].WorkLogEntries)
+		if entries == 0 {
+			continue
+		}
+
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", date, err)
+		}
+		var label string
+		switch groupBy {
+		case GroupByDay:
+			label = date
+		case GroupByWeek:
+			if weekStart == time.Monday {
+				year, week := day.ISOWeek()
+				label = fmt.Sprintf("%d-W%02d", year, week)
+			} else {
+				label = "week of " + model.StartOfWeek(day, weekStart).Format("2006-01-02")
+			}
+		case GroupByMonth:
+			label = day.Format("2006-01")
+		default:
+			return nil, fmt.Errorf("unsupported grouping %q, use %s, %s, or %s", groupBy, GroupByDay, GroupByWeek, GroupByMonth)
+		}
+
+		if len(buckets) == 0 || buckets[len(buckets)-1].Label != label {
+			buckets = append(buckets, Bucket{Label: label})
+		}
+		last := &buckets[len(buckets)-1]
+		last.Entries += entries
+		last.Duration += totals[date]
+	}
+	return buckets, nil
+}
+
+// UnassignedTicket labels time from work log entries that don't reference a ticket.
+const UnassignedTicket = "Unassigned"
+
+// TicketTotals buckets work log durations by the ticket each entry references,
+// sorted by ticket with unassigned time last. Entries are rounded as opts asks.
+// With MergeOverlaps, time shared by overlapping entries counts toward the entry
+// that started first, so overlapping time is only counted once. Entries with
+// invalid times are skipped.
+func TicketTotals(workData model.WorkData, dates []string, opts Options) []Bucket {
+	byTicket := make(map[string]*Bucket)
+	for _, date := range dates {
+		intervals, _ := parseIntervals(date, workData[date].WorkLogEntries, opts.Location)
+		if opts.MergeOverlaps {
+			intervals = trimOverlaps(intervals)
+		}
+		for _, iv := range intervals {
+			ticket := strings.TrimSpace(iv.entry.Ticket)
+			if ticket == "" {
+				ticket = UnassignedTicket
+			}
+			bucket, exists := byTicket[ticket]
+			if !exists {
+				bucket = &Bucket{Label: ticket}
+				byTicket[ticket] = bucket
+			}
+			bucket.Entries++
+			bucket.Duration += opts.entryDuration(iv)
+		}
+	}
+
+	buckets := make([]Bucket, 0, len(byTicket))
+	for _, bucket := range byTicket {
+		buckets = append(buckets, *bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if (buckets[i].Label == UnassignedTicket) != (buckets[j].Label == UnassignedTicket) {
+			return buckets[j].Label == UnassignedTicket
+		}
+		return buckets[i].Label < buckets[j].Label
+	})
+	return buckets
+}
+
+// Total sums the durations for the given dates.
+func Total(totals map[string]time.Duration, dates []string) time.Duration {
+	var total time.Duration
+	for _, date := range dates {
+		total += totals[date]
+	}
+	return total
+}
+
+// Expected sums the hours expected on the given dates, counting standard for a
+// work day, half of it for a half day, and nothing for PTO or a holiday. Dates
+// without a day_type count as work days.
+func Expected(workData model.WorkData, dates []string, standard time.Duration) time.Duration {
+	var expected time.Duration
+	for _, date := range dates {
+		switch workData[date].DayType {
+		case model.DayTypePTO, model.DayTypeHoliday:
+		case model.DayTypeHalf:
+			expected += standard / 2
+		default:
+			expected += standard
+		}
+	}
+	return expected
+}
+
+// HasDayTypes reports whether any of the given dates is marked with a day_type.
+func HasDayTypes(workData model.WorkData, dates []string) bool {
+	for _, date := range dates {
+		if workData[date].DayType != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteCSV writes one row per date with columns date, entries, and hours, followed by a total row.
+func WriteCSV(out io.Writer, workData model.WorkData, dates []string, totals map[string]time.Duration) error {
+	buckets := make([]Bucket, 0, len(dates))
+	for _, date := range dates {
+		buckets = append(buckets, Bucket{Label: date, Entries: len(workData[date].WorkLogEntries), Duration: totals[date]})
+	}
+	return writeBucketsCSV(out, "date", buckets)
+}
+
+// WriteGroupedCSV writes one row per bucket with columns named after the grouping,
+// entries, and hours, followed by a total row.
+func WriteGroupedCSV(out io.Writer, groupBy string, buckets []Bucket) error {
+	return writeBucketsCSV(out, groupBy, buckets)
+}
+
+func writeBucketsCSV(out io.Writer, labelColumn string, buckets []Bucket) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{labelColumn, "entries", "hours"}); err != nil {
+		return err
+	}
+
+	totalEntries := 0
+	var totalDuration time.Duration
+	for _, bucket := range buckets {
+		totalEntries += bucket.Entries
+		totalDuration += bucket.Duration
+		if err := w.Write([]string{bucket.Label, strconv.Itoa(bucket.Entries), FormatHours(bucket.Duration)}); err != nil {
+			return err
+		}
+	}
+	if err := w.Write([]string{"total", strconv.Itoa(totalEntries), FormatHours(totalDuration)}); err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// FormatHours formats a duration as decimal hours rounded half away from zero to
+// two decimal places, as every hours output format shows them.
+func FormatHours(d time.Duration) string {
+	return fmt.Sprintf("%.2f", roundHours(d))
+}
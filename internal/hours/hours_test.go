@@ -0,0 +1,334 @@
+package hours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestGroupTotals(t *testing.T) {
+	entry := []model.WorkLog{{StartTime: "09:00", EndTime: "10:00"}}
+	workData := model.WorkData{
+		"2024-07-31": {WorkLogEntries: entry}, // Wednesday, ISO week 31
+		"2024-08-01": {WorkLogEntries: entry}, // Thursday, ISO week 31
+		"2024-08-02": {Tasks: []model.Task{{Description: "no time logged"}}},
+		"2024-08-05": {WorkLogEntries: append(entry, entry...)}, // Monday, ISO week 32
+	}
+	dates := []string{"2024-07-31", "2024-08-01", "2024-08-02", "2024-08-05"}
+	totals := DailyTotals(workData, dates, Options{})
+
+	tests := []struct {
+		name      string
+		groupBy   string
+		weekStart time.Weekday
+		expected  []Bucket
+	}{
+		{
+			name:    "day",
+			groupBy: GroupByDay,
+			expected: []Bucket{
+				{Label: "2024-07-31", Entries: 1, Duration: time.Hour},
+				{Label: "2024-08-01", Entries: 1, Duration: time.Hour},
+				{Label: "2024-08-05", Entries: 2, Duration: 2 * time.Hour},
+			},
+		},
+		{
+			name:      "week starting monday",
+			groupBy:   GroupByWeek,
+			weekStart: time.Monday,
+			expected: []Bucket{
+				{Label: "2024-W31", Entries: 2, Duration: 2 * time.Hour},
+				{Label: "2024-W32", Entries: 2, Duration: 2 * time.Hour},
+			},
+		},
+		{
+			name:      "week starting sunday",
+			groupBy:   GroupByWeek,
+			weekStart: time.Sunday,
+			expected: []Bucket{
+				{Label: "week of 2024-07-28", Entries: 2, Duration: 2 * time.Hour},
+				{Label: "week of 2024-08-04", Entries: 2, Duration: 2 * time.Hour},
+			},
+		},
+		{
+			name:    "month",
+			groupBy: GroupByMonth,
+			expected: []Bucket{
+				{Label: "2024-07", Entries: 1, Duration: time.Hour},
+				{Label: "2024-08", Entries: 3, Duration: 3 * time.Hour},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets, err := GroupTotals(workData, dates, totals, tt.groupBy, tt.weekStart)
+			if err != nil {
+				t.Fatalf("GroupTotals returned error: %v", err)
+			}
+			if len(buckets) != len(tt.expected) {
+				t.Fatalf("Expected %d buckets, got %d: %+v", len(tt.expected), len(buckets), buckets)
+			}
+			for i, want := range tt.expected {
+				if buckets[i] != want {
+					t.Errorf("Bucket %d: expected %+v, got %+v", i, want, buckets[i])
+				}
+			}
+		})
+	}
+
+	t.Run("unsupported grouping", func(t *testing.T) {
+		if _, err := GroupTotals(workData, dates, totals, "year", time.Monday); err == nil {
+			t.Error("Expected an error for an unsupported grouping")
+		}
+	})
+}
+
+func TestDropShortEntries(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00", EndTime: "09:02"},
+			{StartTime: "09:30", EndTime: "12:00"},
+			{StartTime: "lunch", EndTime: "13:00"},
+		}},
+		"2024-08-02": {WorkLogEntries: []model.WorkLog{{StartTime: "10:00", EndTime: "10:01"}}},
+	}
+	dates := []string{"2024-08-01"}
+
+	filtered := DropShortEntries(workData, dates, Options{MinDuration: 5 * time.Minute})
+	entries := filtered["2024-08-01"].WorkLogEntries
+	if len(entries) != 2 || entries[0].StartTime != "09:30" || entries[1].StartTime != "lunch" {
+		t.Errorf("Expected the 2-minute entry to be dropped and the invalid entry kept, got %+v", entries)
+	}
+	if got := DailyTotals(filtered, dates, Options{})["2024-08-01"]; got != 150*time.Minute {
+		t.Errorf("Expected 2h30m after filtering, got %v", got)
+	}
+	if len(filtered["2024-08-02"].WorkLogEntries) != 1 {
+		t.Error("Expected dates outside the range to be left alone")
+	}
+	if len(workData["2024-08-01"].WorkLogEntries) != 3 {
+		t.Error("Expected the input work data to be unchanged")
+	}
+
+	if unfiltered := DropShortEntries(workData, dates, Options{}); len(unfiltered["2024-08-01"].WorkLogEntries) != 3 {
+		t.Error("Expected no filtering without a minimum duration")
+	}
+}
+
+func TestTicketTotals(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00", EndTime: "11:00", Ticket: "PROJ-2"},
+			{StartTime: "11:00", EndTime: "12:00"},
+			{StartTime: "13:00", EndTime: "14:30", Ticket: "PROJ-1"},
+		}},
+		"2024-08-02": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00", EndTime: "10:00", Ticket: "PROJ-2"},
+			{StartTime: "bad", EndTime: "10:00", Ticket: "PROJ-3"},
+		}},
+	}
+
+	buckets := TicketTotals(workData, []string{"2024-08-01", "2024-08-02"}, Options{})
+
+	expected := []Bucket{
+		{Label: "PROJ-1", Entries: 1, Duration: 90 * time.Minute},
+		{Label: "PROJ-2", Entries: 2, Duration: 3 * time.Hour},
+		{Label: UnassignedTicket, Entries: 1, Duration: time.Hour},
+	}
+	if len(buckets) != len(expected) {
+		t.Fatalf("Expected %d buckets, got %d: %+v", len(expected), len(buckets), buckets)
+	}
+	for i, want := range expected {
+		if buckets[i] != want {
+			t.Errorf("Bucket %d: expected %+v, got %+v", i, want, buckets[i])
+		}
+	}
+}
+
+func TestTicketTotalsMergeOverlaps(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00", EndTime: "11:00", Ticket: "PROJ-1"},
+			{StartTime: "10:00", EndTime: "12:00", Ticket: "PROJ-2"},
+			{StartTime: "10:15", EndTime: "10:45", Ticket: "PROJ-3"},
+		}},
+	}
+	dates := []string{"2024-08-01"}
+
+	tests := []struct {
+		name  string
+		merge bool
+		want  []Bucket
+	}{
+		{name: "double counts by default", want: []Bucket{
+			{Label: "PROJ-1", Entries: 1, Duration: 2 * time.Hour},
+			{Label: "PROJ-2", Entries: 1, Duration: 2 * time.Hour},
+			{Label: "PROJ-3", Entries: 1, Duration: 30 * time.Minute},
+		}},
+		{name: "merged gives shared time to the earlier entry", merge: true, want: []Bucket{
+			{Label: "PROJ-1", Entries: 1, Duration: 2 * time.Hour},
+			{Label: "PROJ-2", Entries: 1, Duration: time.Hour},
+			{Label: "PROJ-3", Entries: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{MergeOverlaps: tt.merge}
+			buckets := TicketTotals(workData, dates, opts)
+			if len(buckets) != len(tt.want) {
+				t.Fatalf("Expected %d buckets, got %d: %+v", len(tt.want), len(buckets), buckets)
+			}
+			var sum time.Duration
+			for i, want := range tt.want {
+				if buckets[i] != want {
+					t.Errorf("Bucket %d: expected %+v, got %+v", i, want, buckets[i])
+				}
+				sum += buckets[i].Duration
+			}
+			if total := DailyTotals(workData, dates, opts)["2024-08-01"]; tt.merge && sum != total {
+				t.Errorf("Merged ticket totals sum to %v, want the daily total %v", sum, total)
+			}
+		})
+	}
+}
+
+func TestDailyTotalsWithLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	workData := model.WorkData{
+		// Clocks sprang forward from 02:00 to 03:00 on this date
+		"2024-03-10": {WorkLogEntries: []model.WorkLog{{StartTime: "01:00", EndTime: "04:00"}}},
+		// Clocks fell back from 02:00 to 01:00 on the night into this date
+		"2024-11-02": {WorkLogEntries: []model.WorkLog{{StartTime: "22:00", EndTime: "02:00", NextDay: true}}},
+	}
+	dates := []string{"2024-03-10", "2024-11-02"}
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want map[string]time.Duration
+	}{
+		{name: "zone-less", want: map[string]time.Duration{"2024-03-10": 3 * time.Hour, "2024-11-02": 4 * time.Hour}},
+		{name: "UTC", loc: time.UTC, want: map[string]time.Duration{"2024-03-10": 3 * time.Hour, "2024-11-02": 4 * time.Hour}},
+		{name: "America/New_York", loc: newYork, want: map[string]time.Duration{"2024-03-10": 2 * time.Hour, "2024-11-02": 5 * time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals := DailyTotals(workData, dates, Options{Location: tt.loc})
+			for _, date := range dates {
+				if totals[date] != tt.want[date] {
+					t.Errorf("%s: got %v, want %v", date, totals[date], tt.want[date])
+				}
+			}
+
+			buckets := TicketTotals(workData, dates, Options{Location: tt.loc})
+			if want := tt.want["2024-03-10"] + tt.want["2024-11-02"]; len(buckets) != 1 || buckets[0].Duration != want {
+				t.Errorf("TicketTotals: got %+v, want one bucket of %v", buckets, want)
+			}
+		})
+	}
+}
+
+func TestDailyTotalsFlexibleTimes(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00:00", EndTime: "10:30:00"},
+			{StartTime: "1:00 PM", EndTime: "2:00PM"},
+		}},
+	}
+
+	totals := DailyTotals(workData, []string{"2024-08-01"}, Options{})
+	if want := 2*time.Hour + 30*time.Minute; totals["2024-08-01"] != want {
+		t.Errorf("Got %v, want %v", totals["2024-08-01"], want)
+	}
+}
+
+func TestRoundDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		to   time.Duration
+		mode string
+		want time.Duration
+	}{
+		{"no rounding", 37 * time.Minute, 0, RoundUp, 37 * time.Minute},
+		{"negative rounding", 37 * time.Minute, -time.Minute, RoundUp, 37 * time.Minute},
+		{"unknown mode", 37 * time.Minute, 15 * time.Minute, "sideways", 37 * time.Minute},
+		{"up", 37 * time.Minute, 15 * time.Minute, RoundUp, 45 * time.Minute},
+		{"up by a minute", 31 * time.Minute, 15 * time.Minute, RoundUp, 45 * time.Minute},
+		{"up exact", 45 * time.Minute, 15 * time.Minute, RoundUp, 45 * time.Minute},
+		{"up from zero", 0, 15 * time.Minute, RoundUp, 0},
+		{"up to an hour", 61 * time.Minute, time.Hour, RoundUp, 2 * time.Hour},
+		{"down", 44 * time.Minute, 15 * time.Minute, RoundDown, 30 * time.Minute},
+		{"down exact", 30 * time.Minute, 30 * time.Minute, RoundDown, 30 * time.Minute},
+		{"down below one unit", 10 * time.Minute, 15 * time.Minute, RoundDown, 0},
+		{"nearest below half", 37 * time.Minute, 15 * time.Minute, RoundNearest, 30 * time.Minute},
+		{"nearest above half", 38 * time.Minute, 15 * time.Minute, RoundNearest, 45 * time.Minute},
+		{"nearest half rounds up", 45 * time.Minute, 30 * time.Minute, RoundNearest, time.Hour},
+		{"nearest hour", 150 * time.Minute, time.Hour, RoundNearest, 3 * time.Hour},
+		{"seconds", 7*time.Minute + 30*time.Second, 15 * time.Minute, RoundNearest, 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundDuration(tt.d, tt.to, tt.mode); got != tt.want {
+				t.Errorf("roundDuration(%s, %s, %q) = %s, want %s", tt.d, tt.to, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundingPerEntry(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00", EndTime: "09:20", Ticket: "PROJ-1"},
+			{StartTime: "10:00", EndTime: "10:05", Ticket: "PROJ-1"},
+			{StartTime: "11:00", EndTime: "11:50", Ticket: "PROJ-2"},
+		}},
+	}
+	dates := []string{"2024-08-01"}
+	opts := Options{Rounding: 15 * time.Minute, RoundMode: RoundUp}
+
+	// Each entry is rounded on its own: 30m + 15m + 60m, not 75m rounded to 90m
+	if got := DailyTotals(workData, dates, opts)["2024-08-01"]; got != 105*time.Minute {
+		t.Errorf("Expected a rounded daily total of 1h45m, got %s", got)
+	}
+
+	buckets := TicketTotals(workData, dates, opts)
+	want := []Bucket{{Label: "PROJ-1", Entries: 2, Duration: 45 * time.Minute}, {Label: "PROJ-2", Entries: 1, Duration: time.Hour}}
+	if len(buckets) != len(want) || buckets[0] != want[0] || buckets[1] != want[1] {
+		t.Errorf("Expected rounded ticket totals %+v, got %+v", want, buckets)
+	}
+
+	grouped, err := GroupTotals(workData, dates, DailyTotals(workData, dates, opts), GroupByDay, time.Monday)
+	if err != nil || len(grouped) != 1 || grouped[0].Duration != 105*time.Minute {
+		t.Errorf("Expected grouped totals to use the rounded daily totals, got %+v (%v)", grouped, err)
+	}
+}
+
+func TestExpected(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-05": {WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "17:00"}}},
+		"2024-08-06": {DayType: model.DayTypeWork},
+		"2024-08-07": {DayType: model.DayTypePTO},
+		"2024-08-08": {DayType: model.DayTypeHoliday},
+		"2024-08-09": {DayType: model.DayTypeHalf},
+	}
+	dates := []string{"2024-08-05", "2024-08-06", "2024-08-07", "2024-08-08", "2024-08-09"}
+
+	if got, want := Expected(workData, dates, 8*time.Hour), 20*time.Hour; got != want {
+		t.Errorf("Expected() = %v, want %v", got, want)
+	}
+	if got, want := Expected(workData, dates, 7*time.Hour+30*time.Minute), 18*time.Hour+45*time.Minute; got != want {
+		t.Errorf("Expected() with a 7.5h day = %v, want %v", got, want)
+	}
+	if !HasDayTypes(workData, dates) {
+		t.Error("HasDayTypes() = false, want true")
+	}
+	if HasDayTypes(workData, dates[:1]) {
+		t.Error("HasDayTypes() = true for a day without day_type, want false")
+	}
+}
@@ -0,0 +1,66 @@
+package hours
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// jsonReport is the document written by MarshalJSON.
+type jsonReport struct {
+	TotalHours float64   `json:"total_hours"`
+	Days       []jsonDay `json:"days"`
+	Warnings   []string  `json:"warnings"`
+}
+
+// jsonDay holds one date's total and the work log entries behind it.
+type jsonDay struct {
+	Date    string      `json:"date"`
+	Hours   float64     `json:"hours"`
+	Entries []jsonEntry `json:"entries"`
+}
+
+// jsonEntry is a single parsed work log entry.
+type jsonEntry struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Hours float64 `json:"hours"`
+}
+
+// MarshalJSON encodes the daily totals as an indented JSON document with the
+// overall total, one object per date listing its entries, and a warning for each
+// entry whose times could not be parsed. Day and total hours come from totals, as
+// computed by DailyTotals, so they match the text and CSV output; all hours are
+// rounded to two decimal places.
+func MarshalJSON(workData model.WorkData, dates []string, totals map[string]time.Duration, opts Options) ([]byte, error) {
+	doc := jsonReport{
+		TotalHours: roundHours(Total(totals, dates)),
+		Days:       make([]jsonDay, 0, len(dates)),
+		Warnings:   []string{},
+	}
+	for _, date := range dates {
+		day := jsonDay{Date: date, Hours: roundHours(totals[date]), Entries: []jsonEntry{}}
+
+		intervals, invalid := parseIntervals(date, workData[date].WorkLogEntries, opts.Location)
+		for _, iv := range intervals {
+			day.Entries = append(day.Entries, jsonEntry{
+				Start: iv.entry.StartTime,
+				End:   iv.entry.EndTime,
+				Hours: roundHours(opts.entryDuration(iv)),
+			})
+		}
+		for _, logEntry := range invalid {
+			doc.Warnings = append(doc.Warnings, fmt.Sprintf("%s: invalid time entry %q to %q, skipped", date, logEntry.StartTime, logEntry.EndTime))
+		}
+		doc.Days = append(doc.Days, day)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// roundHours converts a duration to decimal hours rounded to two places, matching FormatHours.
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
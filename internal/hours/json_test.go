@@ -0,0 +1,48 @@
+package hours
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestMarshalJSON(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "09:00", EndTime: "12:20"},
+			{StartTime: "13:00", EndTime: "17:10"},
+		}},
+		"2024-08-02": {WorkLogEntries: []model.WorkLog{
+			{StartTime: "22:00", EndTime: "01:15", NextDay: true},
+			{StartTime: "9am", EndTime: "10:00"},
+		}},
+	}
+	dates := []string{"2024-08-01", "2024-08-02", "2024-08-03"}
+	totals := DailyTotals(workData, dates, Options{})
+
+	got, err := MarshalJSON(workData, dates, totals, Options{})
+	if err != nil {
+		t.Fatalf("MarshalJSON returned error: %v", err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "hours.golden.json")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("MarshalJSON output does not match %s:\n%s", golden, got)
+	}
+}
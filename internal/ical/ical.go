@@ -0,0 +1,160 @@
+// Package ical exports work log entries as an iCalendar (RFC 5545) file.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding.
+const maxLineOctets = 75
+
+// Layouts for iCalendar DATE-TIME values.
+const (
+	floatingLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+)
+
+// Write writes one VEVENT per work log entry on the given dates. Without a
+// location, event times are "floating" local times exactly as logged; with one,
+// they are converted to UTC from that zone. Entries marked next_day end on the
+// following day, and entries with unparseable times are skipped with a warning.
+// stamp is used as every event's DTSTAMP.
+func Write(out io.Writer, workData model.WorkData, dates []string, loc *time.Location, stamp time.Time) error {
+	sortedDates := append([]string(nil), dates...)
+	sort.Strings(sortedDates)
+
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//taskledger//work log//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+
+	for _, date := range sortedDates {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			slog.Warn("invalid date, skipping calendar events", "date", date)
+			continue
+		}
+
+		dailyLog := workData[date]
+		for i, entry := range dailyLog.WorkLogEntries {
+			start, end, err := entryTimes(day, entry, loc)
+			if err != nil {
+				slog.Warn("invalid time entry, skipping", "date", date, "entry", entry)
+				continue
+			}
+
+			writeLine(&b, "BEGIN:VEVENT")
+			writeLine(&b, fmt.Sprintf("UID:%s-%d@taskledger", date, i))
+			writeLine(&b, "DTSTAMP:"+stamp.UTC().Format(utcLayout))
+			writeLine(&b, "DTSTART:"+formatDateTime(start, loc))
+			writeLine(&b, "DTEND:"+formatDateTime(end, loc))
+			writeLine(&b, "SUMMARY:"+escapeText(eventSummary(entry, dailyLog.Tasks)))
+			writeLine(&b, "END:VEVENT")
+		}
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// entryTimes returns the start and end of a work log entry on the given day.
+func entryTimes(day time.Time, entry model.WorkLog, loc *time.Location) (time.Time, time.Time, error) {
+	startClock, err := model.ParseWorkTime(entry.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endClock, err := model.ParseWorkTime(entry.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	zone := loc
+	if zone == nil {
+		zone = time.UTC
+	}
+	endDay := day
+	if entry.NextDay {
+		endDay = day.AddDate(0, 0, 1)
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), startClock.Hour(), startClock.Minute(), startClock.Second(), 0, zone)
+	end := time.Date(endDay.Year(), endDay.Month(), endDay.Day(), endClock.Hour(), endClock.Minute(), endClock.Second(), 0, zone)
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end time %s is before start time %s", entry.EndTime, entry.StartTime)
+	}
+	return start, end, nil
+}
+
+// formatDateTime formats t as a floating time when no location is set, or in UTC otherwise.
+func formatDateTime(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		return t.Format(floatingLayout)
+	}
+	return t.UTC().Format(utcLayout)
+}
+
+// eventSummary summarizes a work block from the day's task descriptions. When the
+// entry names a ticket, only that ticket's tasks are used if any match.
+func eventSummary(entry model.WorkLog, tasks []model.Task) string {
+	ticket := strings.TrimSpace(entry.Ticket)
+
+	var descriptions []string
+	for _, task := range tasks {
+		if ticket == "" || strings.EqualFold(strings.TrimSpace(task.JiraTicket), ticket) {
+			descriptions = append(descriptions, task.GetDescriptions()...)
+		}
+	}
+	if len(descriptions) == 0 && ticket != "" {
+		return ticket
+	}
+	if len(descriptions) == 0 {
+		return "Work"
+	}
+
+	summary := strings.Join(descriptions, "; ")
+	if ticket != "" {
+		summary = ticket + ": " + summary
+	}
+	return summary
+}
+
+// textEscaper escapes TEXT property values per RFC 5545 section 3.3.11.
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeText escapes a value for use in a TEXT property.
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// writeLine writes a content line terminated by CRLF, folding it so that no
+// line exceeds 75 octets. Continuation lines start with a single space, and
+// lines are never split inside a multi-byte UTF-8 character.
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts toward its length
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// isRuneStart reports whether c can begin a UTF-8 encoded character.
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
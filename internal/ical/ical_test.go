@@ -0,0 +1,107 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// expectedCalendar is a known-good export of the work log in TestWrite, with
+// LF line endings for readability.
+const expectedCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//taskledger//work log//EN
+CALSCALE:GREGORIAN
+BEGIN:VEVENT
+UID:2024-08-01-0@taskledger
+DTSTAMP:20240805T120000Z
+DTSTART:20240801T090000
+DTEND:20240801T123000
+SUMMARY:Fix login\, logout\; and \\ paths\nsecond line\; Cut the releases\;
+  Review PR
+END:VEVENT
+BEGIN:VEVENT
+UID:2024-08-01-1@taskledger
+DTSTAMP:20240805T120000Z
+DTSTART:20240801T220000
+DTEND:20240802T013000
+SUMMARY:PROJ-2: Cut the releases
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestWrite(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {
+			Tasks: []model.Task{
+				{JiraTicket: "PROJ-1", Description: "Fix login, logout; and \\ paths\nsecond line"},
+				{JiraTicket: "PROJ-2", Descriptions: []string{"Cut the releases"}},
+				{Description: "Review PR"},
+			},
+			WorkLogEntries: []model.WorkLog{
+				{StartTime: "09:00", EndTime: "12:30"},
+				{StartTime: "10:00 PM", EndTime: "01:30", NextDay: true, Ticket: "PROJ-2"},
+				{StartTime: "bad", EndTime: "10:00"},
+			},
+		},
+	}
+
+	var b strings.Builder
+	stamp := time.Date(2024, 8, 5, 12, 0, 0, 0, time.UTC)
+	if err := Write(&b, workData, []string{"2024-08-01"}, nil, stamp); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	expected := strings.ReplaceAll(expectedCalendar, "\n", "\r\n")
+	if got := b.String(); got != expected {
+		t.Errorf("Write mismatch\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestWriteWithLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "17:00"}}},
+	}
+
+	var b strings.Builder
+	if err := Write(&b, workData, []string{"2024-08-01"}, loc, time.Time{}); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	for _, want := range []string{"DTSTART:20240801T130000Z\r\n", "DTEND:20240801T210000Z\r\n", "SUMMARY:Work\r\n"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Expected %q in output:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestWriteLineFolding(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "SUMMARY:"+strings.Repeat("é", 80))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("Expected a folded line, got %q", b.String())
+	}
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("Line %d is %d octets, want at most %d", i, len(line), maxLineOctets)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("Continuation line %d does not start with a space: %q", i, line)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if want := "SUMMARY:" + strings.Repeat("é", 80); unfolded.String() != want {
+		t.Errorf("Unfolded line = %q, want %q", unfolded.String(), want)
+	}
+}
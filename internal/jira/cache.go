@@ -0,0 +1,139 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached ticket summaries are considered fresh.
+const DefaultCacheTTL = 24 * time.Hour
+
+// cacheVersion is bumped whenever TicketInfo gains fields, so entries written
+// by older versions, which lack them, are refetched instead of served as fresh.
+// Version 1 added Status and Assignee.
+const cacheVersion = 1
+
+// cacheEntry is a cached ticket along with the time it was fetched and the
+// cacheVersion it was written with.
+type cacheEntry struct {
+	Info      TicketInfo `json:"info"`
+	FetchedAt time.Time  `json:"fetched_at"`
+	Version   int        `json:"version,omitempty"`
+}
+
+// Cache persists fetched ticket info to a JSON file so repeated reports can skip
+// the JIRA API. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	dirty   bool
+	now     func() time.Time
+}
+
+// DefaultCachePath returns the cache file location under the user's cache directory.
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "taskledger", "jira-cache.json"), nil
+}
+
+// OpenCache loads the cache file at path. A missing file yields an empty cache,
+// and an unreadable file is discarded with a warning.
+func OpenCache(path string, ttl time.Duration) (*Cache, error) {
+	cache := &Cache{
+		path:    path,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JIRA cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &cache.entries); err != nil {
+		slog.Warn("ignoring unreadable JIRA cache", "path", path, "error", err)
+		cache.entries = make(map[string]cacheEntry)
+	}
+	return cache, nil
+}
+
+// Get returns the cached info for a ticket if it is younger than the cache TTL
+// and was written with the current cacheVersion.
+func (c *Cache) Get(ticketID string) (TicketInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[cacheKey(ticketID)]
+	if !exists || entry.Version != cacheVersion || c.now().Sub(entry.FetchedAt) >= c.ttl {
+		return TicketInfo{}, false
+	}
+	return entry.Info, true
+}
+
+// Put stores freshly fetched ticket info in the cache.
+func (c *Cache) Put(info TicketInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[cacheKey(info.Key)] = cacheEntry{Info: info, FetchedAt: c.now(), Version: cacheVersion}
+	c.dirty = true
+}
+
+// Save writes the cache to disk if it has changed. The file is replaced atomically
+// so a failed write never leaves a truncated cache behind.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JIRA cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create JIRA cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".jira-cache-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write JIRA cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write JIRA cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write JIRA cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write JIRA cache: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// cacheKey identifies a ticket by its browse URL so entries from different
+// JIRA instances never collide.
+func cacheKey(ticketID string) string {
+	return TicketURL(ticketID)
+}
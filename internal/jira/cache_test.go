@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTripAndExpiry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "jira-cache.json")
+
+	cache, err := OpenCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenCache failed: %v", err)
+	}
+	fetchedAt := time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return fetchedAt }
+	cache.Put(TicketInfo{Key: "PROJ-1", Summary: "Cached summary", URL: TicketURL("PROJ-1")})
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reopened, err := OpenCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenCache failed: %v", err)
+	}
+
+	reopened.now = func() time.Time { return fetchedAt.Add(30 * time.Minute) }
+	if info, ok := reopened.Get("PROJ-1"); !ok || info.Summary != "Cached summary" {
+		t.Errorf("Expected fresh cache hit, got %+v (ok=%v)", info, ok)
+	}
+
+	reopened.now = func() time.Time { return fetchedAt.Add(2 * time.Hour) }
+	if _, ok := reopened.Get("PROJ-1"); ok {
+		t.Error("Expected expired entry to be ignored")
+	}
+	if _, ok := reopened.Get("PROJ-2"); ok {
+		t.Error("Expected miss for unknown ticket")
+	}
+}
+
+func TestCacheIgnoresOlderVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira-cache.json")
+	fetchedAt := time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC)
+	old := fmt.Sprintf(`{%q: {"info": {"Key": "PROJ-1", "Summary": "Old summary"}, "fetched_at": %q}}`, TicketURL("PROJ-1"), fetchedAt.Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := OpenCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("OpenCache failed: %v", err)
+	}
+	cache.now = func() time.Time { return fetchedAt.Add(time.Minute) }
+	if info, ok := cache.Get("PROJ-1"); ok {
+		t.Errorf("Expected an entry without Status and Assignee to be refetched, got %+v", info)
+	}
+}
+
+func TestFetchTicketSummaryUsesCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"key": "PROJ-1", "fields": {"summary": "From API"}}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("JIRA_PAT", "test-token")
+	if err := SetBaseURL(server.URL); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+	cache, err := OpenCache(filepath.Join(t.TempDir(), "jira-cache.json"), time.Hour)
+	if err != nil {
+		t.Fatalf("OpenCache failed: %v", err)
+	}
+	t.Cleanup(func() { SetBaseURL(DefaultBaseURL) })
+	client := NewClientFromEnv()
+	client.Cache = cache
+
+	for i := 0; i < 3; i++ {
+		info, err := client.FetchTicketSummary("PROJ-1")
+		if err != nil {
+			t.Fatalf("FetchTicketSummary failed: %v", err)
+		}
+		if info.Summary != "From API" {
+			t.Errorf("Unexpected summary: %q", info.Summary)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("Expected 1 API request with caching, got %d", got)
+	}
+}
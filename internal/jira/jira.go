@@ -5,23 +5,59 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
 	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/bryan-cox/taskledger/internal/markup"
 	"github.com/bryan-cox/taskledger/internal/model"
+	"github.com/bryan-cox/taskledger/internal/workerpool"
 )
 
-// BaseURL is the JIRA instance base URL.
-const BaseURL = "https://issues.redhat.com"
+// DefaultBaseURL is the JIRA instance base URL used when none is configured.
+const DefaultBaseURL = "https://issues.redhat.com"
 
-// TicketInfo holds information about a JIRA ticket.
+// BaseURL is the JIRA instance base URL. Use SetBaseURL to change it.
+var BaseURL = DefaultBaseURL
+
+// DefaultTimeout is the default timeout for a single JIRA API request.
+const DefaultTimeout = 10 * time.Second
+
+// maxIdleConnsPerHost keeps enough idle connections open for the fetch workers
+// to reuse rather than reconnecting for each ticket.
+const maxIdleConnsPerHost = 16
+
+// HTTPClient is shared by all JIRA API requests so keep-alive connections are
+// reused across fetches. Its Timeout may be changed before fetching.
+var HTTPClient = &http.Client{Timeout: DefaultTimeout, Transport: newTransport()}
+
+// newTransport returns a copy of the default transport that keeps more idle
+// connections per host than the default of two.
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	return transport
+}
+
+// TicketInfo holds information about a JIRA ticket. Fields holds the values of
+// the extra fields requested through Client.Fields, keyed by field ID; fields the
+// ticket doesn't have are left out.
 type TicketInfo struct {
-	Key     string
-	Summary string
-	URL     string
+	Key      string
+	Summary  string
+	URL      string
+	Status   string
+	Assignee string
+	Fields   map[string]string `json:",omitempty"`
 }
 
 // apiResponse represents the response from JIRA API.
@@ -29,19 +65,110 @@ type apiResponse struct {
 	Key    string `json:"key"`
 	Fields struct {
 		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		// Assignee is null for unassigned tickets
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
 	} `json:"fields"`
 }
 
+// fieldsResponse holds the raw values of every field in a JIRA API response.
+type fieldsResponse struct {
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
 // Regex patterns for extracting JIRA ticket IDs.
 var (
 	ticketRegex = regexp.MustCompile(`\b([A-Z]+-\d+)\b`)
-	urlRegex    = regexp.MustCompile(`https://issues\.redhat\.com/browse/([A-Z]+-\d+)`)
+
+	// urlRegex matches browse URLs on the configured JIRA host. It is rebuilt
+	// whenever BaseURL changes.
+	urlRegexMu   sync.Mutex
+	urlRegex     *regexp.Regexp
+	urlRegexBase string
 )
 
+// SetBaseURL configures the JIRA instance base URL used for links, API requests,
+// and ticket extraction from browse URLs.
+func SetBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid JIRA base URL %q: must be an absolute URL like %s", baseURL, DefaultBaseURL)
+	}
+	BaseURL = strings.TrimRight(baseURL, "/")
+	return nil
+}
+
+// Instance is a JIRA instance that tickets with a given key prefix live on.
+type Instance struct {
+	// BaseURL is the instance base URL, without a trailing slash.
+	BaseURL string
+	// Token is the instance's personal access token. Without one, its tickets
+	// get links but no summaries; the default token is never sent to it.
+	Token string
+}
+
+// Instances maps ticket key prefixes, such as "OPS" for OPS-123, to the JIRA
+// instance their tickets live on. Tickets with other prefixes use BaseURL. Use
+// SetInstances to change it.
+var Instances map[string]Instance
+
+// SetInstances configures the JIRA instances used for tickets by key prefix.
+// Prefixes are case-insensitive and may end in a dash ("OPS-").
+func SetInstances(instances map[string]Instance) error {
+	normalized := make(map[string]Instance, len(instances))
+	for prefix, instance := range instances {
+		u, err := url.Parse(instance.BaseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid JIRA base URL %q for prefix %s: must be an absolute URL like %s", instance.BaseURL, prefix, DefaultBaseURL)
+		}
+		instance.BaseURL = strings.TrimRight(instance.BaseURL, "/")
+		normalized[strings.TrimSuffix(strings.ToUpper(prefix), "-")] = instance
+	}
+	Instances = normalized
+	return nil
+}
+
+// instanceFor returns the instance mapped to the prefix of ticketID in instances.
+func instanceFor(instances map[string]Instance, ticketID string) (Instance, bool) {
+	prefix, _, found := strings.Cut(ticketID, "-")
+	if !found {
+		return Instance{}, false
+	}
+	instance, ok := instances[prefix]
+	return instance, ok
+}
+
+// TicketURL returns the browse URL for a ticket ID on the JIRA instance mapped
+// to its prefix, or on the configured BaseURL.
+func TicketURL(ticketID string) string {
+	if instance, ok := instanceFor(Instances, ticketID); ok {
+		return fmt.Sprintf("%s/browse/%s", instance.BaseURL, ticketID)
+	}
+	return fmt.Sprintf("%s/browse/%s", BaseURL, ticketID)
+}
+
+// browseURLRegex returns a regex matching browse URLs on the configured JIRA host,
+// rebuilding it if BaseURL has changed since it was last compiled.
+func browseURLRegex() *regexp.Regexp {
+	urlRegexMu.Lock()
+	defer urlRegexMu.Unlock()
+
+	if urlRegex == nil || urlRegexBase != BaseURL {
+		hostAndPath := strings.TrimPrefix(strings.TrimPrefix(BaseURL, "https://"), "http://")
+		urlRegex = regexp.MustCompile(`https?://` + regexp.QuoteMeta(hostAndPath) + `/browse/([A-Z]+-\d+)`)
+		urlRegexBase = BaseURL
+	}
+	return urlRegex
+}
+
 // ExtractTicketID extracts a JIRA ticket ID from a URL or text.
 func ExtractTicketID(input string) string {
 	// First try to extract from URL
-	if matches := urlRegex.FindStringSubmatch(input); len(matches) > 1 {
+	if matches := browseURLRegex().FindStringSubmatch(input); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -53,22 +180,138 @@ func ExtractTicketID(input string) string {
 	return ""
 }
 
+// Client fetches ticket information from a default JIRA instance and from the
+// instances mapped to ticket prefixes.
+type Client struct {
+	// BaseURL is the JIRA instance base URL, without a trailing slash.
+	BaseURL string
+	// Token is the personal access token. Without one, tickets are returned
+	// with links but no summaries and no requests are made.
+	Token string
+	// Instances maps ticket prefixes to other JIRA instances, which take
+	// precedence over BaseURL and Token for their tickets.
+	Instances map[string]Instance
+	// HTTPClient performs the API requests.
+	HTTPClient *http.Client
+	// Concurrency is the maximum number of tickets ProcessTickets fetches in parallel.
+	Concurrency int
+	// Fields lists extra field IDs to fetch into TicketInfo.Fields.
+	Fields []string
+	// Cache, when set, is consulted before calling the API and updated after.
+	Cache *Cache
+}
+
+// TokenFile and TokenCommand are where ResolveToken looks for the personal access
+// token before the JIRA_PAT environment variable: a file holding the token, and
+// a command that prints it, like a git credential helper.
+var (
+	TokenFile    string
+	TokenCommand string
+)
+
+// ResolveToken returns the personal access token from TokenFile, then the output
+// of TokenCommand, then the JIRA_PAT environment variable, trimmed of whitespace.
+// It returns "" when none is configured, and an error when the configured file
+// or command fails or yields an empty token.
+func ResolveToken() (string, error) {
+	switch {
+	case TokenFile != "":
+		data, err := os.ReadFile(TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read JIRA token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("JIRA token file %s is empty", TokenFile)
+		}
+		return token, nil
+	case TokenCommand != "":
+		cmd := exec.Command("sh", "-c", TokenCommand)
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/c", TokenCommand)
+		}
+		// Let the helper prompt or report problems on the terminal
+		cmd.Stderr = os.Stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("JIRA token command failed: %w", err)
+		}
+		token := strings.TrimSpace(string(output))
+		if token == "" {
+			return "", fmt.Errorf("JIRA token command printed no token")
+		}
+		return token, nil
+	default:
+		return strings.TrimSpace(os.Getenv("JIRA_PAT")), nil
+	}
+}
+
+// NewClientFromEnv returns a client for the configured BaseURL and Instances using
+// the token from ResolveToken, the shared HTTPClient, and DefaultConcurrency,
+// without extra fields or a cache.
+// A token that cannot be resolved is logged, and the client fetches without one.
+func NewClientFromEnv() *Client {
+	token, err := ResolveToken()
+	if err != nil {
+		slog.Warn("JIRA summaries disabled", "error", err)
+	}
+	return &Client{
+		BaseURL:     BaseURL,
+		Token:       token,
+		Instances:   Instances,
+		HTTPClient:  HTTPClient,
+		Concurrency: DefaultConcurrency,
+	}
+}
+
+// instance returns the base URL and token for a ticket ID: those of the instance
+// mapped to its prefix, or the client's own.
+func (c *Client) instance(ticketID string) (string, string) {
+	if instance, ok := instanceFor(c.Instances, ticketID); ok {
+		return instance.BaseURL, instance.Token
+	}
+	return c.BaseURL, c.Token
+}
+
+// ticketURL returns the browse URL for a ticket ID on the client's JIRA instance
+// for it.
+func (c *Client) ticketURL(ticketID string) string {
+	baseURL, _ := c.instance(ticketID)
+	return fmt.Sprintf("%s/browse/%s", baseURL, ticketID)
+}
+
 // FetchTicketSummary fetches the summary of a JIRA ticket using the API.
+// It is a wrapper around Client.FetchTicketSummary using NewClientFromEnv.
 func FetchTicketSummary(ticketID string) (TicketInfo, error) {
+	return NewClientFromEnv().FetchTicketSummary(ticketID)
+}
+
+// FetchTicketSummary fetches the summary of a JIRA ticket using the API.
+func (c *Client) FetchTicketSummary(ticketID string) (TicketInfo, error) {
+	baseURL, token := c.instance(ticketID)
 	ticket := TicketInfo{
 		Key: ticketID,
-		URL: fmt.Sprintf("%s/browse/%s", BaseURL, ticketID),
+		URL: fmt.Sprintf("%s/browse/%s", baseURL, ticketID),
 	}
 
-	// Check if JIRA Personal Access Token is available
-	jiraPAT := os.Getenv("JIRA_PAT")
-	if jiraPAT == "" {
-		// Return ticket info without summary if no PAT is available
+	if token == "" {
+		// Return ticket info without summary if no token is available
 		return ticket, nil
 	}
 
+	// Skip the network call when a fresh cached copy has every requested field
+	if c.Cache != nil {
+		if cached, ok := c.Cache.Get(ticketID); ok && hasFields(cached, c.Fields) {
+			return cached, nil
+		}
+	}
+
 	// Make API request to fetch ticket summary
-	apiURL := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary", BaseURL, ticketID)
+	fields := "summary,status,assignee"
+	for _, field := range c.Fields {
+		fields += "," + url.QueryEscape(field)
+	}
+	apiURL := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=%s", baseURL, ticketID, fields)
 
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -76,11 +319,14 @@ func FetchTicketSummary(ticketID string) (TicketInfo, error) {
 	}
 
 	// Set authorization header
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jiraPAT))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = HTTPClient
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return ticket, fmt.Errorf("failed to fetch ticket: %w", err)
 	}
@@ -90,45 +336,129 @@ func FetchTicketSummary(ticketID string) (TicketInfo, error) {
 		return ticket, fmt.Errorf("JIRA API returned status %d", resp.StatusCode)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ticket, fmt.Errorf("failed to read response: %w", err)
+	}
 	var jiraResp apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&jiraResp); err != nil {
+	if err := json.Unmarshal(body, &jiraResp); err != nil {
 		return ticket, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	ticket.Summary = jiraResp.Fields.Summary
+	ticket.Status = jiraResp.Fields.Status.Name
+	if jiraResp.Fields.Assignee != nil {
+		ticket.Assignee = jiraResp.Fields.Assignee.DisplayName
+	}
+	if len(c.Fields) > 0 {
+		var raw fieldsResponse
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return ticket, fmt.Errorf("failed to decode response: %w", err)
+		}
+		ticket.Fields = fieldValues(raw.Fields, c.Fields)
+	}
+	if c.Cache != nil {
+		c.Cache.Put(ticket)
+	}
 	return ticket, nil
 }
 
+// hasFields reports whether info has a value for every field in fields.
+func hasFields(info TicketInfo, fields []string) bool {
+	for _, field := range fields {
+		if _, ok := info.Fields[field]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldValues returns the value of each requested field as a string, leaving out
+// fields that are missing or null. Strings are used as is, option and user
+// objects by their value, name, or displayName, and anything else as its JSON.
+func fieldValues(raw map[string]json.RawMessage, fields []string) map[string]string {
+	values := make(map[string]string)
+	for _, field := range fields {
+		value, ok := raw[field]
+		if !ok || string(value) == "null" {
+			continue
+		}
+
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			values[field] = text
+			continue
+		}
+		var object map[string]any
+		if err := json.Unmarshal(value, &object); err == nil {
+			if name, ok := firstString(object, "value", "name", "displayName"); ok {
+				values[field] = name
+				continue
+			}
+		}
+		values[field] = string(value)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// firstString returns the first of keys whose value in object is a string.
+func firstString(object map[string]any, keys ...string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := object[key].(string); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// DefaultConcurrency is the default number of JIRA tickets fetched in parallel.
+const DefaultConcurrency = 5
+
 // ProcessTickets processes a map of JIRA tickets and fetches their summaries.
+// It is a wrapper around Client.ProcessTickets using NewClientFromEnv.
 func ProcessTickets(tickets map[string][]model.TaskWithDate) map[string]TicketInfo {
-	jiraInfo := make(map[string]TicketInfo)
+	return NewClientFromEnv().ProcessTickets(tickets)
+}
 
+// ProcessTickets processes a map of JIRA tickets and fetches their summaries.
+// Tickets are fetched concurrently by a bounded pool of c.Concurrency workers; the
+// result does not depend on the order in which fetches complete.
+func (c *Client) ProcessTickets(tickets map[string][]model.TaskWithDate) map[string]TicketInfo {
+	// Deduplicate ticket IDs (several references can point to the same ticket)
+	seen := make(map[string]bool)
+	var ticketIDs []string
 	for ticketReference := range tickets {
 		if ticketReference == "" {
 			continue
 		}
 
 		ticketID := ExtractTicketID(ticketReference)
-		if ticketID == "" {
+		if ticketID == "" || seen[ticketID] {
 			continue
 		}
+		seen[ticketID] = true
+		ticketIDs = append(ticketIDs, ticketID)
+	}
+	sort.Strings(ticketIDs)
 
-		if _, exists := jiraInfo[ticketID]; !exists {
-			// Fetch ticket info (will include summary only if JIRA_PAT is available)
-			if info, err := FetchTicketSummary(ticketID); err == nil {
-				jiraInfo[ticketID] = info
-			} else {
-				// If fetch fails, still create basic info
-				jiraInfo[ticketID] = TicketInfo{
-					Key: ticketID,
-					URL: fmt.Sprintf("%s/browse/%s", BaseURL, ticketID),
-				}
-				slog.Warn("failed to fetch JIRA ticket summary", "ticket", ticketID, "error", err)
-			}
+	return workerpool.Map(ticketIDs, c.Concurrency, c.fetchTicketInfo)
+}
+
+// fetchTicketInfo fetches ticket info (with a summary only if a token is available),
+// falling back to basic info with a warning if the fetch fails.
+func (c *Client) fetchTicketInfo(ticketID string) TicketInfo {
+	info, err := c.FetchTicketSummary(ticketID)
+	if err != nil {
+		slog.Warn("failed to fetch JIRA ticket summary", "ticket", ticketID, "error", err)
+		return TicketInfo{
+			Key: ticketID,
+			URL: c.ticketURL(ticketID),
 		}
 	}
-
-	return jiraInfo
+	return info
 }
 
 // LoadSummariesFromFile loads JIRA ticket summaries from a JSON file.
@@ -147,7 +477,7 @@ func LoadSummariesFromFile(filePath string) (map[string]TicketInfo, error) {
 	// Ensure URLs are set for all tickets
 	for key, info := range summaries {
 		if info.URL == "" {
-			info.URL = fmt.Sprintf("%s/browse/%s", BaseURL, info.Key)
+			info.URL = TicketURL(info.Key)
 		}
 		if info.Key == "" {
 			info.Key = key
@@ -159,7 +489,66 @@ func LoadSummariesFromFile(filePath string) (map[string]TicketInfo, error) {
 }
 
 // FormatTicketHTML formats a JIRA ticket reference as HTML with optional summary.
-func FormatTicketHTML(ticketReference string, jiraInfo map[string]TicketInfo) string {
+// With showStatus, the ticket's status and assignee follow the link.
+func FormatTicketHTML(ticketReference string, jiraInfo map[string]TicketInfo, showStatus bool) string {
+	ticketID := ExtractTicketID(ticketReference)
+	if ticketID == "" {
+		// No JIRA ticket found, return escaped original text
+		return html.EscapeString(ticketReference)
+	}
+
+	info, exists := jiraInfo[ticketID]
+	if !exists {
+		// Fallback: create basic link
+		return fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`, html.EscapeString(TicketURL(ticketID)), html.EscapeString(ticketID))
+	}
+
+	// Create link with summary if available
+	linkText := info.Key
+	if info.Summary != "" {
+		linkText = fmt.Sprintf("%s: %s", info.Key, info.Summary)
+	}
+
+	// The URL comes from the JIRA response or configuration, so it is escaped too
+	link := fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`, html.EscapeString(info.URL), html.EscapeString(linkText))
+
+	// Append the status and assignee, e.g. " (In Progress, Jane Doe)"
+	var details []string
+	if showStatus {
+		for _, detail := range []string{info.Status, info.Assignee} {
+			if detail != "" {
+				details = append(details, html.EscapeString(detail))
+			}
+		}
+	}
+	if len(details) > 0 {
+		link = fmt.Sprintf("%s (%s)", link, strings.Join(details, ", "))
+	}
+	return link + formatFieldsHTML(info.Fields)
+}
+
+// formatFieldsHTML formats the extra fields of a ticket as a suffix such as
+// " [customfield_10002: 5; team: Platform]", sorted by field ID.
+func formatFieldsHTML(fields map[string]string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, html.EscapeString(id+": "+fields[id]))
+	}
+	return " [" + strings.Join(parts, "; ") + "]"
+}
+
+// FormatTicketConfluence formats a JIRA ticket reference as an external link for
+// Confluence storage format, with an optional summary.
+func FormatTicketConfluence(ticketReference string, jiraInfo map[string]TicketInfo) string {
 	ticketID := ExtractTicketID(ticketReference)
 	if ticketID == "" {
 		// No JIRA ticket found, return escaped original text
@@ -169,8 +558,76 @@ func FormatTicketHTML(ticketReference string, jiraInfo map[string]TicketInfo) st
 	info, exists := jiraInfo[ticketID]
 	if !exists {
 		// Fallback: create basic link
-		url := fmt.Sprintf("%s/browse/%s", BaseURL, ticketID)
-		return fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`, url, html.EscapeString(ticketID))
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(TicketURL(ticketID)), html.EscapeString(ticketID))
+	}
+
+	// Create link with summary if available
+	linkText := info.Key
+	if info.Summary != "" {
+		linkText = fmt.Sprintf("%s: %s", info.Key, info.Summary)
+	}
+
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(info.URL), html.EscapeString(linkText))
+}
+
+// FormatTicketMarkdown formats a JIRA ticket reference as a Markdown link with optional summary.
+func FormatTicketMarkdown(ticketReference string, jiraInfo map[string]TicketInfo) string {
+	ticketID := ExtractTicketID(ticketReference)
+	if ticketID == "" {
+		// No JIRA ticket found, return original text
+		return ticketReference
+	}
+
+	info, exists := jiraInfo[ticketID]
+	if !exists {
+		// Fallback: create basic link
+		return fmt.Sprintf("[%s](%s)", ticketID, TicketURL(ticketID))
+	}
+
+	// Create link with summary if available
+	linkText := info.Key
+	if info.Summary != "" {
+		linkText = fmt.Sprintf("%s: %s", info.Key, info.Summary)
+	}
+
+	return fmt.Sprintf("[%s](%s)", markup.MarkdownLinkText(linkText), info.URL)
+}
+
+// FormatTicketAsciiDoc formats a JIRA ticket reference as an AsciiDoc link with optional summary.
+func FormatTicketAsciiDoc(ticketReference string, jiraInfo map[string]TicketInfo) string {
+	ticketID := ExtractTicketID(ticketReference)
+	if ticketID == "" {
+		// No JIRA ticket found, return original text
+		return ticketReference
+	}
+
+	info, exists := jiraInfo[ticketID]
+	if !exists {
+		// Fallback: create basic link
+		return fmt.Sprintf("link:%s[%s]", TicketURL(ticketID), ticketID)
+	}
+
+	// Create link with summary if available
+	linkText := info.Key
+	if info.Summary != "" {
+		linkText = fmt.Sprintf("%s: %s", info.Key, info.Summary)
+	}
+
+	return fmt.Sprintf("link:%s[%s]", info.URL, markup.AsciiDocLinkText(linkText))
+}
+
+// FormatTicketSlack formats a JIRA ticket reference as a Slack mrkdwn link with optional summary.
+func FormatTicketSlack(ticketReference string, jiraInfo map[string]TicketInfo) string {
+	ticketID := ExtractTicketID(ticketReference)
+	if ticketID == "" {
+		// No JIRA ticket found, return original text
+		return markup.SlackText(ticketReference)
+	}
+
+	info, exists := jiraInfo[ticketID]
+	if !exists {
+		// Fallback: create basic link
+		return fmt.Sprintf("<%s|%s>", TicketURL(ticketID), ticketID)
 	}
 
 	// Create link with summary if available
@@ -179,5 +636,5 @@ func FormatTicketHTML(ticketReference string, jiraInfo map[string]TicketInfo) st
 		linkText = fmt.Sprintf("%s: %s", info.Key, info.Summary)
 	}
 
-	return fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`, info.URL, html.EscapeString(linkText))
+	return fmt.Sprintf("<%s|%s>", info.URL, markup.SlackText(linkText))
 }
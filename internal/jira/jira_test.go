@@ -0,0 +1,410 @@
+package jira
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestExtractTicketIDWithConfiguredBaseURL(t *testing.T) {
+	t.Cleanup(func() { SetBaseURL(DefaultBaseURL) })
+
+	if err := SetBaseURL("https://jira.example.com/"); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "https://jira.example.com/browse/OPS-7", want: "OPS-7"},
+		{input: "PROJ-1 follow-up to https://jira.example.com/browse/OPS-7", want: "OPS-7"},
+		{input: "PROJ-1 follow-up to https://issues.redhat.com/browse/OPS-7", want: "PROJ-1"},
+		{input: "PROJ-42", want: "PROJ-42"},
+		{input: "NO-JIRA: docs", want: ""},
+	}
+	for _, tt := range tests {
+		if got := ExtractTicketID(tt.input); got != tt.want {
+			t.Errorf("ExtractTicketID(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if got := TicketURL("OPS-7"); got != "https://jira.example.com/browse/OPS-7" {
+		t.Errorf("TicketURL returned %q", got)
+	}
+}
+
+func TestSetBaseURLRejectsRelativeURL(t *testing.T) {
+	t.Cleanup(func() { SetBaseURL(DefaultBaseURL) })
+
+	if err := SetBaseURL("jira.example.com"); err == nil {
+		t.Error("Expected error for base URL without scheme")
+	}
+	if BaseURL != DefaultBaseURL {
+		t.Errorf("BaseURL changed after invalid SetBaseURL: %s", BaseURL)
+	}
+}
+
+func TestProcessTicketsConcurrently(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		if key == "FAIL-1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"key": %q, "fields": {"summary": "Summary of %s"}}`, key, key)
+	}))
+	defer server.Close()
+
+	t.Setenv("JIRA_PAT", "test-token")
+	if err := SetBaseURL(server.URL); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+	t.Cleanup(func() { SetBaseURL(DefaultBaseURL) })
+
+	tickets := make(map[string][]model.TaskWithDate)
+	for i := 1; i <= 10; i++ {
+		tickets[fmt.Sprintf("PROJ-%d", i)] = nil
+	}
+	tickets["FAIL-1"] = nil
+	tickets[server.URL+"/browse/PROJ-1"] = nil // duplicate reference to PROJ-1
+
+	client := NewClientFromEnv()
+	client.Concurrency = 3
+	info := client.ProcessTickets(tickets)
+
+	if len(info) != 11 {
+		t.Fatalf("Expected 11 tickets, got %d: %v", len(info), info)
+	}
+	for i := 1; i <= 10; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		if info[key].Summary != "Summary of "+key {
+			t.Errorf("Unexpected info for %s: %+v", key, info[key])
+		}
+	}
+	failed := info["FAIL-1"]
+	if failed.Summary != "" || failed.URL != server.URL+"/browse/FAIL-1" {
+		t.Errorf("Failed fetch should fall back to basic info, got %+v", failed)
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, saw %d", got)
+	}
+}
+
+func TestFetchTicketSummaryReusesConnections(t *testing.T) {
+	var newConns int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		fmt.Fprintf(w, `{"key": %q, "fields": {"summary": "Summary of %s"}}`, key, key)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	t.Setenv("JIRA_PAT", "test-token")
+	if err := SetBaseURL(server.URL); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+	t.Cleanup(func() { SetBaseURL(DefaultBaseURL) })
+
+	for i := 1; i <= 3; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		info, err := FetchTicketSummary(key)
+		if err != nil {
+			t.Fatalf("FetchTicketSummary(%s) returned error: %v", key, err)
+		}
+		if info.Summary != "Summary of "+key {
+			t.Errorf("Unexpected info for %s: %+v", key, info)
+		}
+	}
+
+	if got := atomic.LoadInt32(&newConns); got != 1 {
+		t.Errorf("Expected serial fetches to share 1 connection, opened %d", got)
+	}
+}
+
+func TestFetchTicketSummaryTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Setenv("JIRA_PAT", "test-token")
+	if err := SetBaseURL(server.URL); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+	HTTPClient.Timeout = 20 * time.Millisecond
+	t.Cleanup(func() {
+		SetBaseURL(DefaultBaseURL)
+		HTTPClient.Timeout = DefaultTimeout
+	})
+
+	if _, err := FetchTicketSummary("PROJ-1"); err == nil {
+		t.Error("Expected a timeout error")
+	}
+}
+
+func TestClientProcessTickets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer client-token" {
+			t.Errorf("Unexpected Authorization header %q", got)
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		fmt.Fprintf(w, `{"key": %q, "fields": {"summary": "Summary of %s"}}`, key, key)
+	}))
+	defer server.Close()
+
+	// The client must not depend on the package-level configuration
+	t.Setenv("JIRA_PAT", "")
+	client := &Client{
+		BaseURL:     server.URL,
+		Token:       "client-token",
+		HTTPClient:  server.Client(),
+		Concurrency: 2,
+	}
+
+	info, err := client.FetchTicketSummary("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchTicketSummary returned error: %v", err)
+	}
+	if info.Summary != "Summary of PROJ-1" || info.URL != server.URL+"/browse/PROJ-1" {
+		t.Errorf("Unexpected ticket info: %+v", info)
+	}
+
+	tickets := map[string][]model.TaskWithDate{"PROJ-2": nil, "PROJ-3": nil}
+	got := client.ProcessTickets(tickets)
+	if len(got) != 2 || got["PROJ-2"].Summary != "Summary of PROJ-2" || got["PROJ-3"].Summary != "Summary of PROJ-3" {
+		t.Errorf("Unexpected ProcessTickets result: %+v", got)
+	}
+}
+
+func TestClientInstancesByPrefix(t *testing.T) {
+	// newServer returns a JIRA server that only accepts token and names itself in summaries
+	newServer := func(name, token string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != "Bearer "+token {
+				t.Errorf("%s server got Authorization header %q", name, got)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+			fmt.Fprintf(w, `{"key": %q, "fields": {"summary": "%s on %s"}}`, key, key, name)
+		}))
+	}
+	main, ops := newServer("main", "main-token"), newServer("ops", "ops-token")
+	defer main.Close()
+	defer ops.Close()
+
+	t.Cleanup(func() { SetInstances(nil) })
+	if err := SetInstances(map[string]Instance{"ops-": {BaseURL: ops.URL + "/", Token: "ops-token"}}); err != nil {
+		t.Fatalf("SetInstances failed: %v", err)
+	}
+	client := &Client{BaseURL: main.URL, Token: "main-token", Instances: Instances, Concurrency: 2}
+
+	got := client.ProcessTickets(map[string][]model.TaskWithDate{"PROJ-1": nil, "OPS-2": nil})
+	want := map[string]TicketInfo{
+		"PROJ-1": {Key: "PROJ-1", Summary: "PROJ-1 on main", URL: main.URL + "/browse/PROJ-1"},
+		"OPS-2":  {Key: "OPS-2", Summary: "OPS-2 on ops", URL: ops.URL + "/browse/OPS-2"},
+	}
+	for key, info := range want {
+		if got[key].Key != info.Key || got[key].Summary != info.Summary || got[key].URL != info.URL {
+			t.Errorf("ProcessTickets()[%s] = %+v, want %+v", key, got[key], info)
+		}
+	}
+
+	// Links fall back to the mapped instance when no info was fetched
+	if got, want := FormatTicketHTML("OPS-3", nil, false), `<a href="`+ops.URL+`/browse/OPS-3" target="_blank">OPS-3</a>`; got != want {
+		t.Errorf("FormatTicketHTML(OPS-3) = %q, want %q", got, want)
+	}
+	if got, want := TicketURL("PROJ-3"), DefaultBaseURL+"/browse/PROJ-3"; got != want {
+		t.Errorf("TicketURL(PROJ-3) = %q, want %q", got, want)
+	}
+
+	if err := SetInstances(map[string]Instance{"OPS": {BaseURL: "ops.example.com"}}); err == nil {
+		t.Error("Expected an error for a relative instance URL")
+	}
+}
+
+func TestClientWithoutToken(t *testing.T) {
+	client := &Client{BaseURL: "https://jira.example.com"}
+
+	info, err := client.FetchTicketSummary("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchTicketSummary returned error: %v", err)
+	}
+	if info.Summary != "" || info.URL != "https://jira.example.com/browse/PROJ-1" {
+		t.Errorf("Expected basic info without a token, got %+v", info)
+	}
+}
+
+func TestFetchTicketStatusAndAssignee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); got != "summary,status,assignee" {
+			t.Errorf("Unexpected fields query %q", got)
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/") {
+		case "PROJ-1":
+			fmt.Fprint(w, `{"key": "PROJ-1", "fields": {"summary": "Login", "status": {"name": "In Progress"}, "assignee": {"displayName": "Jane Doe"}}}`)
+		default:
+			fmt.Fprint(w, `{"key": "PROJ-2", "fields": {"summary": "Logout", "status": {"name": "Done"}, "assignee": null}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, Token: "test-token", HTTPClient: server.Client()}
+	assigned, err := client.FetchTicketSummary("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchTicketSummary returned error: %v", err)
+	}
+	if assigned.Status != "In Progress" || assigned.Assignee != "Jane Doe" {
+		t.Errorf("Unexpected ticket info: %+v", assigned)
+	}
+	unassigned, err := client.FetchTicketSummary("PROJ-2")
+	if err != nil {
+		t.Fatalf("FetchTicketSummary returned error: %v", err)
+	}
+	if unassigned.Status != "Done" || unassigned.Assignee != "" {
+		t.Errorf("Unexpected ticket info: %+v", unassigned)
+	}
+}
+
+func TestFormatTicketHTMLShowStatus(t *testing.T) {
+	jiraInfo := map[string]TicketInfo{
+		"PROJ-1": {Key: "PROJ-1", Summary: "Login", URL: "https://issues.redhat.com/browse/PROJ-1", Status: "In Progress", Assignee: "Jane Doe"},
+		"PROJ-2": {Key: "PROJ-2", URL: "https://issues.redhat.com/browse/PROJ-2", Status: "Done"},
+	}
+	link := `<a href="https://issues.redhat.com/browse/PROJ-1" target="_blank">PROJ-1: Login</a>`
+	if got := FormatTicketHTML("PROJ-1", jiraInfo, false); got != link {
+		t.Errorf("FormatTicketHTML without showStatus = %q, want %q", got, link)
+	}
+
+	if got, want := FormatTicketHTML("PROJ-1", jiraInfo, true), link+" (In Progress, Jane Doe)"; got != want {
+		t.Errorf("FormatTicketHTML = %q, want %q", got, want)
+	}
+	if got, want := FormatTicketHTML("PROJ-2", jiraInfo, true), `<a href="https://issues.redhat.com/browse/PROJ-2" target="_blank">PROJ-2</a> (Done)`; got != want {
+		t.Errorf("FormatTicketHTML = %q, want %q", got, want)
+	}
+}
+
+func TestFetchTicketCustomFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); got != "summary,status,assignee,customfield_10002,customfield_12310,customfield_404,labels" {
+			t.Errorf("Unexpected fields query %q", got)
+		}
+		fmt.Fprint(w, `{"key": "PROJ-1", "fields": {
+			"summary": "Login",
+			"customfield_10002": 5.0,
+			"customfield_12310": {"self": "https://jira.example.com/rest/api/2/customFieldOption/1", "value": "Platform"},
+			"customfield_404": null,
+			"labels": ["ui", "auth"]
+		}}`)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		Token:      "test-token",
+		HTTPClient: server.Client(),
+		Fields:     []string{"customfield_10002", "customfield_12310", "customfield_404", "labels"},
+	}
+	info, err := client.FetchTicketSummary("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchTicketSummary returned error: %v", err)
+	}
+	want := map[string]string{"customfield_10002": "5.0", "customfield_12310": "Platform", "labels": `["ui", "auth"]`}
+	if fmt.Sprint(info.Fields) != fmt.Sprint(want) {
+		t.Errorf("Fields = %q, want %q", info.Fields, want)
+	}
+	if info.Summary != "Login" {
+		t.Errorf("Unexpected summary %q", info.Summary)
+	}
+
+	got := FormatTicketHTML("PROJ-1", map[string]TicketInfo{"PROJ-1": info}, false)
+	if !strings.HasSuffix(got, `</a> [customfield_10002: 5.0; customfield_12310: Platform; labels: [&#34;ui&#34;, &#34;auth&#34;]]`) {
+		t.Errorf("Expected the fields after the HTML link, got %q", got)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	t.Cleanup(func() { TokenFile, TokenCommand = "", "" })
+	t.Setenv("JIRA_PAT", "env-token")
+
+	tokenFile := filepath.Join(t.TempDir(), "jira-token")
+	if err := os.WriteFile(tokenFile, []byte("  file-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+	emptyFile := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(emptyFile, nil, 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{name: "environment", want: "env-token"},
+		{name: "file", file: tokenFile, want: "file-token"},
+		{name: "command", command: "echo cmd-token", want: "cmd-token"},
+		{name: "file wins over command", file: tokenFile, command: "echo cmd-token", want: "file-token"},
+		{name: "missing file", file: filepath.Join(t.TempDir(), "missing"), wantErr: true},
+		{name: "empty file", file: emptyFile, wantErr: true},
+		{name: "failing command", command: "exit 1", wantErr: true},
+		{name: "command without output", command: "true", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strings.Contains(tt.name, "command") && runtime.GOOS == "windows" {
+				t.Skip("token commands are run with sh in this test")
+			}
+			TokenFile, TokenCommand = tt.file, tt.command
+			got, err := ResolveToken()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got token %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveToken returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	TokenFile, TokenCommand = tokenFile, ""
+	if client := NewClientFromEnv(); client.Token != "file-token" {
+		t.Errorf("Expected NewClientFromEnv to use the resolved token, got %q", client.Token)
+	}
+}
@@ -0,0 +1,26 @@
+// Package markup escapes text for the markup syntaxes reports are rendered in,
+// so that the JIRA links and the report bodies escape the same characters.
+package markup
+
+import "strings"
+
+var (
+	markdownLinkTextReplacer = strings.NewReplacer(`[`, `\[`, `]`, `\]`)
+	asciiDocLinkTextReplacer = strings.NewReplacer(`]`, `\]`)
+	slackTextReplacer        = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`)
+)
+
+// MarkdownLinkText escapes the brackets that would end a Markdown link text early.
+func MarkdownLinkText(text string) string {
+	return markdownLinkTextReplacer.Replace(text)
+}
+
+// AsciiDocLinkText escapes the bracket that would end an AsciiDoc link text early.
+func AsciiDocLinkText(text string) string {
+	return asciiDocLinkTextReplacer.Replace(text)
+}
+
+// SlackText escapes the control characters Slack's mrkdwn reserves for links and mentions.
+func SlackText(text string) string {
+	return slackTextReplacer.Replace(text)
+}
@@ -0,0 +1,23 @@
+package markup
+
+import "testing"
+
+func TestEscapers(t *testing.T) {
+	tests := []struct {
+		name   string
+		escape func(string) string
+		in     string
+		want   string
+	}{
+		{name: "markdown link text", escape: MarkdownLinkText, in: "Fix [WIP] parser", want: `Fix \[WIP\] parser`},
+		{name: "asciidoc link text", escape: AsciiDocLinkText, in: "Fix [WIP] parser", want: `Fix [WIP\] parser`},
+		{name: "slack text", escape: SlackText, in: "<!here> R&D > ops", want: "&lt;!here&gt; R&amp;D &gt; ops"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.escape(tt.in); got != tt.want {
+				t.Errorf("Got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,22 +8,39 @@ const (
 	StatusNotStarted = "not started"
 )
 
+// Day type constants. A day without a day_type is a regular work day.
+const (
+	DayTypeWork    = "work"
+	DayTypePTO     = "pto"
+	DayTypeHoliday = "holiday"
+	DayTypeHalf    = "half"
+)
+
 // WorkLog represents a single time entry (start and end).
+// NextDay marks an entry whose end time falls on the following day, such as a 22:00-02:00 shift.
+// Ticket optionally attributes the time to a JIRA ticket.
 type WorkLog struct {
-	StartTime string `yaml:"start_time"`
-	EndTime   string `yaml:"end_time"`
+	StartTime string `yaml:"start_time" json:"start_time"`
+	EndTime   string `yaml:"end_time" json:"end_time"`
+	NextDay   bool   `yaml:"next_day,omitempty" json:"next_day,omitempty"`
+	Ticket    string `yaml:"ticket,omitempty" json:"ticket,omitempty"`
 }
 
-// Task represents a single work item.
+// Task represents a single work item. Project groups tasks for per-project
+// reports and defaults to the JIRA ticket's project. Private tasks are left out
+// of reports unless explicitly included.
 type Task struct {
-	Status            string   `yaml:"status"`
-	Description       string   `yaml:"description"`
-	Descriptions      []string `yaml:"descriptions"`
-	JiraTicket        string   `yaml:"jira_ticket"`
-	QCGoal            string   `yaml:"qc_goal"`
-	UpnextDescription string   `yaml:"upnext_description"`
-	GithubPR          string   `yaml:"github_pr"`
-	Blocker           string   `yaml:"blocker"`
+	Status            string   `yaml:"status" json:"status"`
+	Description       string   `yaml:"description" json:"description"`
+	Descriptions      []string `yaml:"descriptions" json:"descriptions"`
+	JiraTicket        string   `yaml:"jira_ticket" json:"jira_ticket"`
+	QCGoal            string   `yaml:"qc_goal" json:"qc_goal"`
+	UpnextDescription string   `yaml:"upnext_description" json:"upnext_description"`
+	GithubPR          string   `yaml:"github_pr" json:"github_pr"`
+	Blocker           string   `yaml:"blocker" json:"blocker"`
+	Tags              []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Project           string   `yaml:"project,omitempty" json:"project,omitempty"`
+	Private           bool     `yaml:"private,omitempty" json:"private,omitempty"`
 }
 
 // GetDescriptions returns all descriptions for a task, combining both
@@ -44,10 +61,19 @@ type TaskWithDate struct {
 	Date string
 }
 
-// DailyLog contains all information for a single day.
+// StaleTask is the most recent task of a ticket left in progress, with the
+// number of days from it to the end of the report range.
+type StaleTask struct {
+	TaskWithDate
+	DaysSince int
+}
+
+// DailyLog contains all information for a single day. DayType marks PTO,
+// holidays, and half days, which lower the hours expected that day.
 type DailyLog struct {
-	WorkLogEntries []WorkLog `yaml:"work_log"`
-	Tasks          []Task    `yaml:"tasks"`
+	DayType        string    `yaml:"day_type,omitempty" json:"day_type,omitempty"`
+	WorkLogEntries []WorkLog `yaml:"work_log" json:"work_log"`
+	Tasks          []Task    `yaml:"tasks" json:"tasks"`
 }
 
 // WorkData is the top-level structure, mapping dates to daily logs.
@@ -57,5 +83,7 @@ type WorkData map[string]DailyLog
 type CategorizedTasks struct {
 	Completed map[string][]TaskWithDate // Jira ticket -> list of completed/in-progress tasks
 	NextUp    map[string][]TaskWithDate // Jira ticket -> list of tasks with next up descriptions
-	Blocked   []Task                    // Tasks with blockers
+	Blocked   []TaskWithDate            // Most recent task of each group that has a blocker
+	ByQCGoal  map[string][]TaskWithDate // QC goal -> list of tasks working toward it
+	Stale     []StaleTask               // Most recent task of each group still in progress long before the range ends
 }
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Statuses lists the valid task status values.
+var Statuses = []string{StatusCompleted, StatusInProgress, StatusNotStarted}
+
+// DayTypes lists the valid day_type values.
+var DayTypes = []string{DayTypeWork, DayTypePTO, DayTypeHoliday, DayTypeHalf}
+
+// timeLayouts are the accepted layouts for work log start and end times, tried
+// in order. 24-hour HH:MM is the canonical form; the others accept times
+// exported by other tools.
+var timeLayouts = []string{"15:04", "15:04:05", "3:04 PM", "3:04PM"}
+
+// ParseWorkTime parses a work log start or end time using the first layout in
+// timeLayouts that matches. AM/PM markers are accepted in either case.
+func ParseWorkTime(value string) (time.Time, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, normalized); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, use HH:MM, HH:MM:SS, or H:MM AM/PM", value)
+}
+
+// CanonicalStatus returns the status constant matching status case-insensitively.
+func CanonicalStatus(status string) (string, bool) {
+	for _, known := range Statuses {
+		if strings.EqualFold(status, known) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// ValidationIssue describes a single problem found in the work data.
+type ValidationIssue struct {
+	Date    string
+	Field   string
+	Message string
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Date, i.Field, i.Message)
+}
+
+// Validate checks every date key, work log entry, and task, returning issues in
+// date order. It reports malformed date keys, unknown day types, unparseable
+// times, entries that end before they start (unless marked next_day), and
+// unknown task statuses.
+func (w WorkData) Validate() []ValidationIssue {
+	dates := make([]string, 0, len(w))
+	for date := range w {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	var issues []ValidationIssue
+	for _, date := range dates {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			issues = append(issues, ValidationIssue{Date: date, Field: "date", Message: "date key must be in YYYY-MM-DD format"})
+		}
+
+		dailyLog := w[date]
+		if dailyLog.DayType != "" && !slices.Contains(DayTypes, dailyLog.DayType) {
+			issues = append(issues, ValidationIssue{Date: date, Field: "day_type", Message: fmt.Sprintf("unknown day type %q, use one of: %s", dailyLog.DayType, strings.Join(DayTypes, " | "))})
+		}
+		for i, entry := range dailyLog.WorkLogEntries {
+			field := fmt.Sprintf("work_log[%d]", i)
+			start, startErr := ParseWorkTime(entry.StartTime)
+			if startErr != nil {
+				issues = append(issues, ValidationIssue{Date: date, Field: field + ".start_time", Message: startErr.Error()})
+			}
+			end, endErr := ParseWorkTime(entry.EndTime)
+			if endErr != nil {
+				issues = append(issues, ValidationIssue{Date: date, Field: field + ".end_time", Message: endErr.Error()})
+			}
+			if startErr == nil && endErr == nil && !entry.NextDay && end.Before(start) {
+				issues = append(issues, ValidationIssue{Date: date, Field: field, Message: fmt.Sprintf("end_time %s is before start_time %s (set next_day: true for entries past midnight)", entry.EndTime, entry.StartTime)})
+			}
+		}
+
+		for i, task := range dailyLog.Tasks {
+			if _, ok := CanonicalStatus(task.Status); !ok {
+				issues = append(issues, ValidationIssue{
+					Date:    date,
+					Field:   fmt.Sprintf("tasks[%d].status", i),
+					Message: fmt.Sprintf("unknown status %q, use one of: %s", task.Status, strings.Join(Statuses, " | ")),
+				})
+			}
+		}
+	}
+	return issues
+}
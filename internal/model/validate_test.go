@@ -0,0 +1,90 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWorkTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "09:00", want: "09:00:00"},
+		{value: "9:00", want: "09:00:00"},
+		{value: "17:45:30", want: "17:45:30"},
+		{value: "9:00 AM", want: "09:00:00"},
+		{value: "1:15 PM", want: "13:15:00"},
+		{value: "12:30PM", want: "12:30:00"},
+		{value: "5:00pm", want: "17:00:00"},
+		{value: "12:00 am", want: "00:00:00"},
+		{value: " 08:15 ", want: "08:15:00"},
+		{value: "23:59:59", want: "23:59:59"},
+		{value: "9am", wantErr: true},
+		{value: "25:00", wantErr: true},
+		{value: "lunch", wantErr: true},
+		{value: "13:00 PM", wantErr: true},
+		{value: "9:00:60", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseWorkTime(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWorkTime returned error: %v", err)
+			}
+			if got.Format("15:04:05") != tt.want {
+				t.Errorf("ParseWorkTime(%q) = %s, want %s", tt.value, got.Format("15:04:05"), tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkDataValidate(t *testing.T) {
+	workData := WorkData{
+		"2024-08-01": DailyLog{
+			WorkLogEntries: []WorkLog{
+				{StartTime: "09:00", EndTime: "12:00"},
+				{StartTime: "9am", EndTime: "25:00"},
+				{StartTime: "14:00", EndTime: "13:00"},
+			},
+			Tasks: []Task{
+				{JiraTicket: "PROJ-1", Status: "Completed"},
+				{JiraTicket: "PROJ-2", Status: "done"},
+			},
+		},
+		"08/02/2024": DailyLog{},
+		"2024-08-03": DailyLog{DayType: "vacation"},
+		"2024-08-05": DailyLog{DayType: DayTypePTO},
+	}
+
+	issues := workData.Validate()
+	var lines []string
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+	got := strings.Join(lines, "\n")
+
+	expected := []string{
+		`08/02/2024: date: date key must be in YYYY-MM-DD format`,
+		`2024-08-01: work_log[1].start_time: invalid time "9am", use HH:MM, HH:MM:SS, or H:MM AM/PM`,
+		`2024-08-01: work_log[1].end_time: invalid time "25:00", use HH:MM, HH:MM:SS, or H:MM AM/PM`,
+		`2024-08-01: work_log[2]: end_time 13:00 is before start_time 14:00`,
+		`2024-08-01: tasks[1].status: unknown status "done"`,
+		`2024-08-03: day_type: unknown day type "vacation"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected issue %q, got:\n%s", want, got)
+		}
+	}
+	if len(issues) != len(expected) {
+		t.Errorf("Expected %d issues, got %d:\n%s", len(expected), len(issues), got)
+	}
+}
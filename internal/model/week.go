@@ -0,0 +1,33 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported first days of the week for week-based grouping and relative ranges.
+const (
+	WeekStartMonday = "monday"
+	WeekStartSunday = "sunday"
+)
+
+// ParseWeekStart returns the weekday named by value, which must be monday or
+// sunday (case-insensitive).
+func ParseWeekStart(value string) (time.Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case WeekStartMonday:
+		return time.Monday, nil
+	case WeekStartSunday:
+		return time.Sunday, nil
+	}
+	return time.Monday, fmt.Errorf("invalid week start %q, use %s or %s", value, WeekStartMonday, WeekStartSunday)
+}
+
+// StartOfWeek returns midnight on the first day of the week containing day, in
+// day's location, for weeks beginning on weekStart.
+func StartOfWeek(day time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return midnight.AddDate(0, 0, -offset)
+}
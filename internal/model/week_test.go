@@ -0,0 +1,57 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeekStart(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{value: "monday", want: time.Monday},
+		{value: "Sunday", want: time.Sunday},
+		{value: "saturday", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekStart(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseWeekStart(%q): expected an error, got %v", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseWeekStart(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	tests := []struct {
+		day       string
+		weekStart time.Weekday
+		want      string
+	}{
+		// Thursday, in a week that starts in July either way
+		{day: "2024-08-01", weekStart: time.Monday, want: "2024-07-29"},
+		{day: "2024-08-01", weekStart: time.Sunday, want: "2024-07-28"},
+		// Sunday ends a Monday week but starts a Sunday week
+		{day: "2024-08-04", weekStart: time.Monday, want: "2024-07-29"},
+		{day: "2024-08-04", weekStart: time.Sunday, want: "2024-08-04"},
+		{day: "2024-08-05", weekStart: time.Monday, want: "2024-08-05"},
+		{day: "2024-08-05", weekStart: time.Sunday, want: "2024-08-04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.day+"/"+tt.weekStart.String(), func(t *testing.T) {
+			day, _ := time.Parse("2006-01-02", tt.day)
+			day = day.Add(15 * time.Hour)
+			if got := StartOfWeek(day, tt.weekStart).Format("2006-01-02"); got != tt.want {
+				t.Errorf("StartOfWeek(%s, %s) = %s, want %s", tt.day, tt.weekStart, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// Section headers for AsciiDoc output.
+const (
+	adocHeaderCompleted      = "== 🦀 Things I've been working on"
+	adocHeaderNextUp         = "== ⭐ Things I plan on working on next"
+	adocHeaderBlocked        = "== 🚫 Things that are blocking me"
+	adocHeaderQCGoals        = "== 🎯 Quarterly goals"
+	adocNonFeatureWorkHeader = "Non-feature work"
+)
+
+// PrintAsciiDoc prints the categorized tasks as AsciiDoc to the writer.
+// JIRA tickets are rendered as links using the provided ticket info.
+func PrintAsciiDoc(out io.Writer, tasks model.CategorizedTasks, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	printCompletedTasksAsciiDoc(out, tasks.Completed, jiraInfo, opts)
+	printNextUpTasksAsciiDoc(out, tasks.NextUp, jiraInfo, opts)
+	printBlockedTasksAsciiDoc(out, tasks.Blocked, jiraInfo, opts)
+	printQCGoalsAsciiDoc(out, tasks.ByQCGoal)
+	printStaleTasksAsciiDoc(out, tasks.Stale)
+}
+
+// asciiDocPRLinks renders PR links as a semicolon-separated list of AsciiDoc links.
+func asciiDocPRLinks(prLinks []string) string {
+	var links []string
+	for _, link := range prLinks {
+		links = append(links, fmt.Sprintf("link:%s[%s]", link, link))
+	}
+	return strings.Join(links, "; ")
+}
+
+// printCompletedTasksAsciiDoc prints the completed tasks section as AsciiDoc.
+func printCompletedTasksAsciiDoc(out io.Writer, tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", sectionHeader(adocHeaderCompleted, opts.HeaderCompleted, "== %s"))
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
+
+	// Print feature work first
+	for _, ticket := range featureTickets {
+		taskList := tasks[ticket]
+		sortByDate(taskList)
+		descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+		heading := jira.FormatTicketAsciiDoc(ticket, jiraInfo)
+		if isInlineEntry(ticket) {
+			heading, descriptions = inlineHeader(descriptions...)
+		}
+		fmt.Fprintf(out, "* *%s*\n", heading)
+		for _, desc := range opts.deduplicateDescriptions(descriptions) {
+			fmt.Fprintf(out, "** %s\n", desc)
+		}
+		if len(prLinks) > 0 {
+			fmt.Fprintf(out, "** PR(s): %s\n", asciiDocPRLinks(prLinks))
+		}
+	}
+
+	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTickets) > 0 {
+		fmt.Fprintf(out, "* *%s*\n", adocNonFeatureWorkHeader)
+		for _, ticket := range nonFeatureTickets {
+			taskList := tasks[ticket]
+			sortByDate(taskList)
+			descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+			// Determine header: for synthetic keys, use the first description
+			header := ticket
+			if IsSyntheticKey(ticket) || header == "" {
+				if len(descriptions) > 0 {
+					header = descriptions[0]
+					descriptions = descriptions[1:]
+				} else {
+					header = "Misc"
+				}
+			}
+			fmt.Fprintf(out, "** %s\n", header)
+
+			descriptions = opts.deduplicateDescriptions(descriptions)
+			sortDescriptions(descriptions)
+			for _, desc := range descriptions {
+				fmt.Fprintf(out, "*** %s\n", desc)
+			}
+			if len(prLinks) > 0 {
+				fmt.Fprintf(out, "*** PR(s): %s\n", asciiDocPRLinks(prLinks))
+			}
+		}
+	}
+}
+
+// printNextUpTasksAsciiDoc prints the next up tasks section as AsciiDoc.
+func printNextUpTasksAsciiDoc(out io.Writer, nextUp map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	if len(nextUp) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", sectionHeader(adocHeaderNextUp, opts.HeaderNextUp, "== %s"))
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(nextUp)
+
+	// Print feature work first
+	for _, ticket := range featureTickets {
+		taskList := nextUp[ticket]
+		sortByDate(taskList)
+		mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+		heading := jira.FormatTicketAsciiDoc(ticket, jiraInfo)
+		if isInlineEntry(ticket) {
+			heading, _ = inlineHeader(mostRecentDesc)
+			mostRecentDesc = ""
+		}
+		fmt.Fprintf(out, "* *%s*\n", heading)
+		if mostRecentDesc != "" {
+			fmt.Fprintf(out, "** %s\n", mostRecentDesc)
+		}
+		if len(prLinks) > 0 {
+			fmt.Fprintf(out, "** PR(s): %s\n", asciiDocPRLinks(prLinks))
+		}
+	}
+
+	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTickets) > 0 {
+		fmt.Fprintf(out, "* *%s*\n", adocNonFeatureWorkHeader)
+		for _, ticket := range nonFeatureTickets {
+			taskList := nextUp[ticket]
+			sortByDate(taskList)
+			mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+			// Determine header: for synthetic keys, use the upnext description
+			header := ticket
+			if IsSyntheticKey(ticket) || header == "" {
+				if mostRecentDesc != "" {
+					header = mostRecentDesc
+					mostRecentDesc = ""
+				} else {
+					header = "Misc"
+				}
+			}
+			fmt.Fprintf(out, "** %s\n", header)
+
+			if mostRecentDesc != "" {
+				fmt.Fprintf(out, "*** %s\n", mostRecentDesc)
+			}
+			if len(prLinks) > 0 {
+				fmt.Fprintf(out, "*** PR(s): %s\n", asciiDocPRLinks(prLinks))
+			}
+		}
+	}
+}
+
+// printBlockedTasksAsciiDoc prints the blocked tasks section as AsciiDoc.
+func printBlockedTasksAsciiDoc(out io.Writer, blocked []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	if len(blocked) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+
+	// Separate feature work and non-feature work
+	var featureTasks []model.TaskWithDate
+	var nonFeatureTasks []model.TaskWithDate
+
+	for _, task := range blocked {
+		if opts.isGroupedNonFeature(task) {
+			nonFeatureTasks = append(nonFeatureTasks, task)
+		} else {
+			featureTasks = append(featureTasks, task)
+		}
+	}
+
+	fmt.Fprintf(out, "\n%s\n\n", sectionHeader(adocHeaderBlocked, opts.HeaderBlocked, "== %s"))
+
+	// Print feature work first
+	for _, task := range featureTasks {
+		fmt.Fprintf(out, "* *%s*\n", jira.FormatTicketAsciiDoc(opts.blockedTicket(task), jiraInfo))
+		fmt.Fprintf(out, "** Blocker: %s\n", task.Blocker)
+		fmt.Fprintf(out, "** %s\n", blockedSince(task))
+	}
+
+	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTasks) > 0 {
+		fmt.Fprintf(out, "* *%s*\n", adocNonFeatureWorkHeader)
+		for _, task := range nonFeatureTasks {
+			header := task.JiraTicket
+			if header == "" {
+				header = "Misc"
+			}
+			fmt.Fprintf(out, "** %s\n", header)
+			fmt.Fprintf(out, "*** Blocker: %s\n", task.Blocker)
+			fmt.Fprintf(out, "*** %s\n", blockedSince(task))
+		}
+	}
+}
+
+// printQCGoalsAsciiDoc prints the quarterly goals section as AsciiDoc.
+func printQCGoalsAsciiDoc(out io.Writer, byGoal map[string][]model.TaskWithDate) {
+	if len(byGoal) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", adocHeaderQCGoals)
+
+	for _, goal := range sortedGoals(byGoal) {
+		fmt.Fprintf(out, "* *%s*\n", goal)
+		for _, entry := range qcGoalEntries(byGoal[goal]) {
+			fmt.Fprintf(out, "** %s\n", entry)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// textDateHeaderFormat is the header of each day in the chronological report.
+const textDateHeaderFormat = "\n📅 %s"
+
+// PrintByDate prints a chronological report for report --group-by date: each of
+// the given dates with tasks gets a header, followed by that day's tasks in
+// logged order with their status, ticket, descriptions, PR, and blocker. Unlike
+// the sectioned report, a ticket worked on over several days appears under each
+// of them. Private tasks are skipped unless opts.IncludePrivate is set. PR links are
+// labeled with their titles from prInfo when known.
+func PrintByDate(out io.Writer, workData model.WorkData, dates []string, prInfo map[string]github.PRInfo, opts Options) {
+	for _, date := range dates {
+		var tasks []model.Task
+		for _, task := range workData[date].Tasks {
+			if task.Private && !opts.IncludePrivate {
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(out, paintHeader(out, fmt.Sprintf(textDateHeaderFormat, date)))
+		for _, task := range tasks {
+			printDateTaskEntry(out, task, prInfo, opts)
+		}
+	}
+}
+
+// printDateTaskEntry prints one task of the chronological report: a bullet with
+// its ticket, status, and first description, and nested bullets for the rest.
+func printDateTaskEntry(out io.Writer, task model.Task, prInfo map[string]github.PRInfo, opts Options) {
+	color := ansiNextUp
+	switch {
+	case task.Blocker != "":
+		color = ansiBlocked
+	case strings.EqualFold(task.Status, model.StatusCompleted):
+		color = ansiComplete
+	}
+
+	entry := fmt.Sprintf("[%s]", task.Status)
+	if task.JiraTicket != "" {
+		entry = paint(out, color, task.JiraTicket) + " " + entry
+	}
+	descriptions := task.GetDescriptions()
+	if len(descriptions) > 0 {
+		entry += " " + descriptions[0]
+		descriptions = descriptions[1:]
+	}
+	opts.printWrapped(out, "    • %s", entry)
+
+	for _, desc := range descriptions {
+		opts.printWrapped(out, "        ◦ %s", desc)
+	}
+	if task.GithubPR != "" {
+		fmt.Fprintf(out, "        ◦ %s\n", paint(out, ansiDim, "PR: "+github.Label(task.GithubPR, prInfo)))
+	}
+	if task.Blocker != "" {
+		opts.printWrapped(out, "        ◦ Blocker: %s", task.Blocker)
+	}
+}
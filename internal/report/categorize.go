@@ -3,12 +3,88 @@ package report
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bryan-cox/taskledger/internal/jira"
 	"github.com/bryan-cox/taskledger/internal/model"
 )
 
+// Options controls how CategorizeTasks groups tasks and how the renderers lay
+// out a report. The zero value renders the default report.
+type Options struct {
+	// IncludeEmptySections makes the renderers print the completed, next up, and
+	// blocked section headers even when a section has no entries.
+	IncludeEmptySections bool
+	// CountDuplicates makes the renderers annotate a description logged more than
+	// once for the same ticket with its count, e.g. "code review (x3)".
+	CountDuplicates bool
+	// CollapseCompleted makes the renderers show only the most recent description
+	// of each completed ticket, as the next up section does, instead of every
+	// description in order. PR links still cover all of the ticket's tasks.
+	CollapseCompleted bool
+	// MergeAcrossStatus makes CategorizeTasks list a ticket only in the section of
+	// its most recent status: a ticket that is next up is left out of the
+	// completed section. Tickets whose latest task is completed are never next up.
+	MergeAcrossStatus bool
+	// FlatNonFeatureWork makes the renderers list non-feature work inline with the
+	// other tickets of each section, in sorted order, instead of grouping it under
+	// a "Non-feature work" entry at the end. Entries without a ticket are headed
+	// by their first description, as they are under the group.
+	FlatNonFeatureWork bool
+	// IncludePrivate keeps tasks marked private, which are otherwise left out of
+	// every report section.
+	IncludePrivate bool
+	// StaleAfter, when positive, makes CategorizeTasks list each ticket whose most
+	// recent task is still in progress and more than this many days older than
+	// the last date of the range as possibly stale.
+	StaleAfter int
+	// PROrder is the order of a ticket's PR links in every format: PROrderURL
+	// (the default when empty) sorts them by URL, PROrderChrono keeps the order
+	// they were first logged in.
+	PROrder string
+	// ShowJiraStatus appends each ticket's JIRA status and assignee to its link
+	// in HTML output.
+	ShowJiraStatus bool
+	// HeaderCompleted, HeaderNextUp, and HeaderBlocked replace the titles of the
+	// completed, next up, and blocked sections in every format when set. Each
+	// format keeps its own heading markup around the title.
+	HeaderCompleted string
+	HeaderNextUp    string
+	HeaderBlocked   string
+	// HoursDetail, when set, is rendered as a table per day at the end of HTML
+	// reports, before HTMLFooter.
+	HoursDetail []DayHours
+	// HTMLFooter, when set, is rendered as a small paragraph at the end of HTML
+	// reports.
+	HTMLFooter string
+	// WrapWidth, when positive, hard-wraps description and blocker bullets in
+	// text output at that many columns. Zero leaves lines as they are.
+	WrapWidth int
+}
+
+// PR orderings selectable with report --pr-order.
+const (
+	PROrderURL    = "url"
+	PROrderChrono = "chrono"
+)
+
+// sectionHeader returns defaultHeader, or title formatted with layout when a
+// custom title is set.
+func sectionHeader(defaultHeader, title, layout string) string {
+	if title == "" {
+		return defaultHeader
+	}
+	return fmt.Sprintf(layout, title)
+}
+
+// HasEntries reports whether any report section has at least one entry.
+func HasEntries(tasks model.CategorizedTasks) bool {
+	return len(tasks.Completed) > 0 || len(tasks.NextUp) > 0 || len(tasks.Blocked) > 0 || len(tasks.ByQCGoal) > 0
+}
+
 // IsNonFeatureWork returns true if the task should be grouped under "Non-feature work".
 // A task is non-feature work if:
 // - jira_ticket is empty, OR
@@ -20,6 +96,10 @@ func IsNonFeatureWork(ticket string, githubPR string) bool {
 	if ticket == "" {
 		return true
 	}
+	// Tag groups are shown as their own entries
+	if IsTagKey(ticket) {
+		return false
+	}
 	// Synthetic keys from categorization are always non-feature work
 	if IsSyntheticKey(ticket) {
 		return true
@@ -41,11 +121,188 @@ func IsSyntheticKey(key string) bool {
 	return strings.HasPrefix(key, "__noticket_") || strings.HasPrefix(key, "http://") || strings.HasPrefix(key, "https://")
 }
 
-// CategorizeTasks groups tasks from the work data into completed, next up, and blocked categories.
-func CategorizeTasks(workData model.WorkData, dates []string) model.CategorizedTasks {
+// TagKeyPrefix starts the grouping key of a tag group created by GroupCompletedByTag.
+const TagKeyPrefix = "#"
+
+// Report groupings selectable with report --group-by.
+const (
+	GroupByTicket = "ticket"
+	GroupByTag    = "tag"
+	GroupByDate   = "date"
+)
+
+// IsTagKey returns true if the key names a tag group (e.g. "#review") rather than a ticket.
+func IsTagKey(key string) bool {
+	return strings.HasPrefix(key, TagKeyPrefix)
+}
+
+// FilterTags returns a copy of workData that keeps only tasks carrying at least one
+// of tags. Tags are compared case-insensitively. Work log entries are kept unchanged.
+func FilterTags(workData model.WorkData, tags []string) model.WorkData {
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		wanted[normalizeTag(tag)] = true
+	}
+
+	filtered := make(model.WorkData, len(workData))
+	for date, dailyLog := range workData {
+		var tasks []model.Task
+		for _, task := range dailyLog.Tasks {
+			for _, tag := range task.Tags {
+				if wanted[normalizeTag(tag)] {
+					tasks = append(tasks, task)
+					break
+				}
+			}
+		}
+		dailyLog.Tasks = tasks
+		filtered[date] = dailyLog
+	}
+	return filtered
+}
+
+// normalizeTag returns the form of a tag or status used for comparisons.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// GroupCompletedByTag regroups completed tasks under their tags instead of their
+// tickets. A task with several tags appears under each of them, and its
+// descriptions are prefixed with its JIRA ticket so the ticket stays visible.
+// Tasks without tags keep their original grouping.
+func GroupCompletedByTag(completed map[string][]model.TaskWithDate) map[string][]model.TaskWithDate {
+	// Visit groups in a fixed order so tasks sharing a tag keep a stable order
+	keys := make([]string, 0, len(completed))
+	for key := range completed {
+		keys = append(keys, key)
+	}
+	sortTickets(keys)
+
+	grouped := make(map[string][]model.TaskWithDate, len(completed))
+	for _, key := range keys {
+		for _, task := range completed[key] {
+			tags := uniqueTags(task.Tags)
+			if len(tags) == 0 {
+				grouped[key] = append(grouped[key], task)
+				continue
+			}
+
+			tagged := task
+			if ticketID := jira.ExtractTicketID(task.JiraTicket); ticketID != "" {
+				tagged.Description = ""
+				tagged.Descriptions = nil
+				for _, desc := range task.GetDescriptions() {
+					tagged.Descriptions = append(tagged.Descriptions, ticketID+": "+desc)
+				}
+			}
+			for _, tag := range tags {
+				tagKey := TagKeyPrefix + tag
+				grouped[tagKey] = append(grouped[tagKey], tagged)
+			}
+		}
+	}
+	return grouped
+}
+
+// uniqueTags returns the normalized, non-empty tags in order with duplicates removed.
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		unique = append(unique, tag)
+	}
+	return unique
+}
+
+// FilterTickets returns a copy of workData that keeps only tasks whose JIRA ticket
+// matches one of tickets. Tickets are compared by extracted ticket ID, so a key and
+// a full browse URL for the same ticket match; tasks without a ticket are dropped.
+// Work log entries are kept unchanged.
+func FilterTickets(workData model.WorkData, tickets []string) model.WorkData {
+	wanted := make(map[string]bool, len(tickets))
+	for _, ticket := range tickets {
+		wanted[normalizeTicket(ticket)] = true
+	}
+
+	filtered := make(model.WorkData, len(workData))
+	for date, dailyLog := range workData {
+		var tasks []model.Task
+		for _, task := range dailyLog.Tasks {
+			if task.JiraTicket != "" && wanted[normalizeTicket(task.JiraTicket)] {
+				tasks = append(tasks, task)
+			}
+		}
+		dailyLog.Tasks = tasks
+		filtered[date] = dailyLog
+	}
+	return filtered
+}
+
+// normalizeTicket returns the JIRA ticket ID referenced by ticket, or ticket itself
+// if it contains no recognizable ID.
+func normalizeTicket(ticket string) string {
+	if ticketID := jira.ExtractTicketID(ticket); ticketID != "" {
+		return ticketID
+	}
+	return strings.TrimSpace(ticket)
+}
+
+// ExcludeTickets returns a copy of workData without the tasks whose JIRA ticket
+// matches one of tickets, compared like FilterTickets. Tasks without a ticket are
+// kept. Work log entries are kept unchanged.
+func ExcludeTickets(workData model.WorkData, tickets []string) model.WorkData {
+	unwanted := make(map[string]bool, len(tickets))
+	for _, ticket := range tickets {
+		unwanted[normalizeTicket(ticket)] = true
+	}
+	return excludeTasks(workData, func(task model.Task) bool {
+		return task.JiraTicket != "" && unwanted[normalizeTicket(task.JiraTicket)]
+	})
+}
+
+// ExcludeStatuses returns a copy of workData without the tasks whose status is one
+// of statuses. Statuses are compared case-insensitively. Work log entries are kept
+// unchanged.
+func ExcludeStatuses(workData model.WorkData, statuses []string) model.WorkData {
+	unwanted := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		unwanted[normalizeTag(status)] = true
+	}
+	return excludeTasks(workData, func(task model.Task) bool {
+		return unwanted[normalizeTag(task.Status)]
+	})
+}
+
+// excludeTasks returns a copy of workData without the tasks for which exclude
+// returns true.
+func excludeTasks(workData model.WorkData, exclude func(model.Task) bool) model.WorkData {
+	filtered := make(model.WorkData, len(workData))
+	for date, dailyLog := range workData {
+		var tasks []model.Task
+		for _, task := range dailyLog.Tasks {
+			if !exclude(task) {
+				tasks = append(tasks, task)
+			}
+		}
+		dailyLog.Tasks = tasks
+		filtered[date] = dailyLog
+	}
+	return filtered
+}
+
+// CategorizeTasks groups tasks from the work data into completed, next up, and blocked
+// categories. Private tasks are skipped unless opts.IncludePrivate is set, and with
+// opts.MergeAcrossStatus a ticket appears in only one of the completed and next up sections.
+func CategorizeTasks(workData model.WorkData, dates []string, opts Options) model.CategorizedTasks {
 	completedTasks := make(map[string][]model.TaskWithDate)
 	allNextUpTasks := make(map[string][]model.TaskWithDate)
 	mostRecentTasks := make(map[string]model.TaskWithDate)
+	qcGoalTasks := make(map[string][]model.TaskWithDate)
 
 	emptyCounter := 0
 	for _, date := range dates {
@@ -54,6 +311,9 @@ func CategorizeTasks(workData model.WorkData, dates []string) model.CategorizedT
 			continue
 		}
 		for _, task := range dailyLog.Tasks {
+			if task.Private && !opts.IncludePrivate {
+				continue
+			}
 			taskWithDate := model.TaskWithDate{Task: task, Date: date}
 			jiraTicket := task.JiraTicket
 
@@ -69,8 +329,9 @@ func CategorizeTasks(workData model.WorkData, dates []string) model.CategorizedT
 				}
 			}
 
-			// Track completed tasks - include both completed and in-progress tasks with descriptions
-			if strings.EqualFold(task.Status, model.StatusCompleted) ||
+			// Track completed tasks - include both completed and in-progress tasks with descriptions.
+			// Completed tasks with nothing to show would render as an empty bullet, so skip them
+			if strings.EqualFold(task.Status, model.StatusCompleted) && !isEmptyTask(task) ||
 				(strings.EqualFold(task.Status, model.StatusInProgress) && len(task.GetDescriptions()) > 0) {
 				completedTasks[groupKey] = append(completedTasks[groupKey], taskWithDate)
 			}
@@ -80,6 +341,11 @@ func CategorizeTasks(workData model.WorkData, dates []string) model.CategorizedT
 				allNextUpTasks[groupKey] = append(allNextUpTasks[groupKey], taskWithDate)
 			}
 
+			// Group tasks by the QC goal they contribute to
+			if goal := strings.TrimSpace(task.QCGoal); goal != "" {
+				qcGoalTasks[goal] = append(qcGoalTasks[goal], taskWithDate)
+			}
+
 			// Track most recent task per group
 			if existing, exists := mostRecentTasks[groupKey]; !exists || date > existing.Date {
 				mostRecentTasks[groupKey] = taskWithDate
@@ -97,17 +363,347 @@ func CategorizeTasks(workData model.WorkData, dates []string) model.CategorizedT
 		}
 	}
 
+	if opts.MergeAcrossStatus {
+		for groupKey := range nextUpTasks {
+			delete(completedTasks, groupKey)
+		}
+	}
+
 	// Filter blocked tasks: only include tickets where the most recent task has a blocker
-	var blockedTasks []model.Task
+	var blockedTasks []model.TaskWithDate
 	for _, taskWithDate := range mostRecentTasks {
 		if taskWithDate.Blocker != "" {
-			blockedTasks = append(blockedTasks, taskWithDate.Task)
+			blockedTasks = append(blockedTasks, taskWithDate)
 		}
 	}
+	sort.SliceStable(blockedTasks, func(i, j int) bool {
+		if blockedTasks[i].Date != blockedTasks[j].Date {
+			return blockedTasks[i].Date < blockedTasks[j].Date
+		}
+		return ticketLess(blockedTasks[i].JiraTicket, blockedTasks[j].JiraTicket)
+	})
 
 	return model.CategorizedTasks{
 		Completed: completedTasks,
 		NextUp:    nextUpTasks,
 		Blocked:   blockedTasks,
+		ByQCGoal:  qcGoalTasks,
+		Stale:     opts.staleTasks(mostRecentTasks, dates),
+	}
+}
+
+// EmptyTaskError describes a completed task with no description, PR, or blocker.
+// Such tasks are left out of reports.
+type EmptyTaskError struct {
+	Date   string
+	Ticket string
+}
+
+func (e *EmptyTaskError) Error() string {
+	if e.Ticket == "" {
+		return fmt.Sprintf("completed task on %s has no description, PR, or blocker", e.Date)
+	}
+	return fmt.Sprintf("completed task %s on %s has no description, PR, or blocker", e.Ticket, e.Date)
+}
+
+// FindEmptyTasks returns an *EmptyTaskError for each completed task on the given
+// dates that CategorizeTasks skips for having nothing to show, in date order.
+func FindEmptyTasks(workData model.WorkData, dates []string, opts Options) []error {
+	sortedDates := append([]string(nil), dates...)
+	sort.Strings(sortedDates)
+
+	var errs []error
+	for _, date := range sortedDates {
+		for _, task := range workData[date].Tasks {
+			if task.Private && !opts.IncludePrivate {
+				continue
+			}
+			if strings.EqualFold(task.Status, model.StatusCompleted) && isEmptyTask(task) {
+				errs = append(errs, &EmptyTaskError{Date: date, Ticket: task.JiraTicket})
+			}
+		}
+	}
+	return errs
+}
+
+// isEmptyTask reports whether a task has no description, PR, or blocker.
+func isEmptyTask(task model.Task) bool {
+	return len(task.GetDescriptions()) == 0 && task.GithubPR == "" && task.Blocker == ""
+}
+
+// splitFeatureWork separates the ticket keys of a grouped task map into feature work and
+// non-feature work, each sorted by sortTickets.
+func (opts Options) splitFeatureWork(tasks map[string][]model.TaskWithDate) (featureTickets []string, nonFeatureTickets []string) {
+	if opts.FlatNonFeatureWork {
+		for ticket := range tasks {
+			featureTickets = append(featureTickets, ticket)
+		}
+		sortTickets(featureTickets)
+		return featureTickets, nil
+	}
+	for ticket, taskList := range tasks {
+		// Check if any task in the group has a PR (for NO-JIRA check)
+		prArg := ""
+		for _, t := range taskList {
+			if t.GithubPR != "" {
+				prArg = "has-pr"
+				break
+			}
+		}
+
+		if IsNonFeatureWork(ticket, prArg) {
+			nonFeatureTickets = append(nonFeatureTickets, ticket)
+		} else {
+			featureTickets = append(featureTickets, ticket)
+		}
+	}
+	sortTickets(featureTickets)
+	sortTickets(nonFeatureTickets)
+	return featureTickets, nonFeatureTickets
+}
+
+// isGroupedNonFeature reports whether a blocked task is listed under the
+// "Non-feature work" entry, which FlatNonFeatureWork turns off.
+func (opts Options) isGroupedNonFeature(task model.TaskWithDate) bool {
+	return !opts.FlatNonFeatureWork && IsNonFeatureWork(task.JiraTicket, task.GithubPR)
+}
+
+// isInlineEntry reports whether a ticket listed with the feature work has no
+// ticket to show, as non-feature work listed inline by FlatNonFeatureWork, so
+// its entry is headed by a description instead.
+func isInlineEntry(ticket string) bool {
+	return IsSyntheticKey(ticket) || ticket == ""
+}
+
+// inlineHeader returns the header of an inline entry, its first non-empty
+// description or "Misc", and the descriptions after it.
+func inlineHeader(descriptions ...string) (string, []string) {
+	for i, desc := range descriptions {
+		if desc != "" {
+			return desc, descriptions[i+1:]
+		}
+	}
+	return "Misc", nil
+}
+
+// staleTasks returns the most recent tasks that are still in progress more than
+// StaleAfter days before the last of dates, stalest first.
+func (opts Options) staleTasks(mostRecentTasks map[string]model.TaskWithDate, dates []string) []model.StaleTask {
+	if opts.StaleAfter <= 0 || len(dates) == 0 {
+		return nil
+	}
+	end, err := time.Parse("2006-01-02", dates[len(dates)-1])
+	if err != nil {
+		return nil
+	}
+
+	var stale []model.StaleTask
+	for _, taskWithDate := range mostRecentTasks {
+		if !strings.EqualFold(taskWithDate.Status, model.StatusInProgress) {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", taskWithDate.Date)
+		if err != nil {
+			continue
+		}
+		if days := int(end.Sub(date).Hours() / 24); days > opts.StaleAfter {
+			stale = append(stale, model.StaleTask{TaskWithDate: taskWithDate, DaysSince: days})
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		if stale[i].DaysSince != stale[j].DaysSince {
+			return stale[i].DaysSince > stale[j].DaysSince
+		}
+		return ticketLess(staleTicket(stale[i]), staleTicket(stale[j]))
+	})
+	return stale
+}
+
+// staleTicket returns the ticket of a stale task, its first description without
+// one, or "Misc" when it has neither.
+func staleTicket(task model.StaleTask) string {
+	if task.JiraTicket != "" {
+		return task.JiraTicket
+	}
+	if descriptions := task.GetDescriptions(); len(descriptions) > 0 {
+		return descriptions[0]
+	}
+	return "Misc"
+}
+
+// staleEntry describes how long a stale task has been in progress, e.g.
+// "PROJ-1: 10 days since 2024-08-01".
+func staleEntry(task model.StaleTask) string {
+	return fmt.Sprintf("%s: %d days since %s", staleTicket(task), task.DaysSince, task.Date)
+}
+
+// blockedTicket returns the ticket of a blocked task, or "Misc" without one. With
+// FlatNonFeatureWork a task without a ticket is listed inline, so it is headed by
+// its first description as the other inline entries are.
+func (opts Options) blockedTicket(task model.TaskWithDate) string {
+	if task.JiraTicket != "" {
+		return task.JiraTicket
+	}
+	if opts.FlatNonFeatureWork {
+		header, _ := inlineHeader(task.GetDescriptions()...)
+		return header
+	}
+	return "Misc"
+}
+
+// sortTickets sorts ticket keys naturally: JIRA keys by project and then by
+// number, so PROJ-2 comes before PROJ-10, followed by every other key (empty,
+// NO-JIRA, free text, tag and synthetic keys) in lexical order.
+func sortTickets(tickets []string) {
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return ticketLess(tickets[i], tickets[j])
+	})
+}
+
+// ticketLess reports whether ticket key a sorts before b under sortTickets.
+func ticketLess(a, b string) bool {
+	projectA, numberA, okA := parseTicketKey(a)
+	projectB, numberB, okB := parseTicketKey(b)
+	if okA != okB {
+		return okA
+	}
+	if okA {
+		if projectA != projectB {
+			return projectA < projectB
+		}
+		if numberA != numberB {
+			return numberA < numberB
+		}
+	}
+	return a < b
+}
+
+// parseTicketKey splits the JIRA ticket referenced by a ticket key into its
+// project and number. ok is false for keys that don't reference a ticket.
+func parseTicketKey(key string) (project string, number int, ok bool) {
+	if IsTagKey(key) {
+		return "", 0, false
+	}
+	id := jira.ExtractTicketID(key)
+	dash := strings.LastIndex(id, "-")
+	if dash < 0 {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(id[dash+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return id[:dash], number, true
+}
+
+// sortByDate sorts tasks chronologically (oldest to newest), keeping the logged
+// order of tasks on the same day.
+func sortByDate(taskList []model.TaskWithDate) {
+	sort.SliceStable(taskList, func(i, j int) bool {
+		return taskList[i].Date < taskList[j].Date
+	})
+}
+
+// collectDescriptionsAndPRs gathers all descriptions (in order) and unique PR links from a task list.
+// With CollapseCompleted, only the most recent description is kept.
+func (opts Options) collectDescriptionsAndPRs(taskList []model.TaskWithDate) ([]string, []string) {
+	var descriptions []string
+	for _, taskWithDate := range taskList {
+		descriptions = append(descriptions, taskWithDate.GetDescriptions()...)
+	}
+	if opts.CollapseCompleted && len(descriptions) > 1 {
+		descriptions = descriptions[len(descriptions)-1:]
+	}
+	return descriptions, opts.orderedPRLinks(taskList)
+}
+
+// latestNextUpDescription works backwards through a chronologically sorted task list to find
+// the most recent upnext description (falling back to the last task description), and gathers
+// unique PR links from every task.
+func (opts Options) latestNextUpDescription(taskList []model.TaskWithDate) (string, []string) {
+	var mostRecentDesc string
+	for i := len(taskList) - 1; i >= 0 && mostRecentDesc == ""; i-- {
+		taskWithDate := taskList[i]
+		if taskWithDate.UpnextDescription != "" {
+			mostRecentDesc = taskWithDate.UpnextDescription
+		} else {
+			allDescs := taskWithDate.GetDescriptions()
+			if len(allDescs) > 0 {
+				mostRecentDesc = allDescs[len(allDescs)-1]
+			}
+		}
+	}
+	return mostRecentDesc, opts.orderedPRLinks(taskList)
+}
+
+// orderedPRLinks returns the unique PR links of a chronologically sorted task
+// list, sorted by URL or, with PROrderChrono, in the order they were first seen.
+func (opts Options) orderedPRLinks(taskList []model.TaskWithDate) []string {
+	seen := make(map[string]bool)
+	var links []string
+	for _, taskWithDate := range taskList {
+		if taskWithDate.GithubPR == "" || seen[taskWithDate.GithubPR] {
+			continue
+		}
+		seen[taskWithDate.GithubPR] = true
+		links = append(links, taskWithDate.GithubPR)
+	}
+	if opts.PROrder != PROrderChrono {
+		sort.Strings(links)
+	}
+	return links
+}
+
+// blockedSince describes when a blocked task was last logged, followed by its
+// first description if it has one (e.g. "Since 2024-08-02: Implement parsing").
+func blockedSince(task model.TaskWithDate) string {
+	if descriptions := task.GetDescriptions(); len(descriptions) > 0 {
+		return fmt.Sprintf("Since %s: %s", task.Date, descriptions[0])
+	}
+	return "Since " + task.Date
+}
+
+// sortedGoals returns the QC goals in alphabetical order.
+func sortedGoals(byGoal map[string][]model.TaskWithDate) []string {
+	goals := make([]string, 0, len(byGoal))
+	for goal := range byGoal {
+		goals = append(goals, goal)
+	}
+	sort.Strings(goals)
+	return goals
+}
+
+// qcGoalEntries summarizes the tasks contributing to a QC goal in date order, one
+// line per distinct ticket and first description (e.g. "PROJ-1: Fixed login").
+func qcGoalEntries(taskList []model.TaskWithDate) []string {
+	sortByDate(taskList)
+
+	seen := make(map[string]bool)
+	var entries []string
+	for _, task := range taskList {
+		var parts []string
+		if task.JiraTicket != "" {
+			parts = append(parts, task.JiraTicket)
+		}
+		if descriptions := task.GetDescriptions(); len(descriptions) > 0 {
+			parts = append(parts, descriptions[0])
+		}
+		entry := strings.Join(parts, ": ")
+		if entry == "" || seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// sortedLinks returns the keys of a PR link set in sorted order.
+func sortedLinks(prLinks map[string]bool) []string {
+	var links []string
+	for link := range prLinks {
+		links = append(links, link)
 	}
+	sort.Strings(links)
+	return links
 }
@@ -0,0 +1,315 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestCategorizeTasksBlockedKeepsDate(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{{JiraTicket: "SCR-2", Status: model.StatusInProgress, Blocker: "Old blocker"}}},
+		"2024-08-02": {Tasks: []model.Task{{JiraTicket: "SCR-2", Status: model.StatusInProgress, Description: "Latest work", Blocker: "New blocker"}}},
+	}
+
+	tasks := CategorizeTasks(workData, []string{"2024-08-01", "2024-08-02"}, Options{})
+
+	if len(tasks.Blocked) != 1 {
+		t.Fatalf("Expected 1 blocked task, got %d", len(tasks.Blocked))
+	}
+	if got := tasks.Blocked[0]; got.Date != "2024-08-02" || got.Blocker != "New blocker" {
+		t.Errorf("Expected the most recent blocked task, got %+v", got)
+	}
+}
+
+func TestCategorizeTasksByQCGoal(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Fixed login", QCGoal: "Improve auth reliability"},
+			{JiraTicket: "PROJ-2", Status: model.StatusCompleted, Description: "Unrelated work"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-3", Status: model.StatusInProgress, Description: "Added retries", QCGoal: "Improve auth reliability"},
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Fixed login", QCGoal: "Improve auth reliability"},
+			{Status: model.StatusCompleted, Descriptions: []string{"Wrote runbook"}, QCGoal: "Reduce on-call load"},
+		}},
+	}
+
+	tasks := CategorizeTasks(workData, []string{"2024-08-01", "2024-08-02"}, Options{})
+
+	if len(tasks.ByQCGoal) != 2 {
+		t.Fatalf("Expected 2 QC goals, got %d: %+v", len(tasks.ByQCGoal), tasks.ByQCGoal)
+	}
+	if got := len(tasks.ByQCGoal["Improve auth reliability"]); got != 3 {
+		t.Errorf("Expected 3 tasks for auth goal, got %d", got)
+	}
+
+	var out bytes.Buffer
+	PrintQCGoals(&out, tasks.ByQCGoal, Options{})
+	expected := TextHeaderQCGoals + "\n" +
+		"    • Improve auth reliability\n" +
+		"        ◦ PROJ-1: Fixed login\n" +
+		"        ◦ PROJ-3: Added retries\n" +
+		"    • Reduce on-call load\n" +
+		"        ◦ Wrote runbook\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected QC goals output:\ngot:\n%q\nwant:\n%q", out.String(), expected)
+	}
+}
+
+func TestCategorizeTasksSkipsEmptyCompletedTasks(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "SCR-1", Status: model.StatusCompleted},
+			{JiraTicket: "SCR-2", Status: model.StatusCompleted, GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "SCR-3", Status: model.StatusCompleted, Descriptions: []string{"Wrote docs"}},
+			{Status: model.StatusCompleted},
+		}},
+	}
+	dates := []string{"2024-08-01"}
+
+	tasks := CategorizeTasks(workData, dates, Options{})
+
+	if _, exists := tasks.Completed["SCR-1"]; exists {
+		t.Errorf("Expected empty task SCR-1 to be skipped, got %+v", tasks.Completed["SCR-1"])
+	}
+	if len(tasks.Completed) != 2 {
+		t.Errorf("Expected 2 completed groups, got %d: %+v", len(tasks.Completed), tasks.Completed)
+	}
+
+	errs := FindEmptyTasks(workData, dates, Options{})
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 empty tasks, got %d: %v", len(errs), errs)
+	}
+	var emptyErr *EmptyTaskError
+	if !errors.As(errs[0], &emptyErr) || emptyErr.Ticket != "SCR-1" || emptyErr.Date != "2024-08-01" {
+		t.Errorf("Expected an EmptyTaskError for SCR-1, got %v", errs[0])
+	}
+	if got, want := errs[1].Error(), "completed task on 2024-08-01 has no description, PR, or blocker"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {
+			WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "10:00"}},
+			Tasks: []model.Task{
+				{JiraTicket: "PROJ-1", Description: "Reviewed PR", Tags: []string{"Review"}},
+				{JiraTicket: "PROJ-2", Description: "Standup", Tags: []string{"meeting"}},
+				{JiraTicket: "PROJ-3", Description: "Untagged"},
+			},
+		},
+	}
+
+	filtered := FilterTags(workData, []string{"review", "oncall"})
+
+	tasks := filtered["2024-08-01"].Tasks
+	if len(tasks) != 1 || tasks[0].JiraTicket != "PROJ-1" {
+		t.Errorf("Expected only the review task, got %+v", tasks)
+	}
+	if len(filtered["2024-08-01"].WorkLogEntries) != 1 {
+		t.Errorf("Expected work log entries to be kept")
+	}
+}
+
+func TestExcludeTicketsAndStatuses(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {
+			WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "10:00"}},
+			Tasks: []model.Task{
+				{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Noise"},
+				{JiraTicket: "https://issues.redhat.com/browse/PROJ-2", Status: model.StatusCompleted, Description: "More noise"},
+				{JiraTicket: "SCR-1", Status: model.StatusNotStarted, Description: "Someday"},
+				{JiraTicket: "SCR-2", Status: model.StatusInProgress, Description: "Parser"},
+				{Status: model.StatusCompleted, Description: "Team meeting"},
+			},
+		},
+	}
+
+	filtered := ExcludeTickets(workData, []string{"PROJ-1", "https://issues.redhat.com/browse/PROJ-2"})
+	if got := ticketsOf(filtered["2024-08-01"].Tasks); got != "SCR-1,SCR-2," {
+		t.Errorf("Expected the PROJ tickets to be excluded, got %q", got)
+	}
+	if len(filtered["2024-08-01"].WorkLogEntries) != 1 {
+		t.Errorf("Expected work log entries to be kept")
+	}
+
+	filtered = ExcludeStatuses(workData, []string{"Not Started", "completed"})
+	if got := ticketsOf(filtered["2024-08-01"].Tasks); got != "SCR-2" {
+		t.Errorf("Expected only the in progress task, got %q", got)
+	}
+
+	// Include filters run first, then exclusions
+	filtered = ExcludeStatuses(FilterTickets(workData, []string{"SCR-1", "SCR-2"}), []string{"not started"})
+	if got := ticketsOf(filtered["2024-08-01"].Tasks); got != "SCR-2" {
+		t.Errorf("Expected SCR-2 after including SCR tickets and excluding not started, got %q", got)
+	}
+}
+
+// ticketsOf joins the JIRA tickets of tasks with commas.
+func ticketsOf(tasks []model.Task) string {
+	var tickets []string
+	for _, task := range tasks {
+		tickets = append(tickets, task.JiraTicket)
+	}
+	return strings.Join(tickets, ",")
+}
+
+func TestGroupCompletedByTag(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Reviewed the parser", Tags: []string{"review"}},
+			{JiraTicket: "PROJ-2", Status: model.StatusCompleted, Description: "Paired on the fix", Tags: []string{"Review", "meeting"}},
+			{JiraTicket: "PROJ-3", Status: model.StatusCompleted, Description: "Shipped the feature"},
+		}},
+	}
+
+	tasks := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+	grouped := GroupCompletedByTag(tasks.Completed)
+
+	var out bytes.Buffer
+	PrintCompletedTasks(&out, grouped, nil, Options{})
+	// Tag groups sort after real tickets
+	expected := TextHeaderCompleted + "\n" +
+		"    • PROJ-3: \n" +
+		"        ◦ Shipped the feature\n" +
+		"    • #meeting: \n" +
+		"        ◦ PROJ-2: Paired on the fix\n" +
+		"    • #review: \n" +
+		"        ◦ PROJ-1: Reviewed the parser\n" +
+		"        ◦ PROJ-2: Paired on the fix\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected completed output:\ngot:\n%q\nwant:\n%q", out.String(), expected)
+	}
+}
+
+func TestSortTickets(t *testing.T) {
+	tickets := []string{
+		"PROJ-10",
+		"",
+		"NO-JIRA",
+		"https://issues.redhat.com/browse/ABC-7",
+		"PROJ-2",
+		"Team sync",
+		"ABC-12",
+		"__noticket_1",
+		"PROJ-1",
+	}
+	sortTickets(tickets)
+
+	expected := []string{
+		"https://issues.redhat.com/browse/ABC-7",
+		"ABC-12",
+		"PROJ-1",
+		"PROJ-2",
+		"PROJ-10",
+		"",
+		"NO-JIRA",
+		"Team sync",
+		"__noticket_1",
+	}
+	if !reflect.DeepEqual(tickets, expected) {
+		t.Errorf("sortTickets:\ngot:  %q\nwant: %q", tickets, expected)
+	}
+}
+
+func TestCategorizeTasksSkipsPrivate(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Shipped the fix"},
+			{JiraTicket: "HR-1", Status: model.StatusInProgress, Description: "Benefits enrollment", UpnextDescription: "Submit forms", Blocker: "Waiting on HR", Private: true},
+			{Status: model.StatusCompleted, Description: "Doctor appointment", Private: true},
+		}},
+	}
+	dates := []string{"2024-08-01"}
+
+	tasks := CategorizeTasks(workData, dates, Options{})
+	if len(tasks.Completed) != 1 || tasks.Completed["PROJ-1"] == nil {
+		t.Errorf("Expected only PROJ-1 in completed, got %v", tasks.Completed)
+	}
+	if len(tasks.NextUp) != 0 || len(tasks.Blocked) != 0 {
+		t.Errorf("Expected private tasks to be left out of next up and blocked, got %v and %v", tasks.NextUp, tasks.Blocked)
+	}
+
+	tasks = CategorizeTasks(workData, dates, Options{IncludePrivate: true})
+	if len(tasks.Completed) != 3 || len(tasks.NextUp) != 1 || len(tasks.Blocked) != 1 {
+		t.Errorf("Expected private tasks with IncludePrivate, got completed %d, next up %d, blocked %d",
+			len(tasks.Completed), len(tasks.NextUp), len(tasks.Blocked))
+	}
+}
+
+func TestCategorizeTasksMergeAcrossStatus(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusInProgress, Description: "Started the parser", UpnextDescription: "Finish the parser"},
+			{JiraTicket: "PROJ-2", Status: model.StatusCompleted, Description: "Drafted the design"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Finished the parser"},
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Reviewing feedback", UpnextDescription: "Address feedback"},
+		}},
+	}
+	dates := []string{"2024-08-01", "2024-08-02"}
+
+	tasks := CategorizeTasks(workData, dates, Options{})
+	if tasks.Completed["PROJ-1"] == nil || tasks.Completed["PROJ-2"] == nil || tasks.NextUp["PROJ-2"] == nil {
+		t.Fatalf("Expected PROJ-2 in both completed and next up by default, got completed %v and next up %v", tasks.Completed, tasks.NextUp)
+	}
+
+	tasks = CategorizeTasks(workData, dates, Options{MergeAcrossStatus: true})
+
+	// PROJ-1 was completed last, so it is only completed
+	if tasks.Completed["PROJ-1"] == nil || tasks.NextUp["PROJ-1"] != nil {
+		t.Errorf("Expected PROJ-1 only in completed, got completed %v and next up %v", tasks.Completed, tasks.NextUp)
+	}
+	// PROJ-2 is in progress again, so it is only next up
+	if tasks.Completed["PROJ-2"] != nil || tasks.NextUp["PROJ-2"] == nil {
+		t.Errorf("Expected PROJ-2 only in next up, got completed %v and next up %v", tasks.Completed, tasks.NextUp)
+	}
+}
+
+func TestCategorizeTasksStale(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusInProgress, Description: "Started the parser"},
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Started the cache"},
+			{JiraTicket: "PROJ-3", Status: model.StatusCompleted, Description: "Shipped the docs"},
+		}},
+		"2024-08-05": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Benchmarked the cache"},
+			{JiraTicket: "PROJ-4", Status: model.StatusInProgress, Description: "Sketched the API"},
+		}},
+		"2024-08-11": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-5", Status: model.StatusCompleted, Description: "Reviewed a PR"},
+		}},
+	}
+	dates := []string{"2024-08-01", "2024-08-05", "2024-08-11"}
+
+	if tasks := CategorizeTasks(workData, dates, Options{}); tasks.Stale != nil {
+		t.Fatalf("Expected no stale tickets without StaleAfter, got %+v", tasks.Stale)
+	}
+
+	tasks := CategorizeTasks(workData, dates, Options{StaleAfter: 7})
+
+	// PROJ-1 was last touched 10 days before the end of the range; PROJ-2 and
+	// PROJ-4 only 6, and PROJ-3 is completed
+	var got []string
+	for _, task := range tasks.Stale {
+		got = append(got, staleEntry(task))
+	}
+	want := []string{"PROJ-1: 10 days since 2024-08-01"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Stale = %q, want %q", got, want)
+	}
+
+	var out bytes.Buffer
+	PrintStaleTasks(&out, tasks.Stale, Options{})
+	if expected := TextHeaderStale + "\n    • PROJ-1: 10 days since 2024-08-01\n"; out.String() != expected {
+		t.Errorf("Expected text section %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"io"
+	"strings"
+)
+
+// ANSI escape sequences used by the text printers when writing to a ColorWriter.
+const (
+	ansiReset    = "\033[0m"
+	ansiHeader   = "\033[1;36m" // bold cyan
+	ansiComplete = "\033[32m"   // green
+	ansiNextUp   = "\033[33m"   // yellow
+	ansiBlocked  = "\033[31m"   // red
+	ansiDim      = "\033[2m"
+)
+
+// ColorWriter wraps a writer to turn on ANSI coloring in the text report
+// printers. Any other writer gets the exact plain output, so reports pasted into
+// Slack or saved to files never contain escape codes.
+type ColorWriter struct {
+	io.Writer
+}
+
+// NewColorWriter returns a ColorWriter that writes colored text reports to w.
+func NewColorWriter(w io.Writer) *ColorWriter {
+	return &ColorWriter{Writer: w}
+}
+
+// paint wraps text in the given color when out is a ColorWriter.
+func paint(out io.Writer, color, text string) string {
+	if _, ok := out.(*ColorWriter); !ok {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// paintHeader colors a section header, keeping its leading blank line uncolored.
+func paintHeader(out io.Writer, header string) string {
+	text := strings.TrimLeft(header, "\n")
+	return header[:len(header)-len(text)] + paint(out, ansiHeader, text)
+}
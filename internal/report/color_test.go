@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// ansiPattern matches the ANSI escape sequences used by the text printers.
+var ansiPattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func TestColorWriter(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Shipped", GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Started", UpnextDescription: "Finish", Blocker: "Waiting on review"},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+
+	printSections := func(out io.Writer) {
+		PrintCompletedTasks(out, tasks.Completed, nil, Options{})
+		PrintNextUpTasks(out, tasks.NextUp, nil, Options{})
+		PrintBlockedTasks(out, tasks.Blocked, Options{})
+	}
+
+	var plain, colored bytes.Buffer
+	printSections(&plain)
+	printSections(NewColorWriter(&colored))
+
+	if strings.Contains(plain.String(), "\033[") {
+		t.Errorf("Plain output contains escape codes:\n%q", plain.String())
+	}
+	for _, want := range []string{
+		"\n" + ansiHeader + strings.TrimPrefix(TextHeaderCompleted, "\n") + ansiReset + "\n",
+		"    • " + ansiComplete + "PROJ-1" + ansiReset + ": \n",
+		"    • " + ansiNextUp + "PROJ-2" + ansiReset + "\n",
+		"    • " + ansiBlocked + "PROJ-2" + ansiReset + " \n",
+		ansiDim + "PR(s): https://github.com/example/repo/pull/1" + ansiReset,
+	} {
+		if !strings.Contains(colored.String(), want) {
+			t.Errorf("Colored output missing %q:\n%q", want, colored.String())
+		}
+	}
+	if got := ansiPattern.ReplaceAllString(colored.String(), ""); got != plain.String() {
+		t.Errorf("Colored output differs from plain output beyond escape codes:\ngot:  %q\nwant: %q", got, plain.String())
+	}
+}
@@ -0,0 +1,251 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// Section headers for Confluence storage format output.
+const (
+	confluenceHeaderCompleted      = "<h2>🦀 Things I've been working on</h2>"
+	confluenceHeaderNextUp         = "<h2>⭐ Things I plan on working on next</h2>"
+	confluenceHeaderBlocked        = "<h2>🚫 Things that are blocking me</h2>"
+	confluenceHeaderQCGoals        = "<h2>🎯 Quarterly goals</h2>"
+	confluenceNonFeatureWorkHeader = "Non-feature work"
+)
+
+// confluenceBlockedStatus is a red status lozenge shown in front of each blocker.
+const confluenceBlockedStatus = `<ac:structured-macro ac:name="status"><ac:parameter ac:name="colour">Red</ac:parameter><ac:parameter ac:name="title">Blocked</ac:parameter></ac:structured-macro>`
+
+// ConfluenceInfoMacro wraps text in a Confluence info panel.
+func ConfluenceInfoMacro(text string) string {
+	return fmt.Sprintf(`<ac:structured-macro ac:name="info"><ac:rich-text-body><p>%s</p></ac:rich-text-body></ac:structured-macro>`, html.EscapeString(text))
+}
+
+// PrintConfluence prints the categorized tasks as Confluence storage format
+// (XHTML) to the writer. Unlike the Slack-oriented HTML, entries are nested
+// <ul> lists so the bullet levels survive a paste into the Confluence editor.
+// JIRA tickets and PRs are external links, with PRs labeled by their titles
+// from prInfo when known.
+func PrintConfluence(out io.Writer, tasks model.CategorizedTasks, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) {
+	printCompletedTasksConfluence(out, tasks.Completed, jiraInfo, prInfo, opts)
+	printNextUpTasksConfluence(out, tasks.NextUp, jiraInfo, prInfo, opts)
+	printBlockedTasksConfluence(out, tasks.Blocked, jiraInfo, opts)
+	printQCGoalsConfluence(out, tasks.ByQCGoal)
+	printStaleTasksConfluence(out, tasks.Stale)
+}
+
+// confluencePRLinks renders PR links as a list item with semicolon-separated links.
+func confluencePRLinks(prLinks []string, prInfo map[string]github.PRInfo) string {
+	var links []string
+	for _, link := range prLinks {
+		links = append(links, github.FormatPRHTML(link, prInfo))
+	}
+	return fmt.Sprintf("<li>PR(s): %s</li>", strings.Join(links, "; "))
+}
+
+// confluenceEntry renders a list item with its sub-items as a nested list.
+func confluenceEntry(label, items string) string {
+	if items == "" {
+		return fmt.Sprintf("<li>%s</li>\n", label)
+	}
+	return fmt.Sprintf("<li>%s<ul>%s</ul></li>\n", label, items)
+}
+
+// confluenceBlocker renders the blocker and blocked-since items of a blocked task.
+func confluenceBlocker(task model.TaskWithDate) string {
+	return fmt.Sprintf("<li>%s Blocker: %s</li>", confluenceBlockedStatus, html.EscapeString(task.Blocker)) + confluenceItems(blockedSince(task))
+}
+
+// confluenceItems renders text items as escaped list items.
+func confluenceItems(items ...string) string {
+	var sb strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&sb, "<li>%s</li>", html.EscapeString(item))
+	}
+	return sb.String()
+}
+
+// printCompletedTasksConfluence prints the completed tasks section as Confluence storage format.
+func printCompletedTasksConfluence(out io.Writer, tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+	fmt.Fprintln(out, sectionHeader(confluenceHeaderCompleted, html.EscapeString(opts.HeaderCompleted), "<h2>%s</h2>"))
+	fmt.Fprintln(out, "<ul>")
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
+
+	// Print feature work first
+	for _, ticket := range featureTickets {
+		taskList := tasks[ticket]
+		sortByDate(taskList)
+		descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+		heading := jira.FormatTicketConfluence(ticket, jiraInfo)
+		if isInlineEntry(ticket) {
+			var header string
+			header, descriptions = inlineHeader(descriptions...)
+			heading = html.EscapeString(header)
+		}
+		items := confluenceItems(opts.deduplicateDescriptions(descriptions)...)
+		if len(prLinks) > 0 {
+			items += confluencePRLinks(prLinks, prInfo)
+		}
+		fmt.Fprint(out, confluenceEntry("<strong>"+heading+"</strong>", items))
+	}
+
+	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTickets) > 0 {
+		fmt.Fprintf(out, "<li><strong>%s</strong><ul>\n", confluenceNonFeatureWorkHeader)
+		for _, ticket := range nonFeatureTickets {
+			taskList := tasks[ticket]
+			sortByDate(taskList)
+			descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+			// Determine header: for synthetic keys, use the first description
+			header := ticket
+			if IsSyntheticKey(ticket) || header == "" {
+				if len(descriptions) > 0 {
+					header = descriptions[0]
+					descriptions = descriptions[1:]
+				} else {
+					header = "Misc"
+				}
+			}
+			descriptions = opts.deduplicateDescriptions(descriptions)
+			sortDescriptions(descriptions)
+			items := confluenceItems(descriptions...)
+			if len(prLinks) > 0 {
+				items += confluencePRLinks(prLinks, prInfo)
+			}
+			fmt.Fprint(out, confluenceEntry(html.EscapeString(header), items))
+		}
+		fmt.Fprintln(out, "</ul></li>")
+	}
+	fmt.Fprintln(out, "</ul>")
+}
+
+// printNextUpTasksConfluence prints the next up tasks section as Confluence storage format.
+func printNextUpTasksConfluence(out io.Writer, nextUp map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) {
+	if len(nextUp) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+	fmt.Fprintln(out, sectionHeader(confluenceHeaderNextUp, html.EscapeString(opts.HeaderNextUp), "<h2>%s</h2>"))
+	fmt.Fprintln(out, "<ul>")
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(nextUp)
+
+	// Print feature work first
+	for _, ticket := range featureTickets {
+		taskList := nextUp[ticket]
+		sortByDate(taskList)
+		mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+		heading := jira.FormatTicketConfluence(ticket, jiraInfo)
+		if isInlineEntry(ticket) {
+			header, _ := inlineHeader(mostRecentDesc)
+			heading, mostRecentDesc = html.EscapeString(header), ""
+		}
+		var items string
+		if mostRecentDesc != "" {
+			items = confluenceItems(mostRecentDesc)
+		}
+		if len(prLinks) > 0 {
+			items += confluencePRLinks(prLinks, prInfo)
+		}
+		fmt.Fprint(out, confluenceEntry("<strong>"+heading+"</strong>", items))
+	}
+
+	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTickets) > 0 {
+		fmt.Fprintf(out, "<li><strong>%s</strong><ul>\n", confluenceNonFeatureWorkHeader)
+		for _, ticket := range nonFeatureTickets {
+			taskList := nextUp[ticket]
+			sortByDate(taskList)
+			mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+			// Determine header: for synthetic keys, use the upnext description
+			header := ticket
+			if IsSyntheticKey(ticket) || header == "" {
+				if mostRecentDesc != "" {
+					header = mostRecentDesc
+					mostRecentDesc = ""
+				} else {
+					header = "Misc"
+				}
+			}
+			var items string
+			if mostRecentDesc != "" {
+				items = confluenceItems(mostRecentDesc)
+			}
+			if len(prLinks) > 0 {
+				items += confluencePRLinks(prLinks, prInfo)
+			}
+			fmt.Fprint(out, confluenceEntry(html.EscapeString(header), items))
+		}
+		fmt.Fprintln(out, "</ul></li>")
+	}
+	fmt.Fprintln(out, "</ul>")
+}
+
+// printBlockedTasksConfluence prints the blocked tasks section as Confluence storage format.
+func printBlockedTasksConfluence(out io.Writer, blocked []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	if len(blocked) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+
+	// Separate feature work and non-feature work
+	var featureTasks []model.TaskWithDate
+	var nonFeatureTasks []model.TaskWithDate
+
+	for _, task := range blocked {
+		if opts.isGroupedNonFeature(task) {
+			nonFeatureTasks = append(nonFeatureTasks, task)
+		} else {
+			featureTasks = append(featureTasks, task)
+		}
+	}
+
+	fmt.Fprintln(out, sectionHeader(confluenceHeaderBlocked, html.EscapeString(opts.HeaderBlocked), "<h2>%s</h2>"))
+	fmt.Fprintln(out, "<ul>")
+
+	// Print feature work first
+	for _, task := range featureTasks {
+		fmt.Fprint(out, confluenceEntry("<strong>"+jira.FormatTicketConfluence(opts.blockedTicket(task), jiraInfo)+"</strong>", confluenceBlocker(task)))
+	}
+
+	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTasks) > 0 {
+		fmt.Fprintf(out, "<li><strong>%s</strong><ul>\n", confluenceNonFeatureWorkHeader)
+		for _, task := range nonFeatureTasks {
+			header := task.JiraTicket
+			if header == "" {
+				header = "Misc"
+			}
+			fmt.Fprint(out, confluenceEntry(html.EscapeString(header), confluenceBlocker(task)))
+		}
+		fmt.Fprintln(out, "</ul></li>")
+	}
+	fmt.Fprintln(out, "</ul>")
+}
+
+// printQCGoalsConfluence prints the quarterly goals section as Confluence storage format.
+func printQCGoalsConfluence(out io.Writer, byGoal map[string][]model.TaskWithDate) {
+	if len(byGoal) == 0 {
+		return
+	}
+	fmt.Fprintln(out, confluenceHeaderQCGoals)
+	fmt.Fprintln(out, "<ul>")
+
+	for _, goal := range sortedGoals(byGoal) {
+		fmt.Fprint(out, confluenceEntry("<strong>"+html.EscapeString(goal)+"</strong>", confluenceItems(qcGoalEntries(byGoal[goal])...)))
+	}
+	fmt.Fprintln(out, "</ul>")
+}
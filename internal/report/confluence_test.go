@@ -0,0 +1,57 @@
+package report
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestPrintConfluence(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "SCR-1", Status: model.StatusCompleted, Description: "Parse <tags> & entities", GithubPR: "https://github.com/example/repo/pull/1"},
+			{Status: model.StatusCompleted, Description: "Team sync"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "SCR-2", Status: model.StatusInProgress, Description: "Caching", UpnextDescription: "Benchmarks", Blocker: "Waiting on review"},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01", "2024-08-02"}, Options{})
+	jiraInfo := map[string]jira.TicketInfo{
+		"SCR-1": {Key: "SCR-1", Summary: `Parser "v2"`, URL: "https://issues.redhat.com/browse/SCR-1"},
+	}
+
+	var out strings.Builder
+	PrintConfluence(&out, tasks, jiraInfo, nil, Options{})
+	got := out.String()
+
+	expected := []string{
+		"<h2>🦀 Things I've been working on</h2>",
+		`<li><strong><a href="https://issues.redhat.com/browse/SCR-1">SCR-1: Parser &#34;v2&#34;</a></strong><ul><li>Parse &lt;tags&gt; &amp; entities</li><li>PR(s): <a href="https://github.com/example/repo/pull/1">https://github.com/example/repo/pull/1</a></li></ul></li>`,
+		"<li><strong>Non-feature work</strong><ul>\n<li>Team sync</li>\n</ul></li>",
+		`<li><strong><a href="https://issues.redhat.com/browse/SCR-2">SCR-2</a></strong><ul><li>Benchmarks</li></ul></li>`,
+		`<ac:parameter ac:name="title">Blocked</ac:parameter></ac:structured-macro> Blocker: Waiting on review</li><li>Since 2024-08-02: Caching</li>`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(got, want) {
+			t.Errorf("Confluence output missing %q\nGot:\n%s", want, got)
+		}
+	}
+
+	// Storage format is XHTML, so the output must be well-formed XML
+	decoder := xml.NewDecoder(strings.NewReader("<root>" + got + "</root>"))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Confluence output is not well-formed XML: %v\n%s", err, got)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// TicketChange describes how a JIRA ticket differs between two date ranges.
+type TicketChange struct {
+	Ticket         string   `json:"ticket"`
+	Status         string   `json:"status,omitempty"`
+	PreviousStatus string   `json:"previous_status,omitempty"`
+	NewPRs         []string `json:"new_prs,omitempty"`
+}
+
+// RangeDiff lists the JIRA tickets that changed between two date ranges, each
+// section sorted by ticket.
+type RangeDiff struct {
+	Appeared      []TicketChange `json:"appeared"`
+	Disappeared   []TicketChange `json:"disappeared"`
+	StatusChanged []TicketChange `json:"status_changed"`
+	NewPRs        []TicketChange `json:"new_prs"`
+}
+
+// ticketState is the latest known status and the PRs of a ticket within one range.
+type ticketState struct {
+	status string
+	date   string
+	prs    map[string]bool
+}
+
+// DiffCategorized compares the categorized tasks of two date ranges, a and b,
+// keyed by JIRA ticket ID. A ticket's status is the status of its most recently
+// logged task in the range. Tasks without a JIRA ticket are ignored.
+func DiffCategorized(a, b model.CategorizedTasks) RangeDiff {
+	before := ticketStates(a)
+	after := ticketStates(b)
+
+	diff := RangeDiff{
+		Appeared:      []TicketChange{},
+		Disappeared:   []TicketChange{},
+		StatusChanged: []TicketChange{},
+		NewPRs:        []TicketChange{},
+	}
+	for _, ticket := range sortedStateKeys(after) {
+		state := after[ticket]
+		previous, existed := before[ticket]
+		if !existed {
+			diff.Appeared = append(diff.Appeared, TicketChange{Ticket: ticket, Status: state.status})
+			continue
+		}
+		if !strings.EqualFold(state.status, previous.status) {
+			diff.StatusChanged = append(diff.StatusChanged, TicketChange{Ticket: ticket, Status: state.status, PreviousStatus: previous.status})
+		}
+		var newPRs []string
+		for _, pr := range sortedLinks(state.prs) {
+			if !previous.prs[pr] {
+				newPRs = append(newPRs, pr)
+			}
+		}
+		if len(newPRs) > 0 {
+			diff.NewPRs = append(diff.NewPRs, TicketChange{Ticket: ticket, Status: state.status, NewPRs: newPRs})
+		}
+	}
+	for _, ticket := range sortedStateKeys(before) {
+		if _, exists := after[ticket]; !exists {
+			diff.Disappeared = append(diff.Disappeared, TicketChange{Ticket: ticket, PreviousStatus: before[ticket].status})
+		}
+	}
+	return diff
+}
+
+// ticketStates collects the latest status and PRs of every JIRA ticket in the
+// completed, next up, and blocked categories. Tasks are visited in ticket order
+// so the result does not depend on map iteration; when a ticket has several
+// tasks on its latest date, the most advanced status wins (completed over in
+// progress over anything else).
+func ticketStates(categorized model.CategorizedTasks) map[string]*ticketState {
+	states := make(map[string]*ticketState)
+	add := func(task model.TaskWithDate) {
+		ticket := jira.ExtractTicketID(task.JiraTicket)
+		if ticket == "" {
+			return
+		}
+		state, exists := states[ticket]
+		if !exists {
+			state = &ticketState{prs: make(map[string]bool)}
+			states[ticket] = state
+		}
+		status := strings.ToLower(task.Status)
+		if task.Date > state.date || (task.Date == state.date && statusRank(status) >= statusRank(state.status)) {
+			state.status = status
+			state.date = task.Date
+		}
+		if task.GithubPR != "" {
+			state.prs[task.GithubPR] = true
+		}
+	}
+
+	for _, ticket := range sortedTaskKeys(categorized.Completed) {
+		for _, task := range categorized.Completed[ticket] {
+			add(task)
+		}
+	}
+	for _, ticket := range sortedTaskKeys(categorized.NextUp) {
+		for _, task := range categorized.NextUp[ticket] {
+			add(task)
+		}
+	}
+	for _, task := range categorized.Blocked {
+		add(task)
+	}
+	return states
+}
+
+// statusRank orders task statuses by progress, so that ticketStates can break
+// ties between tasks logged on the same date.
+func statusRank(status string) int {
+	switch status {
+	case model.StatusCompleted:
+		return 2
+	case model.StatusInProgress:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortedTaskKeys returns the tickets of a categorized task map in sortTickets order.
+func sortedTaskKeys(tasks map[string][]model.TaskWithDate) []string {
+	tickets := make([]string, 0, len(tasks))
+	for ticket := range tasks {
+		tickets = append(tickets, ticket)
+	}
+	sortTickets(tickets)
+	return tickets
+}
+
+// sortedStateKeys returns the tickets of a state map in sortTickets order.
+func sortedStateKeys(states map[string]*ticketState) []string {
+	tickets := make([]string, 0, len(states))
+	for ticket := range states {
+		tickets = append(tickets, ticket)
+	}
+	sortTickets(tickets)
+	return tickets
+}
@@ -0,0 +1,66 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestDiffCategorized(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Description: "Started", Status: "in progress"},
+			{JiraTicket: "PROJ-2", Description: "Reviewed", Status: "completed", GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "PROJ-3", Description: "Dropped", Status: "completed"},
+			{Description: "No ticket", Status: "completed"},
+		}},
+		"2024-08-08": {Tasks: []model.Task{
+			{JiraTicket: "https://issues.redhat.com/browse/PROJ-1", Description: "Finished", Status: "Completed"},
+			{JiraTicket: "PROJ-2", Description: "Follow-up", Status: "completed", GithubPR: "https://github.com/example/repo/pull/2"},
+			{JiraTicket: "PROJ-4", Status: "not started", UpnextDescription: "Investigate"},
+			{Description: "Other ticketless work", Status: "completed"},
+		}},
+	}
+
+	diff := DiffCategorized(CategorizeTasks(workData, []string{"2024-08-01"}, Options{}), CategorizeTasks(workData, []string{"2024-08-08"}, Options{}))
+
+	expected := RangeDiff{
+		Appeared:      []TicketChange{{Ticket: "PROJ-4", Status: "not started"}},
+		Disappeared:   []TicketChange{{Ticket: "PROJ-3", PreviousStatus: "completed"}},
+		StatusChanged: []TicketChange{{Ticket: "PROJ-1", Status: "completed", PreviousStatus: "in progress"}},
+		NewPRs:        []TicketChange{{Ticket: "PROJ-2", Status: "completed", NewPRs: []string{"https://github.com/example/repo/pull/2"}}},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("DiffCategorized = %+v, want %+v", diff, expected)
+	}
+}
+
+func TestDiffCategorizedNoChanges(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{{JiraTicket: "PROJ-1", Description: "Work", Status: "completed"}}},
+	}
+	categorized := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+
+	diff := DiffCategorized(categorized, categorized)
+	if len(diff.Appeared)+len(diff.Disappeared)+len(diff.StatusChanged)+len(diff.NewPRs) != 0 {
+		t.Errorf("Expected no changes, got %+v", diff)
+	}
+}
+
+func TestDiffCategorizedSameDateStatus(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{{JiraTicket: "PROJ-1", Description: "Started", Status: "in progress"}}},
+		"2024-08-08": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Description: "Finished", Status: "completed"},
+			{JiraTicket: "PROJ-1", Description: "Waiting", Status: "in progress", Blocker: "Needs review"},
+		}},
+	}
+	before := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+	after := CategorizeTasks(workData, []string{"2024-08-08"}, Options{})
+
+	expected := []TicketChange{{Ticket: "PROJ-1", Status: "completed", PreviousStatus: "in progress"}}
+	if diff := DiffCategorized(before, after); !reflect.DeepEqual(diff.StatusChanged, expected) {
+		t.Errorf("StatusChanged = %+v, want %+v", diff.StatusChanged, expected)
+	}
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// DailyDigest is a one-line summary of a single day's tasks, for pasting into a
+// time tracker's daily note.
+type DailyDigest struct {
+	Date          string         `json:"date"`
+	Tickets       []string       `json:"tickets"`
+	Tasks         int            `json:"tasks"`
+	TasksByStatus map[string]int `json:"tasks_by_status"`
+	PRs           int            `json:"prs"`
+	Summary       string         `json:"summary"`
+}
+
+// NewDailyDigest summarizes the tasks logged on date: the JIRA tickets (in
+// natural order) and distinct PRs of its report sections, and the count of every
+// task by status, compared case-insensitively. Tasks that don't make it into a
+// section, such as a not started task without an upnext description, are only
+// counted. Private tasks are skipped unless opts.IncludePrivate is set.
+func NewDailyDigest(workData model.WorkData, date string, opts Options) DailyDigest {
+	digest := DailyDigest{Date: date, Tickets: []string{}, TasksByStatus: make(map[string]int)}
+	for _, task := range workData[date].Tasks {
+		if task.Private && !opts.IncludePrivate {
+			continue
+		}
+		digest.Tasks++
+		digest.TasksByStatus[strings.ToLower(task.Status)]++
+	}
+
+	tasks := CategorizeTasks(workData, []string{date}, opts)
+	seen := make(map[string]bool)
+	for ticket := range CollectTickets(tasks) {
+		if id := jira.ExtractTicketID(ticket); id != "" && !seen[id] {
+			seen[id] = true
+			digest.Tickets = append(digest.Tickets, id)
+		}
+	}
+	sortTickets(digest.Tickets)
+	digest.PRs = len(CollectAllPRLinks(tasks))
+
+	digest.Summary = digestSentence(digest)
+	return digest
+}
+
+// digestSentence renders a digest as e.g. "Worked on PROJ-1, PROJ-2 (2 completed,
+// 1 in progress), opened 1 PR". Days without tickets count their tasks instead.
+func digestSentence(digest DailyDigest) string {
+	var sb strings.Builder
+	if len(digest.Tickets) > 0 {
+		sb.WriteString("Worked on " + strings.Join(digest.Tickets, ", "))
+	} else {
+		sb.WriteString(fmt.Sprintf("Worked on %d %s", digest.Tasks, plural(digest.Tasks, "task", "tasks")))
+	}
+
+	var counts []string
+	for _, status := range model.Statuses {
+		if n := digest.TasksByStatus[status]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	if len(counts) > 0 {
+		sb.WriteString(" (" + strings.Join(counts, ", ") + ")")
+	}
+
+	if digest.PRs > 0 {
+		sb.WriteString(fmt.Sprintf(", opened %d %s", digest.PRs, plural(digest.PRs, "PR", "PRs")))
+	}
+	return sb.String()
+}
+
+// plural returns singular when n is 1 and pluralForm otherwise.
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
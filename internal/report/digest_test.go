@@ -0,0 +1,46 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestNewDailyDigest(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-10", Status: model.StatusCompleted, Description: "Parser", GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "https://issues.redhat.com/browse/PROJ-2", Status: "In Progress", Description: "Caching", Blocker: "Review"},
+			{JiraTicket: "PROJ-10", Status: model.StatusCompleted, Description: "Fixes", GithubPR: "https://github.com/example/repo/pull/1"},
+			{Status: model.StatusNotStarted, Description: "Planning", UpnextDescription: "Plan the sprint", GithubPR: "https://github.com/example/repo/pull/2"},
+			{JiraTicket: "PROJ-4", Status: model.StatusNotStarted, Description: "Not in any section", GithubPR: "https://github.com/example/repo/pull/3"},
+			{JiraTicket: "PROJ-3", Status: model.StatusCompleted, Description: "Secret", Private: true},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{Status: model.StatusCompleted, Description: "Docs"},
+			{Status: model.StatusCompleted, Description: "Chores"},
+		}},
+	}
+
+	tests := []struct {
+		date    string
+		tickets int
+		tasks   int
+		want    string
+	}{
+		{"2024-08-01", 2, 5, "Worked on PROJ-2, PROJ-10 (2 completed, 1 in progress, 2 not started), opened 2 PRs"},
+		{"2024-08-02", 0, 2, "Worked on 2 tasks (2 completed)"},
+		{"2024-08-03", 0, 0, "Worked on 0 tasks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			digest := NewDailyDigest(workData, tt.date, Options{})
+			if digest.Summary != tt.want {
+				t.Errorf("Summary = %q, want %q", digest.Summary, tt.want)
+			}
+			if len(digest.Tickets) != tt.tickets || digest.Tasks != tt.tasks {
+				t.Errorf("Expected %d tickets and %d tasks, got %+v", tt.tickets, tt.tasks, digest)
+			}
+		})
+	}
+}
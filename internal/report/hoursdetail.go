@@ -0,0 +1,130 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// Section headers for the hours detail of each format.
+const (
+	TextHeaderHoursDetail       = "\n⏱️ Hours worked"
+	mdHeaderHoursDetail         = "## ⏱️ Hours worked"
+	adocHeaderHoursDetail       = "== ⏱️ Hours worked"
+	htmlHeaderHoursDetail       = "<h2>⏱️ Hours worked</h2>"
+	confluenceHeaderHoursDetail = "<h2>⏱️ Hours worked</h2>"
+)
+
+// DayHours is one day of work_log time ranges with the day's formatted total.
+type DayHours struct {
+	Date    string
+	Entries []model.WorkLog
+	Total   string
+}
+
+// NewHoursDetail pairs the work_log entries of each date with its total from
+// totals, formatted with formatTotal. Dates without entries are left out.
+func NewHoursDetail(workData model.WorkData, dates []string, totals map[string]time.Duration, formatTotal func(time.Duration) string) []DayHours {
+	var days []DayHours
+	for _, date := range dates {
+		entries := workData[date].WorkLogEntries
+		if len(entries) == 0 {
+			continue
+		}
+		days = append(days, DayHours{Date: date, Entries: entries, Total: formatTotal(totals[date])})
+	}
+	return days
+}
+
+// timeRange formats a work_log entry as "09:00-12:30", marking entries that end
+// on the next day.
+func timeRange(entry model.WorkLog) string {
+	if entry.NextDay {
+		return entry.StartTime + "-" + entry.EndTime + " (+1 day)"
+	}
+	return entry.StartTime + "-" + entry.EndTime
+}
+
+// dayRanges joins the time ranges of a day with commas, each followed by the
+// ticket the time was logged against, if any.
+func dayRanges(day DayHours) string {
+	ranges := make([]string, 0, len(day.Entries))
+	for _, entry := range day.Entries {
+		r := timeRange(entry)
+		if entry.Ticket != "" {
+			r += " " + entry.Ticket
+		}
+		ranges = append(ranges, r)
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// PrintHoursDetail prints each day's time ranges and total as a text section.
+func PrintHoursDetail(out io.Writer, days []DayHours) {
+	if len(days) == 0 {
+		return
+	}
+	fmt.Fprintln(out, paintHeader(out, TextHeaderHoursDetail))
+	for _, day := range days {
+		fmt.Fprintf(out, "    • %s: %s (total %s)\n", day.Date, dayRanges(day), day.Total)
+	}
+}
+
+// PrintHoursDetailMarkdown prints each day's time ranges and total as a Markdown section.
+func PrintHoursDetailMarkdown(out io.Writer, days []DayHours) {
+	if len(days) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", mdHeaderHoursDetail)
+	for _, day := range days {
+		fmt.Fprintf(out, "- **%s**: %s (total %s)\n", day.Date, dayRanges(day), day.Total)
+	}
+}
+
+// PrintHoursDetailAsciiDoc prints each day's time ranges and total as an AsciiDoc section.
+func PrintHoursDetailAsciiDoc(out io.Writer, days []DayHours) {
+	if len(days) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", adocHeaderHoursDetail)
+	for _, day := range days {
+		fmt.Fprintf(out, "* *%s*: %s (total %s)\n", day.Date, dayRanges(day), day.Total)
+	}
+}
+
+// PrintHoursDetailConfluence prints each day's time ranges and total as
+// Confluence storage format, with a table per day.
+func PrintHoursDetailConfluence(out io.Writer, days []DayHours) {
+	if len(days) == 0 {
+		return
+	}
+	fmt.Fprintln(out, confluenceHeaderHoursDetail)
+	fmt.Fprintln(out, hoursDetailTables(days))
+}
+
+// renderHoursDetailHTML renders each day's time ranges and total as a small table.
+func renderHoursDetailHTML(days []DayHours) string {
+	if len(days) == 0 {
+		return ""
+	}
+	return htmlHeaderHoursDetail + hoursDetailTables(days)
+}
+
+// hoursDetailTables renders a table per day with a row per work_log entry and
+// the day's total in the last row. The markup is valid XHTML, so it is shared by
+// the HTML and Confluence renderers.
+func hoursDetailTables(days []DayHours) string {
+	var sb strings.Builder
+	for _, day := range days {
+		fmt.Fprintf(&sb, `<table><thead><tr><th colspan="2">%s</th></tr><tr><th>Time</th><th>Ticket</th></tr></thead><tbody>`, html.EscapeString(day.Date))
+		for _, entry := range day.Entries {
+			fmt.Fprintf(&sb, `<tr><td>%s</td><td>%s</td></tr>`, html.EscapeString(timeRange(entry)), html.EscapeString(entry.Ticket))
+		}
+		fmt.Fprintf(&sb, `<tr><td><strong>Total</strong></td><td><strong>%s</strong></td></tr></tbody></table>`, html.EscapeString(day.Total))
+	}
+	return sb.String()
+}
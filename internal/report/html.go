@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/bryan-cox/taskledger/internal/github"
 	"github.com/bryan-cox/taskledger/internal/jira"
 	"github.com/bryan-cox/taskledger/internal/model"
 )
@@ -15,6 +16,7 @@ const (
 	htmlHeaderCompleted      = `<h2>🦀 Things I've been working on</h2>`
 	htmlHeaderNextUp         = `<h2>⭐ Things I plan on working on next</h2>`
 	htmlHeaderBlocked        = `<h2>🚫 Things that are blocking me</h2>`
+	htmlHeaderQCGoals        = `<h2>🎯 Quarterly goals</h2>`
 	htmlNonFeatureWorkHeader = `Non-feature work`
 )
 
@@ -25,43 +27,56 @@ const (
 	bulletL3 = `&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;- ` // Third-level bullet (descriptions under non-feature sub-entries)
 )
 
-// GenerateHTML creates an HTML version of the report.
+// GenerateHTML creates an HTML version of the report with the embedded default template.
 // If preloadedJiraInfo is provided (non-nil), it will be used instead of fetching from JIRA API.
-func GenerateHTML(dates []string, completedTasks map[string][]model.TaskWithDate, nextUpTasks map[string][]model.TaskWithDate, blockedTasks []model.Task, preloadedJiraInfo map[string]jira.TicketInfo) string {
-	// Use preloaded JIRA info if provided, otherwise fetch from API
-	var jiraInfo map[string]jira.TicketInfo
-	if preloadedJiraInfo != nil {
-		jiraInfo = preloadedJiraInfo
-	} else {
-		allTickets := collectAllTickets(completedTasks, nextUpTasks, blockedTasks)
-		jiraInfo = jira.ProcessTickets(allTickets)
-	}
-
-	var htmlBuilder strings.Builder
-
-	// HTML document header
-	htmlBuilder.WriteString(`<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-</head>
-<body>`)
-
-	// Title
-	htmlBuilder.WriteString(fmt.Sprintf(`<h1>Work Report (%s to %s)</h1>`, dates[0], dates[len(dates)-1]))
-	htmlBuilder.WriteString(`<p><em>Autogenerated by TaskLedger</em></p>`)
-
-	// Render each section
-	htmlBuilder.WriteString(renderCompletedTasksHTML(completedTasks, jiraInfo))
-	htmlBuilder.WriteString(renderNextUpTasksHTML(nextUpTasks, jiraInfo))
-	htmlBuilder.WriteString(renderBlockedTasksHTML(blockedTasks, jiraInfo))
-
-	htmlBuilder.WriteString(`</body></html>`)
-	return htmlBuilder.String()
+// PR links are annotated with their state from prInfo when available; a nil prInfo renders plain links.
+// Themes other than ThemePlain add a stylesheet to the document head.
+func GenerateHTML(dates []string, tasks model.CategorizedTasks, preloadedJiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, theme string, opts Options) string {
+	content, err := GenerateHTMLWithTemplate(defaultHTMLTemplate, dates, tasks, preloadedJiraInfo, prInfo, theme, opts)
+	if err != nil {
+		// The default template only calls the section renderers, which cannot fail
+		panic(err)
+	}
+	return content
+}
+
+// CollectTickets gathers all JIRA ticket references from categorized tasks, keyed by ticket reference.
+func CollectTickets(tasks model.CategorizedTasks) map[string][]model.TaskWithDate {
+	return collectAllTickets(tasks.Completed, tasks.NextUp, tasks.Blocked)
+}
+
+// CollectPRLinks gathers all GitHub PR links referenced by completed and next up tasks.
+func CollectPRLinks(tasks model.CategorizedTasks) []string {
+	seen := make(map[string]bool)
+	for _, group := range []map[string][]model.TaskWithDate{tasks.Completed, tasks.NextUp} {
+		for _, taskList := range group {
+			for _, task := range taskList {
+				if task.GithubPR != "" {
+					seen[task.GithubPR] = true
+				}
+			}
+		}
+	}
+	return sortedLinks(seen)
+}
+
+// CollectAllPRLinks gathers the GitHub PR links referenced by any task in the
+// report, including blocked ones, deduplicated and sorted.
+func CollectAllPRLinks(tasks model.CategorizedTasks) []string {
+	seen := make(map[string]bool)
+	for _, link := range CollectPRLinks(tasks) {
+		seen[link] = true
+	}
+	for _, task := range tasks.Blocked {
+		if task.GithubPR != "" {
+			seen[task.GithubPR] = true
+		}
+	}
+	return sortedLinks(seen)
 }
 
 // collectAllTickets gathers all JIRA ticket references from categorized tasks.
-func collectAllTickets(completed map[string][]model.TaskWithDate, nextUp map[string][]model.TaskWithDate, blocked []model.Task) map[string][]model.TaskWithDate {
+func collectAllTickets(completed map[string][]model.TaskWithDate, nextUp map[string][]model.TaskWithDate, blocked []model.TaskWithDate) map[string][]model.TaskWithDate {
 	allTickets := make(map[string][]model.TaskWithDate)
 
 	for ticket, tasks := range completed {
@@ -72,15 +87,16 @@ func collectAllTickets(completed map[string][]model.TaskWithDate, nextUp map[str
 	}
 	for _, task := range blocked {
 		if task.JiraTicket != "" {
-			allTickets[task.JiraTicket] = []model.TaskWithDate{{Task: task}}
+			allTickets[task.JiraTicket] = []model.TaskWithDate{task}
 		}
 	}
 	return allTickets
 }
 
-// deduplicateDescriptions removes duplicate descriptions and upgrades "Commented on X" to "Reviewed X"
-// when both exist for the same URL.
-func deduplicateDescriptions(descriptions []string) []string {
+// deduplicateDescriptions removes duplicate descriptions, keeping first-seen order, and upgrades
+// "Commented on X" to "Reviewed X" when both exist for the same URL. With CountDuplicates set,
+// repeated descriptions are annotated with how often they were logged, e.g. "code review (x3)".
+func (opts Options) deduplicateDescriptions(descriptions []string) []string {
 	// Track which URLs have been "Reviewed" vs "Commented on"
 	reviewedURLs := make(map[string]bool)
 	for _, desc := range descriptions {
@@ -90,7 +106,7 @@ func deduplicateDescriptions(descriptions []string) []string {
 		}
 	}
 
-	seen := make(map[string]bool)
+	counts := make(map[string]int)
 	var result []string
 	for _, desc := range descriptions {
 		// If "Commented on X" and we also have "Reviewed X", skip the comment
@@ -100,10 +116,18 @@ func deduplicateDescriptions(descriptions []string) []string {
 				continue
 			}
 		}
-		if !seen[desc] {
-			seen[desc] = true
+		if counts[desc] == 0 {
 			result = append(result, desc)
 		}
+		counts[desc]++
+	}
+
+	if opts.CountDuplicates {
+		for i, desc := range result {
+			if counts[desc] > 1 {
+				result[i] = fmt.Sprintf("%s (x%d)", desc, counts[desc])
+			}
+		}
 	}
 	return result
 }
@@ -131,16 +155,12 @@ func sortDescriptions(descriptions []string) {
 
 
 // renderPRLinksInline renders PR links as inline text with a <br/> prefix and bullet character.
-func renderPRLinksInline(prLinks map[string]bool, bullet string) string {
+func renderPRLinksInline(prLinks []string, bullet string, prInfo map[string]github.PRInfo) string {
 	if len(prLinks) == 0 {
 		return ""
 	}
 
-	var links []string
-	for link := range prLinks {
-		links = append(links, link)
-	}
-	sort.Strings(links)
+	links := prLinks
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf(`<br/>%sPR(s): `, bullet))
@@ -148,59 +168,34 @@ func renderPRLinksInline(prLinks map[string]bool, bullet string) string {
 		if i > 0 {
 			sb.WriteString("; ")
 		}
-		sb.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(link), html.EscapeString(link)))
+		sb.WriteString(github.FormatPRHTML(link, prInfo))
 	}
 	return sb.String()
 }
 
 // renderCompletedTasksHTML renders the completed tasks section as HTML.
-func renderCompletedTasksHTML(tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo) string {
-	if len(tasks) == 0 {
+func renderCompletedTasksHTML(tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) string {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString(htmlHeaderCompleted)
+	sb.WriteString(sectionHeader(htmlHeaderCompleted, html.EscapeString(opts.HeaderCompleted), "<h2>%s</h2>"))
 	sb.WriteString(`<ul>`)
 
 	// Separate feature work and non-feature work
-	var featureTickets []string
-	var nonFeatureTickets []string
-
-	for ticket := range tasks {
-		taskList := tasks[ticket]
-		hasPR := false
-		for _, t := range taskList {
-			if t.GithubPR != "" {
-				hasPR = true
-				break
-			}
-		}
-
-		prArg := ""
-		if hasPR {
-			prArg = "has-pr"
-		}
-
-		if IsNonFeatureWork(ticket, prArg) {
-			nonFeatureTickets = append(nonFeatureTickets, ticket)
-		} else {
-			featureTickets = append(featureTickets, ticket)
-		}
-	}
-	sort.Strings(featureTickets)
-	sort.Strings(nonFeatureTickets)
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
 
 	// Render feature work first
 	for _, ticket := range featureTickets {
-		sb.WriteString(renderTicketEntryHTML(ticket, tasks[ticket], jiraInfo))
+		sb.WriteString(renderTicketEntryHTML(ticket, tasks[ticket], jiraInfo, prInfo, opts))
 	}
 
 	// Render non-feature work grouped under "Non-feature work"
 	if len(nonFeatureTickets) > 0 {
 		sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, htmlNonFeatureWorkHeader))
 		for _, ticket := range nonFeatureTickets {
-			sb.WriteString(renderNonFeatureSubEntryHTML(ticket, tasks[ticket]))
+			sb.WriteString(renderNonFeatureSubEntryHTML(ticket, tasks[ticket], prInfo, opts))
 		}
 		sb.WriteString(`</li>`)
 	}
@@ -210,49 +205,36 @@ func renderCompletedTasksHTML(tasks map[string][]model.TaskWithDate, jiraInfo ma
 }
 
 // renderTicketEntryHTML renders a single ticket entry with descriptions and PRs as inline <br/> items.
-func renderTicketEntryHTML(ticket string, taskList []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo) string {
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
+func renderTicketEntryHTML(ticket string, taskList []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) string {
+	sortByDate(taskList)
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, jira.FormatTicketHTML(ticket, jiraInfo)))
+	descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
 
-	var descriptions []string
-	prLinks := make(map[string]bool)
-
-	for _, taskWithDate := range taskList {
-		descriptions = append(descriptions, taskWithDate.GetDescriptions()...)
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
+	heading := jira.FormatTicketHTML(ticket, jiraInfo, opts.ShowJiraStatus)
+	if isInlineEntry(ticket) {
+		var header string
+		header, descriptions = inlineHeader(descriptions...)
+		heading = html.EscapeString(header)
 	}
 
-	descriptions = deduplicateDescriptions(descriptions)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, heading))
+
+	descriptions = opts.deduplicateDescriptions(descriptions)
 	for _, desc := range descriptions {
 		sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL2, html.EscapeString(desc)))
 	}
-	sb.WriteString(renderPRLinksInline(prLinks, bulletL2))
+	sb.WriteString(renderPRLinksInline(prLinks, bulletL2, prInfo))
 	sb.WriteString(`</li>`)
 
 	return sb.String()
 }
 
 // renderNonFeatureSubEntryHTML renders a non-feature work sub-entry using <br/> for Slack compatibility.
-func renderNonFeatureSubEntryHTML(ticket string, taskList []model.TaskWithDate) string {
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
+func renderNonFeatureSubEntryHTML(ticket string, taskList []model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) string {
+	sortByDate(taskList)
 
-	var descriptions []string
-	prLinks := make(map[string]bool)
-
-	for _, taskWithDate := range taskList {
-		descriptions = append(descriptions, taskWithDate.GetDescriptions()...)
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
-	}
+	descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
 
 	var sb strings.Builder
 
@@ -268,64 +250,39 @@ func renderNonFeatureSubEntryHTML(ticket string, taskList []model.TaskWithDate)
 	}
 	sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL2, html.EscapeString(header)))
 
-	descriptions = deduplicateDescriptions(descriptions)
+	descriptions = opts.deduplicateDescriptions(descriptions)
 	sortDescriptions(descriptions)
 	for _, desc := range descriptions {
 		sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL3, html.EscapeString(desc)))
 	}
-	sb.WriteString(renderPRLinksInline(prLinks, bulletL3))
+	sb.WriteString(renderPRLinksInline(prLinks, bulletL3, prInfo))
 
 	return sb.String()
 }
 
 // renderNextUpTasksHTML renders the next up tasks section as HTML.
-func renderNextUpTasksHTML(tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo) string {
-	if len(tasks) == 0 {
+func renderNextUpTasksHTML(tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) string {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString(htmlHeaderNextUp)
+	sb.WriteString(sectionHeader(htmlHeaderNextUp, html.EscapeString(opts.HeaderNextUp), "<h2>%s</h2>"))
 	sb.WriteString(`<ul>`)
 
 	// Separate feature work and non-feature work
-	var featureTickets []string
-	var nonFeatureTickets []string
-
-	for ticket := range tasks {
-		taskList := tasks[ticket]
-		hasPR := false
-		for _, t := range taskList {
-			if t.GithubPR != "" {
-				hasPR = true
-				break
-			}
-		}
-
-		prArg := ""
-		if hasPR {
-			prArg = "has-pr"
-		}
-
-		if IsNonFeatureWork(ticket, prArg) {
-			nonFeatureTickets = append(nonFeatureTickets, ticket)
-		} else {
-			featureTickets = append(featureTickets, ticket)
-		}
-	}
-	sort.Strings(featureTickets)
-	sort.Strings(nonFeatureTickets)
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
 
 	// Render feature work first
 	for _, ticket := range featureTickets {
-		sb.WriteString(renderNextUpTicketEntryHTML(ticket, tasks[ticket], jiraInfo))
+		sb.WriteString(renderNextUpTicketEntryHTML(ticket, tasks[ticket], jiraInfo, prInfo, opts))
 	}
 
 	// Render non-feature work grouped under "Non-feature work"
 	if len(nonFeatureTickets) > 0 {
 		sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, htmlNonFeatureWorkHeader))
 		for _, ticket := range nonFeatureTickets {
-			sb.WriteString(renderNonFeatureNextUpSubEntryHTML(ticket, tasks[ticket]))
+			sb.WriteString(renderNonFeatureNextUpSubEntryHTML(ticket, tasks[ticket], prInfo, opts))
 		}
 		sb.WriteString(`</li>`)
 	}
@@ -335,68 +292,34 @@ func renderNextUpTasksHTML(tasks map[string][]model.TaskWithDate, jiraInfo map[s
 }
 
 // renderNextUpTicketEntryHTML renders a single next up ticket entry using inline <br/>.
-func renderNextUpTicketEntryHTML(ticket string, taskList []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo) string {
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
+func renderNextUpTicketEntryHTML(ticket string, taskList []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, opts Options) string {
+	sortByDate(taskList)
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, jira.FormatTicketHTML(ticket, jiraInfo)))
-
-	var mostRecentDesc string
-	prLinks := make(map[string]bool)
-
-	for i := len(taskList) - 1; i >= 0; i-- {
-		taskWithDate := taskList[i]
-		if mostRecentDesc == "" {
-			if taskWithDate.UpnextDescription != "" {
-				mostRecentDesc = taskWithDate.UpnextDescription
-			} else {
-				allDescs := taskWithDate.GetDescriptions()
-				if len(allDescs) > 0 {
-					mostRecentDesc = allDescs[len(allDescs)-1]
-				}
-			}
-		}
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
+	mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+	heading := jira.FormatTicketHTML(ticket, jiraInfo, opts.ShowJiraStatus)
+	if isInlineEntry(ticket) {
+		header, _ := inlineHeader(mostRecentDesc)
+		heading, mostRecentDesc = html.EscapeString(header), ""
 	}
 
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, heading))
+
 	if mostRecentDesc != "" {
 		sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL2, html.EscapeString(mostRecentDesc)))
 	}
-	sb.WriteString(renderPRLinksInline(prLinks, bulletL2))
+	sb.WriteString(renderPRLinksInline(prLinks, bulletL2, prInfo))
 	sb.WriteString(`</li>`)
 
 	return sb.String()
 }
 
 // renderNonFeatureNextUpSubEntryHTML renders a non-feature next up sub-entry using <br/>.
-func renderNonFeatureNextUpSubEntryHTML(ticket string, taskList []model.TaskWithDate) string {
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
+func renderNonFeatureNextUpSubEntryHTML(ticket string, taskList []model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) string {
+	sortByDate(taskList)
 
-	var mostRecentDesc string
-	prLinks := make(map[string]bool)
-
-	for i := len(taskList) - 1; i >= 0; i-- {
-		taskWithDate := taskList[i]
-		if mostRecentDesc == "" {
-			if taskWithDate.UpnextDescription != "" {
-				mostRecentDesc = taskWithDate.UpnextDescription
-			} else {
-				allDescs := taskWithDate.GetDescriptions()
-				if len(allDescs) > 0 {
-					mostRecentDesc = allDescs[len(allDescs)-1]
-				}
-			}
-		}
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
-	}
+	mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
 
 	var sb strings.Builder
 
@@ -415,23 +338,23 @@ func renderNonFeatureNextUpSubEntryHTML(ticket string, taskList []model.TaskWith
 	if mostRecentDesc != "" {
 		sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL3, html.EscapeString(mostRecentDesc)))
 	}
-	sb.WriteString(renderPRLinksInline(prLinks, bulletL3))
+	sb.WriteString(renderPRLinksInline(prLinks, bulletL3, prInfo))
 
 	return sb.String()
 }
 
 // renderBlockedTasksHTML renders the blocked tasks section as HTML.
-func renderBlockedTasksHTML(tasks []model.Task, jiraInfo map[string]jira.TicketInfo) string {
-	if len(tasks) == 0 {
+func renderBlockedTasksHTML(tasks []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) string {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
 		return ""
 	}
 
 	// Separate feature work and non-feature work
-	var featureTasks []model.Task
-	var nonFeatureTasks []model.Task
+	var featureTasks []model.TaskWithDate
+	var nonFeatureTasks []model.TaskWithDate
 
 	for _, task := range tasks {
-		if IsNonFeatureWork(task.JiraTicket, task.GithubPR) {
+		if opts.isGroupedNonFeature(task) {
 			nonFeatureTasks = append(nonFeatureTasks, task)
 		} else {
 			featureTasks = append(featureTasks, task)
@@ -439,13 +362,14 @@ func renderBlockedTasksHTML(tasks []model.Task, jiraInfo map[string]jira.TicketI
 	}
 
 	var sb strings.Builder
-	sb.WriteString(htmlHeaderBlocked)
+	sb.WriteString(sectionHeader(htmlHeaderBlocked, html.EscapeString(opts.HeaderBlocked), "<h2>%s</h2>"))
 	sb.WriteString(`<ul>`)
 
 	// Render feature work first
 	for _, task := range featureTasks {
-		sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, jira.FormatTicketHTML(task.JiraTicket, jiraInfo)))
+		sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, jira.FormatTicketHTML(opts.blockedTicket(task), jiraInfo, opts.ShowJiraStatus)))
 		sb.WriteString(fmt.Sprintf(`<br/>%sBlocker: %s`, bulletL2, html.EscapeString(task.Blocker)))
+		sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL2, html.EscapeString(blockedSince(task))))
 		sb.WriteString(`</li>`)
 	}
 
@@ -459,6 +383,7 @@ func renderBlockedTasksHTML(tasks []model.Task, jiraInfo map[string]jira.TicketI
 			}
 			sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL2, html.EscapeString(header)))
 			sb.WriteString(fmt.Sprintf(`<br/>&nbsp;&nbsp;&nbsp;%sBlocker: %s`, bulletL3, html.EscapeString(task.Blocker)))
+			sb.WriteString(fmt.Sprintf(`<br/>&nbsp;&nbsp;&nbsp;%s%s`, bulletL3, html.EscapeString(blockedSince(task))))
 		}
 		sb.WriteString(`</li>`)
 	}
@@ -466,3 +391,23 @@ func renderBlockedTasksHTML(tasks []model.Task, jiraInfo map[string]jira.TicketI
 	sb.WriteString(`</ul>`)
 	return sb.String()
 }
+
+// renderQCGoalsHTML renders the quarterly goals section as HTML.
+func renderQCGoalsHTML(byGoal map[string][]model.TaskWithDate) string {
+	if len(byGoal) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(htmlHeaderQCGoals)
+	sb.WriteString(`<ul>`)
+	for _, goal := range sortedGoals(byGoal) {
+		sb.WriteString(fmt.Sprintf(`<li><strong>%s</strong>`, html.EscapeString(goal)))
+		for _, entry := range qcGoalEntries(byGoal[goal]) {
+			sb.WriteString(fmt.Sprintf(`<br/>%s%s`, bulletL2, html.EscapeString(entry)))
+		}
+		sb.WriteString(`</li>`)
+	}
+	sb.WriteString(`</ul>`)
+	return sb.String()
+}
@@ -0,0 +1,248 @@
+package report
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestRenderBlockedTasksHTML(t *testing.T) {
+	blocked := []model.TaskWithDate{
+		{
+			Date: "2024-08-02",
+			Task: model.Task{JiraTicket: "SCR-2", Description: "Implement <parsing>", Blocker: "Waiting on review"},
+		},
+		{
+			Date: "2024-08-03",
+			Task: model.Task{Blocker: "Need access"},
+		},
+	}
+
+	got := renderBlockedTasksHTML(blocked, map[string]jira.TicketInfo{}, Options{})
+
+	expected := []string{
+		`Blocker: Waiting on review`,
+		bulletL2 + `Since 2024-08-02: Implement &lt;parsing&gt;`,
+		`Blocker: Need access`,
+		bulletL3 + `Since 2024-08-03`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(got, want) {
+			t.Errorf("Blocked HTML missing %q\nGot:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderQCGoalsHTML(t *testing.T) {
+	byGoal := map[string][]model.TaskWithDate{
+		"Ship <auth>": {{Date: "2024-08-01", Task: model.Task{JiraTicket: "PROJ-1", Description: "Fixed login"}}},
+	}
+
+	got := renderQCGoalsHTML(byGoal)
+
+	want := htmlHeaderQCGoals + `<ul><li><strong>Ship &lt;auth&gt;</strong><br/>` + bulletL2 + `PROJ-1: Fixed login</li></ul>`
+	if got != want {
+		t.Errorf("Unexpected QC goals HTML:\ngot:  %s\nwant: %s", got, want)
+	}
+	if renderQCGoalsHTML(nil) != "" {
+		t.Error("Expected no section without QC goals")
+	}
+}
+
+func TestGenerateHTMLIncludesAllDescriptions(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{{
+			JiraTicket:   "PROJ-1",
+			Status:       model.StatusCompleted,
+			Description:  "Wrote the parser",
+			Descriptions: []string{"Added parser tests", "Documented the format"},
+		}}},
+	}
+	dates := []string{"2024-08-01"}
+
+	got := GenerateHTML(dates, CategorizeTasks(workData, dates, Options{}), map[string]jira.TicketInfo{}, nil, ThemePlain, Options{})
+
+	first := strings.Index(got, "Wrote the parser")
+	second := strings.Index(got, "Added parser tests")
+	third := strings.Index(got, "Documented the format")
+	if first < 0 || second < 0 || third < 0 {
+		t.Fatalf("Expected every description in the HTML, got:\n%s", got)
+	}
+	if !(first < second && second < third) {
+		t.Errorf("Expected descriptions in logged order, got:\n%s", got)
+	}
+}
+
+func TestGenerateHTMLTheme(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Fixed login"}}},
+	}
+	dates := []string{"2024-08-01"}
+	tasks := CategorizeTasks(workData, dates, Options{})
+
+	if got := GenerateHTML(dates, tasks, map[string]jira.TicketInfo{}, nil, ThemePlain, Options{}); strings.Contains(got, "<style>") {
+		t.Errorf("Expected no stylesheet for the plain theme, got:\n%s", got)
+	}
+	for _, theme := range []string{ThemeLight, ThemeDark} {
+		got := GenerateHTML(dates, tasks, map[string]jira.TicketInfo{}, nil, theme, Options{})
+		if !strings.Contains(got, "<style>") || !strings.Contains(got, "a:hover") {
+			t.Errorf("Expected a stylesheet for the %s theme, got:\n%s", theme, got)
+		}
+	}
+	if GenerateHTML(dates, tasks, map[string]jira.TicketInfo{}, nil, ThemeLight, Options{}) == GenerateHTML(dates, tasks, map[string]jira.TicketInfo{}, nil, ThemeDark, Options{}) {
+		t.Error("Expected the light and dark themes to differ")
+	}
+	if IsValidTheme("solarized") {
+		t.Error("Expected an unknown theme to be invalid")
+	}
+}
+
+func TestPRTitleLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/example/repo/pulls/123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"title": "Fix [caching] bug", "state": "closed", "merged": true}`)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	github.APIBaseURL = server.URL
+	t.Cleanup(func() { github.APIBaseURL = github.DefaultAPIBaseURL })
+
+	resolved := "https://github.com/example/repo/pull/123"
+	unresolved := "https://github.com/example/repo/pull/404"
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "SCR-1", Description: "Caching", Status: model.StatusCompleted, GithubPR: resolved},
+			{JiraTicket: "SCR-2", Description: "Other", Status: model.StatusCompleted, GithubPR: unresolved},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+	prInfo := github.ProcessPRs(CollectPRLinks(tasks))
+
+	var text strings.Builder
+	PrintCompletedTasks(&text, tasks.Completed, prInfo, Options{})
+	if !strings.Contains(text.String(), "PR(s): repo#123: Fix [caching] bug") {
+		t.Errorf("Expected a titled PR label in the text report, got:\n%s", text.String())
+	}
+	if !strings.Contains(text.String(), "PR(s): "+unresolved) {
+		t.Errorf("Expected an unresolved PR to fall back to its URL, got:\n%s", text.String())
+	}
+
+	var markdown strings.Builder
+	PrintMarkdown(&markdown, tasks, map[string]jira.TicketInfo{}, prInfo, Options{})
+	if !strings.Contains(markdown.String(), `[repo#123: Fix \[caching\] bug](`+resolved+`)`) {
+		t.Errorf("Expected a titled Markdown link to the full URL, got:\n%s", markdown.String())
+	}
+
+	html := GenerateHTML([]string{"2024-08-01"}, tasks, map[string]jira.TicketInfo{}, prInfo, ThemePlain, Options{})
+	if !strings.Contains(html, `<a href="`+resolved+`">repo#123: Fix [caching] bug</a> [merged]`) {
+		t.Errorf("Expected a titled HTML link to the full URL, got:\n%s", html)
+	}
+}
+
+func TestCollectAllPRLinks(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "SCR-1", Status: model.StatusCompleted, Description: "Done", GithubPR: "https://github.com/example/repo/pull/2"},
+			{JiraTicket: "SCR-2", Status: model.StatusInProgress, UpnextDescription: "Review", GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "SCR-3", Status: model.StatusInProgress, Blocker: "Waiting on CI", GithubPR: "https://github.com/example/repo/pull/3"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "SCR-1", Status: model.StatusCompleted, Description: "Follow-up", GithubPR: "https://github.com/example/repo/pull/2"},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01", "2024-08-02"}, Options{})
+
+	want := []string{
+		"https://github.com/example/repo/pull/1",
+		"https://github.com/example/repo/pull/2",
+		"https://github.com/example/repo/pull/3",
+	}
+	if got := CollectAllPRLinks(tasks); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("CollectAllPRLinks() = %v, want %v", got, want)
+	}
+	if got := CollectPRLinks(tasks); len(got) != 2 {
+		t.Errorf("Expected CollectPRLinks to leave out the blocked PR, got %v", got)
+	}
+}
+
+func TestGenerateHTMLHoursDetail(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {
+			Tasks:          []model.Task{{JiraTicket: "SCR-1", Description: "Caching", Status: model.StatusCompleted}},
+			WorkLogEntries: []model.WorkLog{{StartTime: "09:00", EndTime: "12:30", Ticket: "SCR-1"}, {StartTime: "22:00", EndTime: "01:00", NextDay: true}},
+		},
+		"2024-08-02": {Tasks: []model.Task{{JiraTicket: "SCR-2", Description: "Docs", Status: model.StatusCompleted}}},
+	}
+	dates := []string{"2024-08-01", "2024-08-02"}
+	totals := map[string]time.Duration{"2024-08-01": 390 * time.Minute}
+	opts := Options{HoursDetail: NewHoursDetail(workData, dates, totals, func(d time.Duration) string { return fmt.Sprintf("%.2f", d.Hours()) })}
+
+	if len(opts.HoursDetail) != 1 {
+		t.Fatalf("Expected only the day with work_log entries, got %+v", opts.HoursDetail)
+	}
+
+	got := GenerateHTML(dates, CategorizeTasks(workData, dates, opts), map[string]jira.TicketInfo{}, nil, ThemePlain, opts)
+	expected := []string{
+		"<h2>⏱️ Hours worked</h2>",
+		`<th colspan="2">2024-08-01</th>`,
+		"<tr><td>09:00-12:30</td><td>SCR-1</td></tr>",
+		"<tr><td>22:00-01:00 (+1 day)</td><td></td></tr>",
+		"<tr><td><strong>Total</strong></td><td><strong>6.50</strong></td></tr>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML report missing %q\nGot:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "2024-08-02</th>") {
+		t.Errorf("Expected no table for a day without work_log entries, got:\n%s", got)
+	}
+}
+
+func TestGenerateHTMLEscapesUserText(t *testing.T) {
+	const script = `<script>alert("x")</script>`
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Feature " + script, Descriptions: []string{"More " + script}, GithubPR: `https://github.com/example/repo/pull/1"><script>alert(1)</script>`, QCGoal: "Goal " + script},
+			{JiraTicket: "Free-text " + script, Status: model.StatusCompleted, Description: "Chores " + script},
+			{Status: model.StatusCompleted, Description: "Ticketless " + script},
+			{JiraTicket: "NO-JIRA " + script, Status: model.StatusCompleted, Description: "Docs", GithubPR: "https://github.com/example/repo/pull/2?" + script},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Work", UpnextDescription: "Next " + script, Blocker: "Blocked " + script},
+			{JiraTicket: "Free-text " + script, Status: model.StatusInProgress, Description: "Other", UpnextDescription: "Later " + script, Blocker: "Stuck " + script},
+		}},
+	}
+	dates := []string{"2024-08-01", "2024-08-02"}
+	tasks := CategorizeTasks(workData, dates, Options{})
+	jiraInfo := map[string]jira.TicketInfo{
+		"PROJ-1": {Key: "PROJ-1", Summary: "Summary " + script, URL: `https://issues.example.com/browse/PROJ-1"><script>alert(1)</script>`},
+	}
+
+	got := GenerateHTML(dates, tasks, jiraInfo, nil, ThemePlain, Options{})
+	if strings.Contains(got, "<script") {
+		t.Errorf("HTML report contains unescaped markup:\n%s", got)
+	}
+	for _, want := range []string{"Feature &lt;script&gt;", "Blocked &lt;script&gt;", "Free-text &lt;script&gt;", "Next &lt;script&gt;", "Goal &lt;script&gt;"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML report missing escaped text %q:\n%s", want, got)
+		}
+	}
+
+	var confluence strings.Builder
+	PrintConfluence(&confluence, tasks, jiraInfo, nil, Options{})
+	if strings.Contains(confluence.String(), "<script") {
+		t.Errorf("Confluence report contains unescaped markup:\n%s", confluence.String())
+	}
+}
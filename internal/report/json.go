@@ -0,0 +1,143 @@
+package report
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// jsonReport is the structured JSON representation of a categorized report.
+type jsonReport struct {
+	Completed []jsonEntry `json:"completed"`
+	NextUp    []jsonEntry `json:"next_up"`
+	Blocked   []jsonEntry `json:"blocked"`
+	Stale     []jsonEntry `json:"stale,omitempty"`
+}
+
+// jsonEntry is a single ticket (or ticketless work item) within a report section.
+type jsonEntry struct {
+	Ticket       string   `json:"ticket"`
+	Key          string   `json:"key"`
+	URL          string   `json:"url"`
+	Summary      string   `json:"summary,omitempty"`
+	NonFeature   bool     `json:"non_feature"`
+	Descriptions []string `json:"descriptions"`
+	PRs          []string `json:"prs"`
+	Dates        []string `json:"dates"`
+	Blocker      string   `json:"blocker,omitempty"`
+	DaysSince    int      `json:"days_since,omitempty"`
+}
+
+// MarshalJSON serializes the categorized tasks into stable, indented JSON.
+// Empty sections and lists are emitted as empty arrays rather than null.
+func MarshalJSON(tasks model.CategorizedTasks, jiraInfo map[string]jira.TicketInfo, opts Options) ([]byte, error) {
+	result := jsonReport{
+		Completed: []jsonEntry{},
+		NextUp:    []jsonEntry{},
+		Blocked:   []jsonEntry{},
+	}
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks.Completed)
+	for _, ticket := range append(featureTickets, nonFeatureTickets...) {
+		taskList := tasks.Completed[ticket]
+		sortByDate(taskList)
+		descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+		entry := newJSONEntry(ticket, jiraInfo)
+		entry.NonFeature = opts.isNonFeatureGroup(ticket, taskList)
+		entry.Descriptions = append(entry.Descriptions, opts.deduplicateDescriptions(descriptions)...)
+		entry.PRs = append(entry.PRs, prLinks...)
+		entry.Dates = uniqueDates(taskList)
+		result.Completed = append(result.Completed, entry)
+	}
+
+	featureTickets, nonFeatureTickets = opts.splitFeatureWork(tasks.NextUp)
+	for _, ticket := range append(featureTickets, nonFeatureTickets...) {
+		taskList := tasks.NextUp[ticket]
+		sortByDate(taskList)
+		mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+		entry := newJSONEntry(ticket, jiraInfo)
+		entry.NonFeature = opts.isNonFeatureGroup(ticket, taskList)
+		if mostRecentDesc != "" {
+			entry.Descriptions = append(entry.Descriptions, mostRecentDesc)
+		}
+		entry.PRs = append(entry.PRs, prLinks...)
+		entry.Dates = uniqueDates(taskList)
+		result.NextUp = append(result.NextUp, entry)
+	}
+
+	for _, task := range tasks.Blocked {
+		entry := newJSONEntry(task.JiraTicket, jiraInfo)
+		entry.NonFeature = IsNonFeatureWork(task.JiraTicket, task.GithubPR)
+		entry.Descriptions = append(entry.Descriptions, task.GetDescriptions()...)
+		if task.GithubPR != "" {
+			entry.PRs = append(entry.PRs, task.GithubPR)
+		}
+		entry.Blocker = task.Blocker
+		entry.Dates = append(entry.Dates, task.Date)
+		result.Blocked = append(result.Blocked, entry)
+	}
+	sort.SliceStable(result.Blocked, func(i, j int) bool {
+		return ticketLess(result.Blocked[i].Ticket, result.Blocked[j].Ticket)
+	})
+
+	// Stale tickets keep their stalest-first order
+	for _, task := range tasks.Stale {
+		entry := newJSONEntry(task.JiraTicket, jiraInfo)
+		entry.NonFeature = IsNonFeatureWork(task.JiraTicket, task.GithubPR)
+		entry.Descriptions = append(entry.Descriptions, task.GetDescriptions()...)
+		if task.GithubPR != "" {
+			entry.PRs = append(entry.PRs, task.GithubPR)
+		}
+		entry.DaysSince = task.DaysSince
+		entry.Dates = append(entry.Dates, task.Date)
+		result.Stale = append(result.Stale, entry)
+	}
+
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// newJSONEntry creates an entry for a ticket reference, resolving its JIRA key, URL, and summary.
+// Synthetic grouping keys are not exposed as ticket references.
+func newJSONEntry(ticket string, jiraInfo map[string]jira.TicketInfo) jsonEntry {
+	entry := jsonEntry{
+		Descriptions: []string{},
+		PRs:          []string{},
+		Dates:        []string{},
+	}
+	if !IsSyntheticKey(ticket) {
+		entry.Ticket = ticket
+	}
+
+	entry.Key = jira.ExtractTicketID(entry.Ticket)
+	if entry.Key == "" {
+		return entry
+	}
+	if info, exists := jiraInfo[entry.Key]; exists {
+		entry.URL = info.URL
+		entry.Summary = info.Summary
+	} else {
+		entry.URL = jira.TicketURL(entry.Key)
+	}
+	return entry
+}
+
+// isNonFeatureGroup reports whether a grouped ticket is non-feature work, considering PRs across the group.
+func (opts Options) isNonFeatureGroup(ticket string, taskList []model.TaskWithDate) bool {
+	_, nonFeature := opts.splitFeatureWork(map[string][]model.TaskWithDate{ticket: taskList})
+	return len(nonFeature) > 0
+}
+
+// uniqueDates returns the distinct dates (YYYY-MM-DD) of a chronologically sorted task list.
+func uniqueDates(taskList []model.TaskWithDate) []string {
+	dates := []string{}
+	for _, taskWithDate := range taskList {
+		if len(dates) == 0 || dates[len(dates)-1] != taskWithDate.Date {
+			dates = append(dates, taskWithDate.Date)
+		}
+	}
+	return dates
+}
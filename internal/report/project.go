@@ -0,0 +1,62 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// NoProject is the project of tasks that set no project and have no JIRA ticket
+// to infer one from.
+const NoProject = "Other"
+
+// TaskProject returns the task's project: its project field when set, otherwise
+// the project prefix of its JIRA ticket (PROJ for PROJ-123), otherwise NoProject.
+func TaskProject(task model.Task) string {
+	if project := strings.TrimSpace(task.Project); project != "" {
+		return project
+	}
+	if project, _, ok := parseTicketKey(task.JiraTicket); ok {
+		return project
+	}
+	return NoProject
+}
+
+// SplitByProject divides the tasks on the given dates by TaskProject. It returns
+// the projects in alphabetical order, with NoProject last, and a copy of workData
+// per project holding only that project's tasks. Work log entries are kept
+// unchanged in every copy.
+func SplitByProject(workData model.WorkData, dates []string) ([]string, map[string]model.WorkData) {
+	byProject := make(map[string]model.WorkData)
+	for _, date := range dates {
+		dailyLog, exists := workData[date]
+		if !exists {
+			continue
+		}
+		for _, task := range dailyLog.Tasks {
+			project := TaskProject(task)
+			if byProject[project] == nil {
+				byProject[project] = make(model.WorkData)
+			}
+			projectLog, exists := byProject[project][date]
+			if !exists {
+				projectLog.WorkLogEntries = dailyLog.WorkLogEntries
+			}
+			projectLog.Tasks = append(projectLog.Tasks, task)
+			byProject[project][date] = projectLog
+		}
+	}
+
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if (projects[i] == NoProject) != (projects[j] == NoProject) {
+			return projects[j] == NoProject
+		}
+		return projects[i] < projects[j]
+	})
+	return projects, byProject
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestTaskProject(t *testing.T) {
+	tests := []struct {
+		name string
+		task model.Task
+		want string
+	}{
+		{name: "explicit project", task: model.Task{Project: "Console", JiraTicket: "PROJ-123"}, want: "Console"},
+		{name: "inferred from the ticket key", task: model.Task{JiraTicket: "PROJ-123"}, want: "PROJ"},
+		{name: "inferred from a browse URL", task: model.Task{JiraTicket: "https://issues.example.com/browse/OCPBUGS-42"}, want: "OCPBUGS"},
+		{name: "no ticket", task: model.Task{Description: "Team meeting"}, want: NoProject},
+		{name: "NO-JIRA", task: model.Task{JiraTicket: "NO-JIRA"}, want: NoProject},
+		{name: "blank project falls back to the ticket", task: model.Task{Project: "  ", JiraTicket: "SCR-1"}, want: "SCR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TaskProject(tt.task); got != tt.want {
+				t.Errorf("TaskProject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitByProject(t *testing.T) {
+	entries := []model.WorkLog{{StartTime: "09:00", EndTime: "17:00"}}
+	workData := model.WorkData{
+		"2024-08-01": {WorkLogEntries: entries, Tasks: []model.Task{
+			{JiraTicket: "SCR-1", Description: "Parser"},
+			{Description: "Team meeting"},
+			{JiraTicket: "PROJ-9", Project: "Console", Description: "Login page"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "SCR-2", Description: "Lexer"},
+		}},
+		"2024-08-05": {Tasks: []model.Task{
+			{JiraTicket: "ZZZ-1", Description: "Outside the range"},
+		}},
+	}
+
+	projects, byProject := SplitByProject(workData, []string{"2024-08-01", "2024-08-02"})
+
+	if want := []string{"Console", "SCR", NoProject}; !reflect.DeepEqual(projects, want) {
+		t.Errorf("Expected projects %v, got %v", want, projects)
+	}
+	scr := byProject["SCR"]
+	if len(scr) != 2 || len(scr["2024-08-01"].Tasks) != 1 || scr["2024-08-02"].Tasks[0].JiraTicket != "SCR-2" {
+		t.Errorf("Unexpected SCR work data: %+v", scr)
+	}
+	if !reflect.DeepEqual(scr["2024-08-01"].WorkLogEntries, entries) {
+		t.Errorf("Expected work log entries to be kept, got %+v", scr["2024-08-01"].WorkLogEntries)
+	}
+	if other := byProject[NoProject]; len(other) != 1 || other["2024-08-01"].Tasks[0].Description != "Team meeting" {
+		t.Errorf("Unexpected %s work data: %+v", NoProject, other)
+	}
+}
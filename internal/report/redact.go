@@ -0,0 +1,90 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// RedactedID pairs a placeholder with the identifier it stands for.
+type RedactedID struct {
+	Placeholder string
+	Original    string
+}
+
+// Redaction replaces the JIRA tickets and GitHub PR links of a report with
+// TICKET-N and PR-N placeholders, so the report can be shared without internal
+// identifiers. It is applied to rendered output, which leaves the grouping and
+// layout of the report untouched.
+type Redaction struct {
+	// IDs lists the placeholders in the order they were assigned.
+	IDs []RedactedID
+
+	tickets map[string]string
+	urls    *strings.Replacer
+}
+
+// redactKeyRegex matches the JIRA keys a Redaction looks up.
+var redactKeyRegex = regexp.MustCompile(`\b[A-Z]+-\d+\b`)
+
+// NewRedaction assigns placeholders to the JIRA tickets and PR links of the
+// tasks on the given dates, numbered in the order they were logged.
+func NewRedaction(workData model.WorkData, dates []string) *Redaction {
+	r := &Redaction{tickets: make(map[string]string)}
+	prs := make(map[string]string)
+	for _, date := range dates {
+		for _, task := range workData[date].Tasks {
+			if key := jira.ExtractTicketID(task.JiraTicket); key != "" && r.tickets[key] == "" {
+				r.tickets[key] = fmt.Sprintf("TICKET-%d", len(r.tickets)+1)
+				r.IDs = append(r.IDs, RedactedID{Placeholder: r.tickets[key], Original: key})
+			}
+			if task.GithubPR != "" && prs[task.GithubPR] == "" {
+				prs[task.GithubPR] = fmt.Sprintf("PR-%d", len(prs)+1)
+				r.IDs = append(r.IDs, RedactedID{Placeholder: prs[task.GithubPR], Original: task.GithubPR})
+			}
+		}
+	}
+
+	// Whole URLs go first, longest first so that a link is never replaced by a
+	// shorter link it starts with. JIRA browse links become the bare placeholder
+	// so the JIRA host is hidden too.
+	urls := make(map[string]string, len(prs)+2*len(r.tickets))
+	for link, placeholder := range prs {
+		urls[link] = placeholder
+	}
+	for key, placeholder := range r.tickets {
+		urls[jira.TicketURL(key)] = placeholder
+	}
+	links := make([]string, 0, len(urls))
+	for link := range urls {
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		if len(links[i]) != len(links[j]) {
+			return len(links[i]) > len(links[j])
+		}
+		return links[i] < links[j]
+	})
+	var pairs []string
+	for _, link := range links {
+		pairs = append(pairs, link, urls[link])
+	}
+	r.urls = strings.NewReplacer(pairs...)
+	return r
+}
+
+// Apply replaces every known PR link, JIRA browse link, and JIRA key in s with
+// its placeholder.
+func (r *Redaction) Apply(s string) string {
+	s = r.urls.Replace(s)
+	return redactKeyRegex.ReplaceAllStringFunc(s, func(key string) string {
+		if placeholder, ok := r.tickets[key]; ok {
+			return placeholder
+		}
+		return key
+	})
+}
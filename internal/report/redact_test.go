@@ -0,0 +1,68 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestRedaction(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Parser, follow-up in PROJ-10", GithubPR: "https://github.com/example/repo/pull/1"},
+			{Status: model.StatusCompleted, Description: "Docs", GithubPR: "https://github.com/example/repo/pull/12"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "https://issues.redhat.com/browse/PROJ-10", Status: model.StatusInProgress, Description: "Caching", UpnextDescription: "Benchmarks", Blocker: "Waiting on PROJ-1 review"},
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Fixes for UTF-8 input"},
+		}},
+	}
+	dates := []string{"2024-08-01", "2024-08-02"}
+	redaction := NewRedaction(workData, dates)
+
+	wantIDs := []RedactedID{
+		{Placeholder: "TICKET-1", Original: "PROJ-1"},
+		{Placeholder: "PR-1", Original: "https://github.com/example/repo/pull/1"},
+		{Placeholder: "PR-2", Original: "https://github.com/example/repo/pull/12"},
+		{Placeholder: "TICKET-2", Original: "PROJ-10"},
+	}
+	if !reflect.DeepEqual(redaction.IDs, wantIDs) {
+		t.Errorf("IDs = %+v, want %+v", redaction.IDs, wantIDs)
+	}
+
+	tasks := CategorizeTasks(workData, dates, Options{})
+	var text strings.Builder
+	PrintCompletedTasks(&text, tasks.Completed, nil, Options{})
+	PrintNextUpTasks(&text, tasks.NextUp, nil, Options{})
+	PrintBlockedTasks(&text, tasks.Blocked, Options{})
+	data, err := MarshalJSON(tasks, nil, Options{})
+	if err != nil {
+		t.Fatalf("MarshalJSON returned error: %v", err)
+	}
+	outputs := map[string]string{
+		"text": text.String(),
+		"html": GenerateHTML(dates, tasks, map[string]jira.TicketInfo{}, nil, ThemePlain, Options{}),
+		"json": string(data),
+	}
+	for format, output := range outputs {
+		redacted := redaction.Apply(output)
+		for _, id := range wantIDs {
+			if strings.Contains(redacted, id.Original) {
+				t.Errorf("%s output leaks %q:\n%s", format, id.Original, redacted)
+			}
+		}
+		for _, leak := range []string{"PROJ-", "issues.redhat.com", "github.com"} {
+			if strings.Contains(redacted, leak) {
+				t.Errorf("%s output leaks %q:\n%s", format, leak, redacted)
+			}
+		}
+		for _, kept := range []string{"TICKET-1", "TICKET-2", "PR-1", "PR-2", "Parser, follow-up in TICKET-2", "Fixes for UTF-8 input"} {
+			if !strings.Contains(redacted, kept) {
+				t.Errorf("Expected %q in the redacted %s output:\n%s", kept, format, redacted)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/markup"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// Section headers for Slack Block Kit output.
+const (
+	slackHeaderCompleted      = "*🦀 Things I've been working on*"
+	slackHeaderNextUp         = "*⭐ Things I plan on working on next*"
+	slackHeaderBlocked        = "*🚫 Things that are blocking me*"
+	slackHeaderQCGoals        = "*🎯 Quarterly goals*"
+	slackNonFeatureWorkHeader = "Non-feature work"
+)
+
+// SlackTextLimit is the maximum number of characters Slack accepts in a single
+// section block's text.
+const SlackTextLimit = 3000
+
+// slackPayload is a Slack Block Kit message, as accepted by incoming webhooks.
+type slackPayload struct {
+	Text   string       `json:"text,omitempty"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackBlock is a single Block Kit layout block.
+type slackBlock struct {
+	Type string           `json:"type"`
+	Text *slackTextObject `json:"text,omitempty"`
+}
+
+// slackTextObject is a Block Kit text object.
+type slackTextObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// MarshalSlackBlocks serializes the categorized tasks as a Slack Block Kit payload.
+// The title, when given, becomes a header block. Each report section is rendered
+// as mrkdwn section blocks separated by dividers, and sections longer than
+// SlackTextLimit are split across several blocks at line boundaries.
+func MarshalSlackBlocks(title string, tasks model.CategorizedTasks, jiraInfo map[string]jira.TicketInfo, opts Options) ([]byte, error) {
+	payload := slackPayload{Text: title, Blocks: []slackBlock{}}
+	if title != "" {
+		payload.Blocks = append(payload.Blocks, slackBlock{
+			Type: "header",
+			Text: &slackTextObject{Type: "plain_text", Text: title},
+		})
+	}
+
+	sections := [][]string{
+		slackCompletedLines(tasks.Completed, jiraInfo, opts),
+		slackNextUpLines(tasks.NextUp, jiraInfo, opts),
+		slackBlockedLines(tasks.Blocked, jiraInfo, opts),
+		slackQCGoalLines(tasks.ByQCGoal),
+		slackStaleLines(tasks.Stale),
+	}
+	for _, lines := range sections {
+		if len(lines) == 0 {
+			continue
+		}
+		if len(payload.Blocks) > 0 {
+			payload.Blocks = append(payload.Blocks, slackBlock{Type: "divider"})
+		}
+		for _, chunk := range chunkSlackLines(lines, SlackTextLimit) {
+			payload.Blocks = append(payload.Blocks, slackBlock{
+				Type: "section",
+				Text: &slackTextObject{Type: "mrkdwn", Text: chunk},
+			})
+		}
+	}
+
+	// Keep Slack's <url|text> link syntax readable rather than \u003c-escaped
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// chunkSlackLines joins lines into texts of at most limit characters, breaking
+// between lines where possible and splitting any single over-long line.
+func chunkSlackLines(lines []string, limit int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		if currentLen > 0 {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+	}
+
+	for _, line := range lines {
+		for utf8.RuneCountInString(line) > limit {
+			flush()
+			runes := []rune(line)
+			chunks = append(chunks, string(runes[:limit]))
+			line = string(runes[limit:])
+		}
+
+		lineLen := utf8.RuneCountInString(line)
+		if currentLen > 0 && currentLen+1+lineLen > limit {
+			flush()
+		}
+		if currentLen > 0 {
+			current.WriteString("\n")
+			currentLen++
+		}
+		current.WriteString(line)
+		currentLen += lineLen
+	}
+	flush()
+	return chunks
+}
+
+// slackPRLinks renders PR links as a semicolon-separated list of Slack links.
+func slackPRLinks(prLinks []string) string {
+	var links []string
+	for _, link := range prLinks {
+		escaped := markup.SlackText(link)
+		links = append(links, fmt.Sprintf("<%s|%s>", escaped, escaped))
+	}
+	return strings.Join(links, "; ")
+}
+
+// slackCompletedLines renders the completed tasks section as mrkdwn lines.
+func slackCompletedLines(tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) []string {
+	if len(tasks) == 0 {
+		return nil
+	}
+	lines := []string{sectionHeader(slackHeaderCompleted, opts.HeaderCompleted, "*%s*")}
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
+
+	// Feature work first
+	for _, ticket := range featureTickets {
+		taskList := tasks[ticket]
+		sortByDate(taskList)
+		descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+		heading := jira.FormatTicketSlack(ticket, jiraInfo)
+		if isInlineEntry(ticket) {
+			var header string
+			header, descriptions = inlineHeader(descriptions...)
+			heading = markup.SlackText(header)
+		}
+		lines = append(lines, fmt.Sprintf("• *%s*", heading))
+		for _, desc := range opts.deduplicateDescriptions(descriptions) {
+			lines = append(lines, "    ◦ "+markup.SlackText(desc))
+		}
+		if len(prLinks) > 0 {
+			lines = append(lines, "    ◦ PR(s): "+slackPRLinks(prLinks))
+		}
+	}
+
+	// Non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTickets) > 0 {
+		lines = append(lines, fmt.Sprintf("• *%s*", slackNonFeatureWorkHeader))
+		for _, ticket := range nonFeatureTickets {
+			taskList := tasks[ticket]
+			sortByDate(taskList)
+			descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
+
+			// Determine header: for synthetic keys, use the first description
+			header := ticket
+			if IsSyntheticKey(ticket) || header == "" {
+				if len(descriptions) > 0 {
+					header = descriptions[0]
+					descriptions = descriptions[1:]
+				} else {
+					header = "Misc"
+				}
+			}
+			lines = append(lines, "    ◦ "+markup.SlackText(header))
+
+			descriptions = opts.deduplicateDescriptions(descriptions)
+			sortDescriptions(descriptions)
+			for _, desc := range descriptions {
+				lines = append(lines, "        ▪ "+markup.SlackText(desc))
+			}
+			if len(prLinks) > 0 {
+				lines = append(lines, "        ▪ PR(s): "+slackPRLinks(prLinks))
+			}
+		}
+	}
+	return lines
+}
+
+// slackNextUpLines renders the next up tasks section as mrkdwn lines.
+func slackNextUpLines(nextUp map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) []string {
+	if len(nextUp) == 0 {
+		return nil
+	}
+	lines := []string{sectionHeader(slackHeaderNextUp, opts.HeaderNextUp, "*%s*")}
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(nextUp)
+
+	// Feature work first
+	for _, ticket := range featureTickets {
+		taskList := nextUp[ticket]
+		sortByDate(taskList)
+		mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+		heading := jira.FormatTicketSlack(ticket, jiraInfo)
+		if isInlineEntry(ticket) {
+			header, _ := inlineHeader(mostRecentDesc)
+			heading, mostRecentDesc = markup.SlackText(header), ""
+		}
+		lines = append(lines, fmt.Sprintf("• *%s*", heading))
+		if mostRecentDesc != "" {
+			lines = append(lines, "    ◦ "+markup.SlackText(mostRecentDesc))
+		}
+		if len(prLinks) > 0 {
+			lines = append(lines, "    ◦ PR(s): "+slackPRLinks(prLinks))
+		}
+	}
+
+	// Non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTickets) > 0 {
+		lines = append(lines, fmt.Sprintf("• *%s*", slackNonFeatureWorkHeader))
+		for _, ticket := range nonFeatureTickets {
+			taskList := nextUp[ticket]
+			sortByDate(taskList)
+			mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+			// Determine header: for synthetic keys, use the upnext description
+			header := ticket
+			if IsSyntheticKey(ticket) || header == "" {
+				if mostRecentDesc != "" {
+					header = mostRecentDesc
+					mostRecentDesc = ""
+				} else {
+					header = "Misc"
+				}
+			}
+			lines = append(lines, "    ◦ "+markup.SlackText(header))
+
+			if mostRecentDesc != "" {
+				lines = append(lines, "        ▪ "+markup.SlackText(mostRecentDesc))
+			}
+			if len(prLinks) > 0 {
+				lines = append(lines, "        ▪ PR(s): "+slackPRLinks(prLinks))
+			}
+		}
+	}
+	return lines
+}
+
+// slackBlockedLines renders the blocked tasks section as mrkdwn lines.
+func slackBlockedLines(blocked []model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) []string {
+	if len(blocked) == 0 {
+		return nil
+	}
+	lines := []string{sectionHeader(slackHeaderBlocked, opts.HeaderBlocked, "*%s*")}
+
+	// Separate feature work and non-feature work
+	var featureTasks []model.TaskWithDate
+	var nonFeatureTasks []model.TaskWithDate
+	for _, task := range blocked {
+		if opts.isGroupedNonFeature(task) {
+			nonFeatureTasks = append(nonFeatureTasks, task)
+		} else {
+			featureTasks = append(featureTasks, task)
+		}
+	}
+
+	// Feature work first
+	for _, task := range featureTasks {
+		lines = append(lines,
+			fmt.Sprintf("• *%s*", jira.FormatTicketSlack(opts.blockedTicket(task), jiraInfo)),
+			"    ◦ Blocker: "+markup.SlackText(task.Blocker),
+			"    ◦ "+markup.SlackText(blockedSince(task)),
+		)
+	}
+
+	// Non-feature work at the end (grouped under "Non-feature work" with sub-entries)
+	if len(nonFeatureTasks) > 0 {
+		lines = append(lines, fmt.Sprintf("• *%s*", slackNonFeatureWorkHeader))
+		for _, task := range nonFeatureTasks {
+			header := task.JiraTicket
+			if header == "" {
+				header = "Misc"
+			}
+			lines = append(lines,
+				"    ◦ "+markup.SlackText(header),
+				"        ▪ Blocker: "+markup.SlackText(task.Blocker),
+				"        ▪ "+markup.SlackText(blockedSince(task)),
+			)
+		}
+	}
+	return lines
+}
+
+// slackQCGoalLines renders the quarterly goals section as mrkdwn lines.
+func slackQCGoalLines(byGoal map[string][]model.TaskWithDate) []string {
+	if len(byGoal) == 0 {
+		return nil
+	}
+	lines := []string{slackHeaderQCGoals}
+
+	for _, goal := range sortedGoals(byGoal) {
+		lines = append(lines, fmt.Sprintf("• *%s*", markup.SlackText(goal)))
+		for _, entry := range qcGoalEntries(byGoal[goal]) {
+			lines = append(lines, "    ◦ "+markup.SlackText(entry))
+		}
+	}
+	return lines
+}
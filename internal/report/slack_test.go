@@ -0,0 +1,106 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestMarshalSlackBlocks(t *testing.T) {
+	tasks := model.CategorizedTasks{
+		Completed: map[string][]model.TaskWithDate{
+			"PROJ-1": {{Date: "2024-08-01", Task: model.Task{JiraTicket: "PROJ-1", Description: "Fixed <login> & logout", GithubPR: "https://github.com/example/repo/pull/1"}}},
+		},
+		NextUp: map[string][]model.TaskWithDate{
+			"PROJ-2": {{Date: "2024-08-01", Task: model.Task{JiraTicket: "PROJ-2", UpnextDescription: "Add retries"}}},
+		},
+		Blocked: []model.TaskWithDate{
+			{Date: "2024-08-01", Task: model.Task{JiraTicket: "PROJ-3", Blocker: "Waiting on review"}},
+		},
+	}
+	jiraInfo := map[string]jira.TicketInfo{
+		"PROJ-1": {Key: "PROJ-1", Summary: "Login flow", URL: "https://jira.example.com/browse/PROJ-1"},
+	}
+
+	data, err := MarshalSlackBlocks("Work Report", tasks, jiraInfo, Options{})
+	if err != nil {
+		t.Fatalf("MarshalSlackBlocks returned error: %v", err)
+	}
+
+	var payload slackPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("Failed to parse payload: %v\n%s", err, data)
+	}
+
+	var types []string
+	for _, block := range payload.Blocks {
+		types = append(types, block.Type)
+	}
+	wantTypes := "header,divider,section,divider,section,divider,section"
+	if got := strings.Join(types, ","); got != wantTypes {
+		t.Fatalf("Block types = %s, want %s", got, wantTypes)
+	}
+
+	completed := payload.Blocks[2].Text.Text
+	for _, want := range []string{
+		"<https://jira.example.com/browse/PROJ-1|PROJ-1: Login flow>",
+		"Fixed &lt;login&gt; &amp; logout",
+		"<https://github.com/example/repo/pull/1|https://github.com/example/repo/pull/1>",
+	} {
+		if !strings.Contains(completed, want) {
+			t.Errorf("Completed section missing %q\nGot:\n%s", want, completed)
+		}
+	}
+	if !strings.Contains(payload.Blocks[4].Text.Text, "Add retries") {
+		t.Errorf("Next up section missing upnext description\nGot:\n%s", payload.Blocks[4].Text.Text)
+	}
+	if !strings.Contains(payload.Blocks[6].Text.Text, "Blocker: Waiting on review") {
+		t.Errorf("Blocked section missing blocker\nGot:\n%s", payload.Blocks[6].Text.Text)
+	}
+}
+
+func TestMarshalSlackBlocksSplitsLongSections(t *testing.T) {
+	completed := make(map[string][]model.TaskWithDate)
+	for i := 0; i < 100; i++ {
+		ticket := fmt.Sprintf("PROJ-%d", i)
+		completed[ticket] = []model.TaskWithDate{{Date: "2024-08-01", Task: model.Task{
+			JiraTicket:  ticket,
+			Description: strings.Repeat("long description ", 5),
+		}}}
+	}
+
+	data, err := MarshalSlackBlocks("", model.CategorizedTasks{Completed: completed}, nil, Options{})
+	if err != nil {
+		t.Fatalf("MarshalSlackBlocks returned error: %v", err)
+	}
+
+	var payload slackPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("Failed to parse payload: %v", err)
+	}
+	if len(payload.Blocks) < 2 {
+		t.Fatalf("Expected the section to be split across blocks, got %d block(s)", len(payload.Blocks))
+	}
+	for i, block := range payload.Blocks {
+		if block.Type != "section" {
+			t.Errorf("Block %d has type %q, want section", i, block.Type)
+			continue
+		}
+		if n := utf8.RuneCountInString(block.Text.Text); n > SlackTextLimit {
+			t.Errorf("Block %d has %d characters, over the %d limit", i, n, SlackTextLimit)
+		}
+	}
+}
+
+func TestChunkSlackLines(t *testing.T) {
+	got := chunkSlackLines([]string{"aaaa", "bb", "cccccccccc"}, 7)
+	want := []string{"aaaa\nbb", "ccccccc", "ccc"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("chunkSlackLines = %q, want %q", got, want)
+	}
+}
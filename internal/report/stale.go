@@ -0,0 +1,101 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/bryan-cox/taskledger/internal/markup"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// Section headers for the possibly stale tickets of each format.
+const (
+	TextHeaderStale       = "\n⏳ Possibly stale"
+	mdHeaderStale         = "## ⏳ Possibly stale"
+	adocHeaderStale       = "== ⏳ Possibly stale"
+	htmlHeaderStale       = "<h2>⏳ Possibly stale</h2>"
+	confluenceHeaderStale = "<h2>⏳ Possibly stale</h2>"
+	slackHeaderStale      = "*⏳ Possibly stale*"
+)
+
+// PrintStaleTasks prints the tickets left in progress for more than Options.StaleAfter
+// days as a text section, with the days since each was last logged.
+func PrintStaleTasks(out io.Writer, stale []model.StaleTask, opts Options) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Fprintln(out, paintHeader(out, TextHeaderStale))
+	for _, task := range stale {
+		opts.printWrapped(out, "    • %s", staleEntry(task))
+	}
+}
+
+// printStaleTasksMarkdown prints the possibly stale tickets as a Markdown section.
+func printStaleTasksMarkdown(out io.Writer, stale []model.StaleTask) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", mdHeaderStale)
+	for _, task := range stale {
+		fmt.Fprintf(out, "- %s\n", staleEntry(task))
+	}
+}
+
+// printStaleTasksAsciiDoc prints the possibly stale tickets as an AsciiDoc section.
+func printStaleTasksAsciiDoc(out io.Writer, stale []model.StaleTask) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s\n\n", adocHeaderStale)
+	for _, task := range stale {
+		fmt.Fprintf(out, "* %s\n", staleEntry(task))
+	}
+}
+
+// printStaleTasksConfluence prints the possibly stale tickets as Confluence
+// storage format.
+func printStaleTasksConfluence(out io.Writer, stale []model.StaleTask) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Fprintln(out, confluenceHeaderStale)
+	fmt.Fprintf(out, "<ul>%s</ul>\n", confluenceItems(staleEntries(stale)...))
+}
+
+// renderStaleTasksHTML renders the possibly stale tickets as an HTML section.
+func renderStaleTasksHTML(stale []model.StaleTask) string {
+	if len(stale) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(htmlHeaderStale)
+	sb.WriteString(`<ul>`)
+	for _, entry := range staleEntries(stale) {
+		fmt.Fprintf(&sb, `<li>%s</li>`, html.EscapeString(entry))
+	}
+	sb.WriteString(`</ul>`)
+	return sb.String()
+}
+
+// slackStaleLines renders the possibly stale tickets as mrkdwn lines.
+func slackStaleLines(stale []model.StaleTask) []string {
+	if len(stale) == 0 {
+		return nil
+	}
+	lines := []string{slackHeaderStale}
+	for _, entry := range staleEntries(stale) {
+		lines = append(lines, "• "+markup.SlackText(entry))
+	}
+	return lines
+}
+
+// staleEntries describes each stale task with staleEntry.
+func staleEntries(stale []model.StaleTask) []string {
+	entries := make([]string, 0, len(stale))
+	for _, task := range stale {
+		entries = append(entries, staleEntry(task))
+	}
+	return entries
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// PrintSummary prints only the tickets in each section, one per line, labeled
+// with their JIRA summaries when jiraInfo has them. Descriptions and PR links are
+// left out, and non-feature work is collapsed into a single line.
+func PrintSummary(out io.Writer, tasks model.CategorizedTasks, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	printSummarySection(out, sectionHeader(TextHeaderCompleted, opts.HeaderCompleted, "\n%s"), ansiComplete, tasks.Completed, jiraInfo, opts)
+	printSummarySection(out, sectionHeader(TextHeaderNextUp, opts.HeaderNextUp, "\n%s"), ansiNextUp, tasks.NextUp, jiraInfo, opts)
+
+	blocked := make(map[string][]model.TaskWithDate)
+	for _, task := range tasks.Blocked {
+		blocked[task.JiraTicket] = append(blocked[task.JiraTicket], task)
+	}
+	printSummarySection(out, sectionHeader(TextHeaderBlocked, opts.HeaderBlocked, "\n%s"), ansiBlocked, blocked, jiraInfo, opts)
+}
+
+// printSummarySection prints a section header followed by one line per ticket.
+func printSummarySection(out io.Writer, header, color string, tasks map[string][]model.TaskWithDate, jiraInfo map[string]jira.TicketInfo, opts Options) {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
+		return
+	}
+	fmt.Fprintln(out, paintHeader(out, header))
+
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
+	for _, ticket := range featureTickets {
+		fmt.Fprintf(out, "    • %s\n", paint(out, color, summaryLabel(ticket, jiraInfo)))
+	}
+	if len(nonFeatureTickets) > 0 {
+		fmt.Fprintf(out, "    • %s\n", paint(out, color, textNonFeatureWorkHeader))
+	}
+}
+
+// summaryLabel returns a ticket's JIRA key followed by its summary when known,
+// or the ticket reference as written when it has no JIRA key.
+func summaryLabel(ticket string, jiraInfo map[string]jira.TicketInfo) string {
+	ticketID := jira.ExtractTicketID(ticket)
+	if ticketID == "" {
+		return ticket
+	}
+	if info, exists := jiraInfo[ticketID]; exists && info.Summary != "" {
+		return fmt.Sprintf("%s: %s", ticketID, info.Summary)
+	}
+	return ticketID
+}
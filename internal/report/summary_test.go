@@ -0,0 +1,38 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestPrintSummary(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-10", Status: model.StatusCompleted, Description: "Shipped the parser", GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Reviewing", UpnextDescription: "Finish review", Blocker: "Waiting on CI"},
+			{Status: model.StatusCompleted, Description: "Team wiki"},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+	jiraInfo := map[string]jira.TicketInfo{
+		"PROJ-10": {Key: "PROJ-10", Summary: "Parser rewrite"},
+	}
+
+	var out bytes.Buffer
+	PrintSummary(&out, tasks, jiraInfo, Options{})
+
+	expected := TextHeaderCompleted + "\n" +
+		"    • PROJ-2\n" +
+		"    • PROJ-10: Parser rewrite\n" +
+		"    • Non-feature work\n" +
+		TextHeaderNextUp + "\n" +
+		"    • PROJ-2\n" +
+		TextHeaderBlocked + "\n" +
+		"    • PROJ-2\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected summary:\ngot:\n%q\nwant:\n%q", out.String(), expected)
+	}
+}
@@ -0,0 +1,246 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/bryan-cox/taskledger/internal/github"
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+// defaultTextTemplate renders the standard text report.
+//
+//go:embed templates/report.txt.tmpl
+var defaultTextTemplate string
+
+// defaultHTMLTemplateText renders the standard, Slack-friendly HTML report.
+//
+//go:embed templates/report.html.tmpl
+var defaultHTMLTemplateText string
+
+// defaultHTMLTemplate is defaultHTMLTemplateText, parsed once.
+var defaultHTMLTemplate = htmltemplate.Must(parseHTMLTemplate("report.html.tmpl", defaultHTMLTemplateText))
+
+// TemplateData is the data text and HTML report templates are executed with.
+// Project is set when rendering one project's sub-report with report --by-project.
+type TemplateData struct {
+	Project   string
+	StartDate string
+	EndDate   string
+	Dates     []string
+	Tasks     model.CategorizedTasks
+	JiraInfo  map[string]jira.TicketInfo
+	PRInfo    map[string]github.PRInfo
+}
+
+// ParseTextTemplate parses the text/template file at path, or the embedded
+// default template when path is empty. See templateFuncs for the functions
+// available to templates.
+func ParseTextTemplate(path string) (*template.Template, error) {
+	text := defaultTextTemplate
+	name := "report.txt.tmpl"
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		text = string(data)
+		name = path
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs(io.Discard, TemplateData{}, Options{})).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ExecuteTextTemplate renders the report for data with a template from
+// ParseTextTemplate. Sections are colored when out is a ColorWriter.
+func ExecuteTextTemplate(out io.Writer, tmpl *template.Template, data TemplateData, opts Options) error {
+	bound, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	return bound.Funcs(templateFuncs(out, data, opts)).Execute(out, data)
+}
+
+// ParseHTMLTemplate parses the html/template file at path, or the embedded
+// default template when path is empty. HTML templates get the same functions as
+// text templates, with sections rendered as HTML, plus ticketURL, themeCSS, and
+// footer.
+func ParseHTMLTemplate(path string) (*htmltemplate.Template, error) {
+	if path == "" {
+		return defaultHTMLTemplate, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := parseHTMLTemplate(path, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// parseHTMLTemplate parses an HTML report template with placeholder functions,
+// which GenerateHTMLWithTemplate rebinds to the report being rendered.
+func parseHTMLTemplate(name, text string) (*htmltemplate.Template, error) {
+	return htmltemplate.New(name).Funcs(htmlTemplateFuncs(TemplateData{}, "", Options{})).Parse(text)
+}
+
+// GenerateHTMLWithTemplate renders the HTML report with a template from
+// ParseHTMLTemplate. JIRA info is resolved as in GenerateHTML.
+func GenerateHTMLWithTemplate(tmpl *htmltemplate.Template, dates []string, tasks model.CategorizedTasks, preloadedJiraInfo map[string]jira.TicketInfo, prInfo map[string]github.PRInfo, theme string, opts Options) (string, error) {
+	// Use preloaded JIRA info if provided, otherwise fetch from API
+	jiraInfo := preloadedJiraInfo
+	if jiraInfo == nil {
+		jiraInfo = jira.ProcessTickets(CollectTickets(tasks))
+	}
+	data := TemplateData{
+		StartDate: dates[0],
+		EndDate:   dates[len(dates)-1],
+		Dates:     dates,
+		Tasks:     tasks,
+		JiraInfo:  jiraInfo,
+		PRInfo:    prInfo,
+	}
+
+	bound, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := bound.Funcs(htmlTemplateFuncs(data, theme, opts)).Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// templateFuncs returns the functions available to text report templates:
+//
+//   - completedSection, nextUpSection, blockedSection, qcGoalsSection,
+//     staleSection: a whole section as printed by the default report
+//   - the helpers from helperFuncs
+func templateFuncs(out io.Writer, data TemplateData, opts Options) template.FuncMap {
+	// Sections are rendered into a buffer, colored the same way as out
+	section := func(print func(io.Writer)) func() string {
+		return func() string {
+			var buf strings.Builder
+			if _, ok := out.(*ColorWriter); ok {
+				print(NewColorWriter(&buf))
+			} else {
+				print(&buf)
+			}
+			return buf.String()
+		}
+	}
+
+	funcs := template.FuncMap(helperFuncs(data, opts))
+	funcs["completedSection"] = section(func(w io.Writer) { PrintCompletedTasks(w, data.Tasks.Completed, data.PRInfo, opts) })
+	funcs["nextUpSection"] = section(func(w io.Writer) { PrintNextUpTasks(w, data.Tasks.NextUp, data.PRInfo, opts) })
+	funcs["blockedSection"] = section(func(w io.Writer) { PrintBlockedTasks(w, data.Tasks.Blocked, opts) })
+	funcs["qcGoalsSection"] = section(func(w io.Writer) { PrintQCGoals(w, data.Tasks.ByQCGoal, opts) })
+	funcs["staleSection"] = section(func(w io.Writer) { PrintStaleTasks(w, data.Tasks.Stale, opts) })
+	return funcs
+}
+
+// htmlTemplateFuncs returns the functions available to HTML report templates:
+//
+//   - completedSection, nextUpSection, blockedSection, qcGoalsSection,
+//     staleSection: a whole section as rendered by the default report
+//   - hoursDetailSection: the Options.HoursDetail tables, or "" when there are none
+//   - ticketURL: a ticket's JIRA browse URL, or "" when it has no JIRA key
+//   - themeCSS: the stylesheet of the --theme, or "" for the plain theme
+//   - footer: Options.HTMLFooter
+//   - the helpers from helperFuncs
+func htmlTemplateFuncs(data TemplateData, theme string, opts Options) htmltemplate.FuncMap {
+	funcs := htmltemplate.FuncMap(helperFuncs(data, opts))
+	funcs["completedSection"] = func() htmltemplate.HTML {
+		return htmltemplate.HTML(renderCompletedTasksHTML(data.Tasks.Completed, data.JiraInfo, data.PRInfo, opts))
+	}
+	funcs["nextUpSection"] = func() htmltemplate.HTML {
+		return htmltemplate.HTML(renderNextUpTasksHTML(data.Tasks.NextUp, data.JiraInfo, data.PRInfo, opts))
+	}
+	funcs["blockedSection"] = func() htmltemplate.HTML {
+		return htmltemplate.HTML(renderBlockedTasksHTML(data.Tasks.Blocked, data.JiraInfo, opts))
+	}
+	funcs["qcGoalsSection"] = func() htmltemplate.HTML {
+		return htmltemplate.HTML(renderQCGoalsHTML(data.Tasks.ByQCGoal))
+	}
+	funcs["staleSection"] = func() htmltemplate.HTML {
+		return htmltemplate.HTML(renderStaleTasksHTML(data.Tasks.Stale))
+	}
+	funcs["hoursDetailSection"] = func() htmltemplate.HTML {
+		return htmltemplate.HTML(renderHoursDetailHTML(opts.HoursDetail))
+	}
+	funcs["ticketURL"] = func(ticket string) string {
+		key := jira.ExtractTicketID(ticket)
+		if key == "" {
+			return ""
+		}
+		if info, exists := data.JiraInfo[key]; exists && info.URL != "" {
+			return info.URL
+		}
+		return jira.TicketURL(key)
+	}
+	funcs["themeCSS"] = func() htmltemplate.CSS {
+		return htmltemplate.CSS(themeCSS[theme])
+	}
+	funcs["footer"] = func() string {
+		return opts.HTMLFooter
+	}
+	return funcs
+}
+
+// helperFuncs returns the functions shared by text and HTML report templates:
+//
+//   - sortedTickets: the keys of a ticket map, feature work first, each sorted naturally
+//   - isNonFeature: whether a ticket's tasks are non-feature work
+//   - descriptions: a ticket's completed descriptions, oldest first and deduplicated
+//   - nextUpDescription: a ticket's most recent upnext (or last) description
+//   - prLinks: a ticket's PR URLs, in --pr-order
+//   - prLabel: a PR URL labeled "repo#N: title" when its title is known
+//   - jiraSummary: a ticket's JIRA summary, or "" when unknown
+//   - jiraField: the value of a ticket's extra JIRA field, or "" when unknown
+//   - blockedSince: "Since <date>: <description>" for a blocked task
+func helperFuncs(data TemplateData, opts Options) map[string]any {
+	return map[string]any{
+		"sortedTickets": func(tasks map[string][]model.TaskWithDate) []string {
+			featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
+			return append(featureTickets, nonFeatureTickets...)
+		},
+		"isNonFeature": opts.isNonFeatureGroup,
+		"descriptions": func(taskList []model.TaskWithDate) []string {
+			sortByDate(taskList)
+			descriptions, _ := opts.collectDescriptionsAndPRs(taskList)
+			return opts.deduplicateDescriptions(descriptions)
+		},
+		"nextUpDescription": func(taskList []model.TaskWithDate) string {
+			sortByDate(taskList)
+			description, _ := opts.latestNextUpDescription(taskList)
+			return description
+		},
+		"prLinks": func(taskList []model.TaskWithDate) []string {
+			sortByDate(taskList)
+			_, prLinks := opts.collectDescriptionsAndPRs(taskList)
+			return prLinks
+		},
+		"prLabel": func(prURL string) string {
+			return github.Label(prURL, data.PRInfo)
+		},
+		"jiraSummary": func(ticket string) string {
+			return data.JiraInfo[jira.ExtractTicketID(ticket)].Summary
+		},
+		"jiraField": func(ticket, field string) string {
+			return data.JiraInfo[jira.ExtractTicketID(ticket)].Fields[field]
+		},
+		"blockedSince": blockedSince,
+	}
+}
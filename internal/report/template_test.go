@@ -0,0 +1,154 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func templateTestData() TemplateData {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-10", Status: model.StatusCompleted, Description: "Shipped the parser", GithubPR: "https://github.com/example/repo/pull/1"},
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Reviewing", UpnextDescription: "Finish review", Blocker: "Waiting on CI"},
+		}},
+	}
+	dates := []string{"2024-08-01"}
+	return TemplateData{
+		StartDate: "2024-08-01",
+		EndDate:   "2024-08-01",
+		Dates:     dates,
+		Tasks:     CategorizeTasks(workData, dates, Options{}),
+		JiraInfo:  map[string]jira.TicketInfo{"PROJ-10": {Key: "PROJ-10", Summary: "Parser rewrite", Fields: map[string]string{"customfield_10002": "5"}}},
+	}
+}
+
+func TestDefaultTextTemplate(t *testing.T) {
+	data := templateTestData()
+	tmpl, err := ParseTextTemplate("")
+	if err != nil {
+		t.Fatalf("ParseTextTemplate returned error: %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := ExecuteTextTemplate(&got, tmpl, data, Options{}); err != nil {
+		t.Fatalf("ExecuteTextTemplate returned error: %v", err)
+	}
+
+	// The default template matches the built-in printers
+	var want bytes.Buffer
+	want.WriteString("Work Report (2024-08-01 to 2024-08-01)\n=======Autogenerated by TaskLedger=======\n")
+	PrintCompletedTasks(&want, data.Tasks.Completed, nil, Options{})
+	PrintNextUpTasks(&want, data.Tasks.NextUp, nil, Options{})
+	PrintBlockedTasks(&want, data.Tasks.Blocked, Options{})
+	PrintQCGoals(&want, data.Tasks.ByQCGoal, Options{})
+	if got.String() != want.String() {
+		t.Errorf("Unexpected default template output:\ngot:\n%q\nwant:\n%q", got.String(), want.String())
+	}
+}
+
+func TestCustomTextTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	custom := `Done ({{.StartDate}}):
+{{range sortedTickets .Tasks.Completed}}- {{.}} {{jiraSummary .}}{{with jiraField . "customfield_10002"}} ({{.}} points){{end}}
+{{range descriptions (index $.Tasks.Completed .)}}  * {{.}}
+{{end}}{{range prLinks (index $.Tasks.Completed .)}}  * {{prLabel .}}
+{{end}}{{end}}Next:
+{{range sortedTickets .Tasks.NextUp}}- {{.}}: {{nextUpDescription (index $.Tasks.NextUp .)}}
+{{end}}Stuck:
+{{range .Tasks.Blocked}}- {{.JiraTicket}}: {{.Blocker}} ({{blockedSince .}})
+{{end}}`
+	if err := os.WriteFile(path, []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	tmpl, err := ParseTextTemplate(path)
+	if err != nil {
+		t.Fatalf("ParseTextTemplate returned error: %v", err)
+	}
+	var got bytes.Buffer
+	if err := ExecuteTextTemplate(&got, tmpl, templateTestData(), Options{}); err != nil {
+		t.Fatalf("ExecuteTextTemplate returned error: %v", err)
+	}
+
+	expected := "Done (2024-08-01):\n" +
+		"- PROJ-2 \n" +
+		"  * Reviewing\n" +
+		"- PROJ-10 Parser rewrite (5 points)\n" +
+		"  * Shipped the parser\n" +
+		"  * https://github.com/example/repo/pull/1\n" +
+		"Next:\n" +
+		"- PROJ-2: Finish review\n" +
+		"Stuck:\n" +
+		"- PROJ-2: Waiting on CI (Since 2024-08-01: Reviewing)\n"
+	if got.String() != expected {
+		t.Errorf("Unexpected custom template output:\ngot:\n%q\nwant:\n%q", got.String(), expected)
+	}
+}
+
+func TestParseTextTemplateErrors(t *testing.T) {
+	if _, err := ParseTextTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("Expected an error for a missing template file")
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(path, []byte("{{range .Tasks.Completed}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if _, err := ParseTextTemplate(path); err == nil {
+		t.Error("Expected an error for an unterminated action")
+	}
+}
+
+func TestCustomHTMLTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html.tmpl")
+	custom := `<h1>Team update {{.StartDate}}</h1><ul>
+{{- range sortedTickets .Tasks.Completed}}<li><a href="{{ticketURL .}}">{{.}}</a> {{jiraSummary .}}</li>{{end -}}
+</ul>{{blockedSection}}`
+	if err := os.WriteFile(path, []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	tmpl, err := ParseHTMLTemplate(path)
+	if err != nil {
+		t.Fatalf("ParseHTMLTemplate returned error: %v", err)
+	}
+	data := templateTestData()
+	data.Tasks.Completed["<b>not markup</b>"] = []model.TaskWithDate{{Date: "2024-08-01"}}
+	got, err := GenerateHTMLWithTemplate(tmpl, data.Dates, data.Tasks, data.JiraInfo, nil, ThemePlain, Options{})
+	if err != nil {
+		t.Fatalf("GenerateHTMLWithTemplate returned error: %v", err)
+	}
+
+	expected := `<h1>Team update 2024-08-01</h1><ul>` +
+		`<li><a href="` + jira.TicketURL("PROJ-2") + `">PROJ-2</a> </li>` +
+		`<li><a href="` + jira.TicketURL("PROJ-10") + `">PROJ-10</a> Parser rewrite</li>` +
+		`<li><a href="">&lt;b&gt;not markup&lt;/b&gt;</a> </li>` +
+		`</ul>` + renderBlockedTasksHTML(data.Tasks.Blocked, data.JiraInfo, Options{})
+	if got != expected {
+		t.Errorf("Unexpected custom HTML template output:\ngot:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestDefaultHTMLTemplate(t *testing.T) {
+	tmpl, err := ParseHTMLTemplate("")
+	if err != nil {
+		t.Fatalf("ParseHTMLTemplate returned error: %v", err)
+	}
+	data := templateTestData()
+	got, err := GenerateHTMLWithTemplate(tmpl, data.Dates, data.Tasks, data.JiraInfo, nil, ThemeDark, Options{})
+	if err != nil {
+		t.Fatalf("GenerateHTMLWithTemplate returned error: %v", err)
+	}
+	if want := GenerateHTML(data.Dates, data.Tasks, data.JiraInfo, nil, ThemeDark, Options{}); got != want {
+		t.Errorf("Expected the default template to match GenerateHTML:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if !strings.HasPrefix(got, "<!DOCTYPE html>\n<html>\n<head>\n    <meta charset=\"UTF-8\">\n    <style>"+themeCSS[ThemeDark]+"</style>") {
+		t.Errorf("Expected the dark theme stylesheet to be kept verbatim, got:\n%s", got)
+	}
+}
@@ -3,9 +3,9 @@ package report
 import (
 	"fmt"
 	"io"
-	"sort"
 	"strings"
 
+	"github.com/bryan-cox/taskledger/internal/github"
 	"github.com/bryan-cox/taskledger/internal/model"
 )
 
@@ -14,114 +14,78 @@ const (
 	TextHeaderCompleted      = "\n🦀 Thing I've been working on"
 	TextHeaderNextUp         = "\n:starfleet: Thing I plan on working on next"
 	TextHeaderBlocked        = "\n:facepalm: Thing that is blocking me or that I could use some help / discussion about"
+	TextHeaderQCGoals        = "\n🎯 Quarterly goals"
 	textNonFeatureWorkHeader = "Non-feature work"
 )
 
-// PrintCompletedTasks prints the completed tasks section to the writer.
-func PrintCompletedTasks(out io.Writer, tasks map[string][]model.TaskWithDate) {
-	if len(tasks) == 0 {
+// PrintCompletedTasks prints the completed tasks section to the writer. PR links
+// are labeled with their titles from prInfo when known.
+func PrintCompletedTasks(out io.Writer, tasks map[string][]model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) {
+	if len(tasks) == 0 && !opts.IncludeEmptySections {
 		return
 	}
-	fmt.Fprintln(out, TextHeaderCompleted)
+	fmt.Fprintln(out, paintHeader(out, sectionHeader(TextHeaderCompleted, opts.HeaderCompleted, "\n%s")))
 
 	// Separate feature work and non-feature work
-	var featureTickets []string
-	var nonFeatureTickets []string
-
-	for ticket := range tasks {
-		taskList := tasks[ticket]
-		// Check if any task in the group has a PR (for NO-JIRA check)
-		hasPR := false
-		for _, t := range taskList {
-			if t.GithubPR != "" {
-				hasPR = true
-				break
-			}
-		}
-
-		prArg := ""
-		if hasPR {
-			prArg = "has-pr"
-		}
-
-		if IsNonFeatureWork(ticket, prArg) {
-			nonFeatureTickets = append(nonFeatureTickets, ticket)
-		} else {
-			featureTickets = append(featureTickets, ticket)
-		}
-	}
-	sort.Strings(featureTickets)
-	sort.Strings(nonFeatureTickets)
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(tasks)
 
 	// Print feature work first
 	for _, ticket := range featureTickets {
-		printTicketEntry(out, ticket, tasks[ticket])
+		printTicketEntry(out, ticket, tasks[ticket], prInfo, opts)
 	}
 
 	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
 	if len(nonFeatureTickets) > 0 {
-		fmt.Fprintf(out, "    • %s: \n", textNonFeatureWorkHeader)
+		fmt.Fprintf(out, "    • %s: \n", paint(out, ansiComplete, textNonFeatureWorkHeader))
 		for _, ticket := range nonFeatureTickets {
-			printNonFeatureSubEntry(out, ticket, tasks[ticket])
+			printNonFeatureSubEntry(out, ticket, tasks[ticket], prInfo, opts)
 		}
 	}
 }
 
+// textPRLinks renders PR links as a semicolon-separated list of labels.
+func textPRLinks(prLinks []string, prInfo map[string]github.PRInfo) string {
+	var labels []string
+	for _, link := range prLinks {
+		labels = append(labels, github.Label(link, prInfo))
+	}
+	return strings.Join(labels, "; ")
+}
+
 // printTicketEntry prints a single ticket entry with its descriptions and PRs.
-func printTicketEntry(out io.Writer, ticket string, taskList []model.TaskWithDate) {
+func printTicketEntry(out io.Writer, ticket string, taskList []model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) {
 	// Sort tasks chronologically (oldest to newest)
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
-
-	// Print the Jira ticket header
-	fmt.Fprintf(out, "    • %s: \n", ticket)
+	sortByDate(taskList)
 
 	// Collect all descriptions and unique PR links
-	var descriptions []string
-	prLinks := make(map[string]bool)
+	descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
 
-	for _, taskWithDate := range taskList {
-		descriptions = append(descriptions, taskWithDate.GetDescriptions()...)
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
+	// Print the Jira ticket header
+	header := ticket
+	if isInlineEntry(ticket) {
+		header, descriptions = inlineHeader(descriptions...)
 	}
+	fmt.Fprintf(out, "    • %s: \n", paint(out, ansiComplete, header))
 
 	// Print all descriptions (deduplicated)
-	descriptions = deduplicateDescriptions(descriptions)
+	descriptions = opts.deduplicateDescriptions(descriptions)
 	for _, desc := range descriptions {
-		fmt.Fprintf(out, "        ◦ %s\n", desc)
+		opts.printWrapped(out, "        ◦ %s", desc)
 	}
 
 	// Print PR links
 	if len(prLinks) > 0 {
-		var links []string
-		for link := range prLinks {
-			links = append(links, link)
-		}
-		sort.Strings(links)
-		fmt.Fprintf(out, "        ◦ PR(s): %s\n", strings.Join(links, "; "))
+		fmt.Fprintf(out, "        ◦ %s\n", paint(out, ansiDim, "PR(s): "+textPRLinks(prLinks, prInfo)))
 	}
 }
 
 // printNonFeatureSubEntry prints a non-feature work sub-entry with ticket name as header.
-func printNonFeatureSubEntry(out io.Writer, ticket string, taskList []model.TaskWithDate) {
+func printNonFeatureSubEntry(out io.Writer, ticket string, taskList []model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) {
 	// Sort tasks chronologically (oldest to newest)
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
+	sortByDate(taskList)
 
 	// Collect all descriptions and unique PR links
-	var descriptions []string
-	prLinks := make(map[string]bool)
-
-	for _, taskWithDate := range taskList {
-		descriptions = append(descriptions, taskWithDate.GetDescriptions()...)
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
-	}
+	descriptions, prLinks := opts.collectDescriptionsAndPRs(taskList)
 
 	// Determine header: for synthetic keys, use the first description
 	header := ticket
@@ -133,151 +97,79 @@ func printNonFeatureSubEntry(out io.Writer, ticket string, taskList []model.Task
 			header = "Misc"
 		}
 	}
-	fmt.Fprintf(out, "        ◦ %s\n", header)
+	opts.printWrapped(out, "        ◦ %s", header)
 
 	// Print remaining descriptions (third-level indent), deduplicated and sorted
-	descriptions = deduplicateDescriptions(descriptions)
+	descriptions = opts.deduplicateDescriptions(descriptions)
 	sortDescriptions(descriptions)
 	for _, desc := range descriptions {
-		fmt.Fprintf(out, "            ▪ %s\n", desc)
+		opts.printWrapped(out, "            ▪ %s", desc)
 	}
 
 	// Print PR links
 	if len(prLinks) > 0 {
-		var links []string
-		for link := range prLinks {
-			links = append(links, link)
-		}
-		sort.Strings(links)
-		fmt.Fprintf(out, "            ▪ PR(s): %s\n", strings.Join(links, "; "))
+		fmt.Fprintf(out, "            ▪ %s\n", paint(out, ansiDim, "PR(s): "+textPRLinks(prLinks, prInfo)))
 	}
 }
 
-// PrintNextUpTasks prints the next up tasks section to the writer.
-func PrintNextUpTasks(out io.Writer, nextUp map[string][]model.TaskWithDate) {
-	if len(nextUp) == 0 {
+// PrintNextUpTasks prints the next up tasks section to the writer. PR links are
+// labeled with their titles from prInfo when known.
+func PrintNextUpTasks(out io.Writer, nextUp map[string][]model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) {
+	if len(nextUp) == 0 && !opts.IncludeEmptySections {
 		return
 	}
-	fmt.Fprintln(out, TextHeaderNextUp)
+	fmt.Fprintln(out, paintHeader(out, sectionHeader(TextHeaderNextUp, opts.HeaderNextUp, "\n%s")))
 
 	// Separate feature work and non-feature work
-	var featureTickets []string
-	var nonFeatureTickets []string
-
-	for ticket := range nextUp {
-		taskList := nextUp[ticket]
-		// Check if any task in the group has a PR (for NO-JIRA check)
-		hasPR := false
-		for _, t := range taskList {
-			if t.GithubPR != "" {
-				hasPR = true
-				break
-			}
-		}
-
-		prArg := ""
-		if hasPR {
-			prArg = "has-pr"
-		}
-
-		if IsNonFeatureWork(ticket, prArg) {
-			nonFeatureTickets = append(nonFeatureTickets, ticket)
-		} else {
-			featureTickets = append(featureTickets, ticket)
-		}
-	}
-	sort.Strings(featureTickets)
-	sort.Strings(nonFeatureTickets)
+	featureTickets, nonFeatureTickets := opts.splitFeatureWork(nextUp)
 
 	// Print feature work first
 	for _, ticket := range featureTickets {
-		printNextUpTicketEntry(out, ticket, nextUp[ticket])
+		printNextUpTicketEntry(out, ticket, nextUp[ticket], prInfo, opts)
 	}
 
 	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
 	if len(nonFeatureTickets) > 0 {
-		fmt.Fprintf(out, "    • %s\n", textNonFeatureWorkHeader)
+		fmt.Fprintf(out, "    • %s\n", paint(out, ansiNextUp, textNonFeatureWorkHeader))
 		for _, ticket := range nonFeatureTickets {
-			printNonFeatureNextUpSubEntry(out, ticket, nextUp[ticket])
+			printNonFeatureNextUpSubEntry(out, ticket, nextUp[ticket], prInfo, opts)
 		}
 	}
 }
 
 // printNextUpTicketEntry prints a single next up ticket entry.
-func printNextUpTicketEntry(out io.Writer, ticket string, taskList []model.TaskWithDate) {
+func printNextUpTicketEntry(out io.Writer, ticket string, taskList []model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) {
 	// Sort tasks chronologically (oldest to newest)
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
-
-	fmt.Fprintf(out, "    • %s\n", ticket)
+	sortByDate(taskList)
 
 	// For next up tasks, only use the most recent entry per ticket
-	var mostRecentDesc string
-	prLinks := make(map[string]bool)
-
-	// Work backwards to find the most recent upnext description
-	for i := len(taskList) - 1; i >= 0; i-- {
-		taskWithDate := taskList[i]
-		if mostRecentDesc == "" {
-			if taskWithDate.UpnextDescription != "" {
-				mostRecentDesc = taskWithDate.UpnextDescription
-			} else {
-				allDescs := taskWithDate.GetDescriptions()
-				if len(allDescs) > 0 {
-					mostRecentDesc = allDescs[len(allDescs)-1]
-				}
-			}
-		}
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
+	mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
+
+	header := ticket
+	if isInlineEntry(ticket) {
+		header, _ = inlineHeader(mostRecentDesc)
+		mostRecentDesc = ""
 	}
+	fmt.Fprintf(out, "    • %s\n", paint(out, ansiNextUp, header))
 
 	// Print the most recent description
 	if mostRecentDesc != "" {
-		fmt.Fprintf(out, "        ◦ %s\n", mostRecentDesc)
+		opts.printWrapped(out, "        ◦ %s", mostRecentDesc)
 	}
 
 	// Print PR links
 	if len(prLinks) > 0 {
-		var links []string
-		for link := range prLinks {
-			links = append(links, link)
-		}
-		sort.Strings(links)
-		fmt.Fprintf(out, "        ◦ PR(s): %s\n", strings.Join(links, "; "))
+		fmt.Fprintf(out, "        ◦ %s\n", paint(out, ansiDim, "PR(s): "+textPRLinks(prLinks, prInfo)))
 	}
 }
 
 // printNonFeatureNextUpSubEntry prints a non-feature next up sub-entry.
-func printNonFeatureNextUpSubEntry(out io.Writer, ticket string, taskList []model.TaskWithDate) {
+func printNonFeatureNextUpSubEntry(out io.Writer, ticket string, taskList []model.TaskWithDate, prInfo map[string]github.PRInfo, opts Options) {
 	// Sort tasks chronologically (oldest to newest)
-	sort.Slice(taskList, func(i, j int) bool {
-		return taskList[i].Date < taskList[j].Date
-	})
+	sortByDate(taskList)
 
 	// For next up tasks, only use the most recent entry per ticket
-	var mostRecentDesc string
-	prLinks := make(map[string]bool)
-
-	// Work backwards to find the most recent upnext description
-	for i := len(taskList) - 1; i >= 0; i-- {
-		taskWithDate := taskList[i]
-		if mostRecentDesc == "" {
-			if taskWithDate.UpnextDescription != "" {
-				mostRecentDesc = taskWithDate.UpnextDescription
-			} else {
-				allDescs := taskWithDate.GetDescriptions()
-				if len(allDescs) > 0 {
-					mostRecentDesc = allDescs[len(allDescs)-1]
-				}
-			}
-		}
-		if taskWithDate.GithubPR != "" {
-			prLinks[taskWithDate.GithubPR] = true
-		}
-	}
+	mostRecentDesc, prLinks := opts.latestNextUpDescription(taskList)
 
 	// Determine header: for synthetic keys, use the upnext description or first task description
 	header := ticket
@@ -289,60 +181,73 @@ func printNonFeatureNextUpSubEntry(out io.Writer, ticket string, taskList []mode
 			header = "Misc"
 		}
 	}
-	fmt.Fprintf(out, "        ◦ %s\n", header)
+	opts.printWrapped(out, "        ◦ %s", header)
 
 	// Print the most recent description (third-level indent)
 	if mostRecentDesc != "" {
-		fmt.Fprintf(out, "            ▪ %s\n", mostRecentDesc)
+		opts.printWrapped(out, "            ▪ %s", mostRecentDesc)
 	}
 
 	// Print PR links
 	if len(prLinks) > 0 {
-		var links []string
-		for link := range prLinks {
-			links = append(links, link)
-		}
-		sort.Strings(links)
-		fmt.Fprintf(out, "            ▪ PR(s): %s\n", strings.Join(links, "; "))
+		fmt.Fprintf(out, "            ▪ %s\n", paint(out, ansiDim, "PR(s): "+textPRLinks(prLinks, prInfo)))
 	}
 }
 
 // PrintBlockedTasks prints the blocked tasks section to the writer.
-func PrintBlockedTasks(out io.Writer, blocked []model.Task) {
-	if len(blocked) == 0 {
+func PrintBlockedTasks(out io.Writer, blocked []model.TaskWithDate, opts Options) {
+	if len(blocked) == 0 && !opts.IncludeEmptySections {
 		return
 	}
 
 	// Separate feature work and non-feature work
-	var featureTasks []model.Task
-	var nonFeatureTasks []model.Task
+	var featureTasks []model.TaskWithDate
+	var nonFeatureTasks []model.TaskWithDate
 
 	for _, task := range blocked {
-		if IsNonFeatureWork(task.JiraTicket, task.GithubPR) {
+		if opts.isGroupedNonFeature(task) {
 			nonFeatureTasks = append(nonFeatureTasks, task)
 		} else {
 			featureTasks = append(featureTasks, task)
 		}
 	}
 
-	fmt.Fprintln(out, TextHeaderBlocked)
+	fmt.Fprintln(out, paintHeader(out, sectionHeader(TextHeaderBlocked, opts.HeaderBlocked, "\n%s")))
 
 	// Print feature work first
 	for _, task := range featureTasks {
-		fmt.Fprintf(out, "    • %s \n", task.JiraTicket)
-		fmt.Fprintf(out, "        ◦ Blocker: %s\n", task.Blocker)
+		fmt.Fprintf(out, "    • %s \n", paint(out, ansiBlocked, opts.blockedTicket(task)))
+		opts.printWrapped(out, "        ◦ Blocker: %s", task.Blocker)
+		opts.printWrapped(out, "        ◦ %s", paint(out, ansiDim, blockedSince(task)))
 	}
 
 	// Print non-feature work at the end (grouped under "Non-feature work" with sub-entries)
 	if len(nonFeatureTasks) > 0 {
-		fmt.Fprintf(out, "    • %s \n", textNonFeatureWorkHeader)
+		fmt.Fprintf(out, "    • %s \n", paint(out, ansiBlocked, textNonFeatureWorkHeader))
 		for _, task := range nonFeatureTasks {
 			header := task.JiraTicket
 			if header == "" {
 				header = "Misc"
 			}
-			fmt.Fprintf(out, "        ◦ %s\n", header)
-			fmt.Fprintf(out, "            ▪ Blocker: %s\n", task.Blocker)
+			opts.printWrapped(out, "        ◦ %s", header)
+			opts.printWrapped(out, "            ▪ Blocker: %s", task.Blocker)
+			opts.printWrapped(out, "            ▪ %s", paint(out, ansiDim, blockedSince(task)))
+		}
+	}
+}
+
+// PrintQCGoals prints the quarterly goals section to the writer, listing the work
+// done toward each goal.
+func PrintQCGoals(out io.Writer, byGoal map[string][]model.TaskWithDate, opts Options) {
+	if len(byGoal) == 0 {
+		return
+	}
+	fmt.Fprintln(out, paintHeader(out, TextHeaderQCGoals))
+
+	for _, goal := range sortedGoals(byGoal) {
+		fmt.Fprintf(out, "    • %s\n", goal)
+		for _, entry := range qcGoalEntries(byGoal[goal]) {
+			opts.printWrapped(out, "        ◦ %s", entry)
 		}
 	}
 }
@@ -0,0 +1,136 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bryan-cox/taskledger/internal/jira"
+	"github.com/bryan-cox/taskledger/internal/model"
+)
+
+func TestTextReportBullets(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Shipped the parser"},
+			{JiraTicket: "PROJ-2", Status: model.StatusInProgress, Description: "Reviewing", Blocker: "Waiting on CI"},
+			{Status: model.StatusCompleted, Description: "Team wiki", Descriptions: []string{"Fixed links"}},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01"}, Options{})
+
+	var out bytes.Buffer
+	PrintCompletedTasks(&out, tasks.Completed, nil, Options{})
+	PrintNextUpTasks(&out, tasks.NextUp, nil, Options{})
+	PrintBlockedTasks(&out, tasks.Blocked, Options{})
+	output := out.String()
+
+	for _, want := range []string{"🦀 Thing I've been working on", "    • PROJ-1", "        ◦ Shipped the parser", "            ▪ Fixed links"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the text report, got:\n%s", want, output)
+		}
+	}
+	// UTF-8 bullets decoded as Windows-1252
+	for _, mojibake := range []string{"â€¢", "â—¦", "â–ª", "ðŸ¦€"} {
+		if strings.Contains(output, mojibake) {
+			t.Errorf("Text report contains mojibake %q:\n%s", mojibake, output)
+		}
+	}
+}
+
+func TestCollapseCompleted(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Drafted the design", GithubPR: "https://github.com/example/repo/pull/1"},
+		}},
+		"2024-08-02": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Implemented the parser"},
+		}},
+		"2024-08-03": {Tasks: []model.Task{
+			{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "Added tests", GithubPR: "https://github.com/example/repo/pull/2"},
+		}},
+	}
+	tasks := CategorizeTasks(workData, []string{"2024-08-01", "2024-08-02", "2024-08-03"}, Options{})
+	render := func(opts Options) string {
+		var out bytes.Buffer
+		PrintCompletedTasks(&out, tasks.Completed, nil, opts)
+		return out.String()
+	}
+	prs := "PR(s): https://github.com/example/repo/pull/1; https://github.com/example/repo/pull/2"
+
+	full := render(Options{})
+	for _, want := range []string{"Drafted the design", "Implemented the parser", "Added tests", prs} {
+		if !strings.Contains(full, want) {
+			t.Errorf("Expected %q in the full report, got:\n%s", want, full)
+		}
+	}
+
+	collapsed := render(Options{CollapseCompleted: true})
+	for _, want := range []string{"        ◦ Added tests\n", prs} {
+		if !strings.Contains(collapsed, want) {
+			t.Errorf("Expected %q in the collapsed report, got:\n%s", want, collapsed)
+		}
+	}
+	for _, older := range []string{"Drafted the design", "Implemented the parser"} {
+		if strings.Contains(collapsed, older) {
+			t.Errorf("Collapsed report should not include %q:\n%s", older, collapsed)
+		}
+	}
+}
+
+func TestCountDuplicateDescriptions(t *testing.T) {
+	workData := model.WorkData{
+		"2024-08-01": {Tasks: []model.Task{{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "code review"}}},
+		"2024-08-02": {Tasks: []model.Task{{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Descriptions: []string{"Fixed the parser", "code review"}}}},
+		"2024-08-03": {Tasks: []model.Task{{JiraTicket: "PROJ-1", Status: model.StatusCompleted, Description: "code review"}}},
+	}
+	dates := []string{"2024-08-01", "2024-08-02", "2024-08-03"}
+
+	var plain strings.Builder
+	PrintCompletedTasks(&plain, CategorizeTasks(workData, dates, Options{}).Completed, nil, Options{})
+	if strings.Count(plain.String(), "code review") != 1 || strings.Contains(plain.String(), "(x3)") {
+		t.Errorf("Expected a single unannotated description by default:\n%s", plain.String())
+	}
+
+	opts := Options{CountDuplicates: true}
+	var text strings.Builder
+	PrintCompletedTasks(&text, CategorizeTasks(workData, dates, opts).Completed, nil, opts)
+	expected := TextHeaderCompleted + "\n" +
+		"    • PROJ-1: \n" +
+		"        ◦ code review (x3)\n" +
+		"        ◦ Fixed the parser\n"
+	if text.String() != expected {
+		t.Errorf("Unexpected text output:\ngot:\n%q\nwant:\n%q", text.String(), expected)
+	}
+
+	htmlOutput := GenerateHTML(dates, CategorizeTasks(workData, dates, opts), map[string]jira.TicketInfo{}, nil, ThemePlain, opts)
+	if !strings.Contains(htmlOutput, "code review (x3)") || strings.Contains(htmlOutput, "Fixed the parser (x") {
+		t.Errorf("Expected only the repeated description to be annotated in HTML:\n%s", htmlOutput)
+	}
+}
+
+func TestPrintBlockedTasksWrapped(t *testing.T) {
+	blocked := []model.TaskWithDate{{
+		Task: model.Task{JiraTicket: "PROJ-1", Status: model.StatusInProgress, Description: "Waiting on the platform team to rotate the certificates", Blocker: "Needs access"},
+		Date: "2024-08-01",
+	}}
+
+	var out bytes.Buffer
+	PrintBlockedTasks(&out, blocked, Options{WrapWidth: 40})
+	expected := TextHeaderBlocked + "\n" +
+		"    • PROJ-1 \n" +
+		"        ◦ Blocker: Needs access\n" +
+		"        ◦ Since 2024-08-01: Waiting on\n" +
+		"          the platform team to rotate\n" +
+		"          the certificates\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected wrapped blocked section:\ngot:\n%q\nwant:\n%q", out.String(), expected)
+	}
+
+	// Color codes don't count toward the width, and the dates stay dimmed
+	var colored bytes.Buffer
+	PrintBlockedTasks(NewColorWriter(&colored), blocked, Options{WrapWidth: 40})
+	if !strings.Contains(colored.String(), ansiDim+"Since 2024-08-01") || strings.Count(colored.String(), "\n") != strings.Count(expected, "\n") {
+		t.Errorf("Expected the same wrapping with the since line dimmed, got:\n%q", colored.String())
+	}
+}
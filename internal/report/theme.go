@@ -0,0 +1,91 @@
+package report
+
+// HTML report themes. The plain theme emits unstyled markup that pastes cleanly
+// into Slack; the others add a stylesheet for reading in a browser.
+const (
+	ThemePlain = "plain"
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// themeCSS maps each styled theme to its stylesheet.
+var themeCSS = map[string]string{
+	ThemeLight: lightThemeVars + baseThemeCSS,
+	ThemeDark:  darkThemeVars + baseThemeCSS,
+}
+
+// IsValidTheme reports whether theme names a supported HTML theme.
+func IsValidTheme(theme string) bool {
+	_, styled := themeCSS[theme]
+	return styled || theme == ThemePlain
+}
+
+// lightThemeVars defines the color palette for the light theme.
+const lightThemeVars = `
+:root {
+    --fg: #24292f;
+    --bg: #ffffff;
+    --muted: #57606a;
+    --rule: #d0d7de;
+    --heading: #0550ae;
+    --accent: #0969da;
+    --link: #0969da;
+    --link-hover: #0550ae;
+    --link-hover-bg: #ddf4ff;
+}`
+
+// darkThemeVars defines the color palette for the dark theme.
+const darkThemeVars = `
+:root {
+    --fg: #e6edf3;
+    --bg: #0d1117;
+    --muted: #8d96a0;
+    --rule: #30363d;
+    --heading: #79c0ff;
+    --accent: #388bfd;
+    --link: #58a6ff;
+    --link-hover: #a5d6ff;
+    --link-hover-bg: #161b22;
+}`
+
+// baseThemeCSS lays out the report using the palette defined by a theme.
+const baseThemeCSS = `
+body {
+    font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+    font-size: 16px;
+    line-height: 1.6;
+    max-width: 900px;
+    margin: 2rem auto;
+    padding: 0 1rem;
+    color: var(--fg);
+    background: var(--bg);
+}
+h1 {
+    font-size: 1.8rem;
+    padding-bottom: 0.3rem;
+    border-bottom: 1px solid var(--rule);
+}
+h2 {
+    font-size: 1.3rem;
+    margin-top: 2rem;
+    padding-left: 0.6rem;
+    color: var(--heading);
+    border-left: 4px solid var(--accent);
+}
+li {
+    margin-bottom: 0.6rem;
+}
+em {
+    color: var(--muted);
+}
+a {
+    color: var(--link);
+    text-decoration: none;
+    border-radius: 3px;
+}
+a:hover {
+    color: var(--link-hover);
+    background: var(--link-hover-bg);
+    text-decoration: underline;
+}
+`
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WrapLine wraps line at width columns between words. The leading indentation
+// and a bullet marker (a single symbol followed by a space) stay on the first
+// line, and continuation lines are indented to start under the text after the
+// bullet. A word longer than the room left is put on a line of its own rather
+// than split. Width counts runes, not ANSI color codes; a width of zero or less
+// returns line as is.
+func WrapLine(line string, width int) string {
+	if width <= 0 || visibleLength(line) <= width {
+		return line
+	}
+
+	text := strings.TrimLeft(line, " ")
+	prefix := line[:len(line)-len(text)]
+	if marker, size := utf8.DecodeRuneInString(text); size > 0 && !unicode.IsLetter(marker) && !unicode.IsDigit(marker) && strings.HasPrefix(text[size:], " ") {
+		prefix += text[:size+1]
+		text = text[size+1:]
+	}
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return line
+	}
+	hanging := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+
+	var sb strings.Builder
+	current, length := prefix+words[0], visibleLength(prefix+words[0])
+	for _, word := range words[1:] {
+		wordLength := visibleLength(word)
+		if length+1+wordLength > width {
+			sb.WriteString(current + "\n")
+			current, length = hanging+word, len(hanging)+wordLength
+			continue
+		}
+		current += " " + word
+		length += 1 + wordLength
+	}
+	sb.WriteString(current)
+	return sb.String()
+}
+
+// visibleLength counts the runes of s that take up a column, skipping ANSI
+// color sequences such as those added by paint.
+func visibleLength(s string) int {
+	length := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			if end := strings.IndexByte(s[i:], 'm'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		length++
+	}
+	return length
+}
+
+// printWrapped prints a bullet line, wrapped at opts.WrapWidth.
+func (opts Options) printWrapped(out io.Writer, format string, args ...any) {
+	fmt.Fprintln(out, WrapLine(fmt.Sprintf(format, args...), opts.WrapWidth))
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "second level bullet",
+			line:  "        ◦ Added retries to the token refresh path",
+			width: 30,
+			want: []string{
+				"        ◦ Added retries to the",
+				"          token refresh path",
+			},
+		},
+		{
+			name:  "third level bullet",
+			line:  "            ▪ Rotated the staging certificates before expiry",
+			width: 33,
+			want: []string{
+				"            ▪ Rotated the staging",
+				"              certificates before",
+				"              expiry",
+			},
+		},
+		{
+			name:  "word longer than the width stays whole",
+			line:  "    • See https://example.com/a/very/long/link for details",
+			width: 20,
+			want: []string{
+				"    • See",
+				"      https://example.com/a/very/long/link",
+				"      for details",
+			},
+		},
+		{
+			name:  "no bullet keeps the indentation",
+			line:  "  plain words that need wrapping",
+			width: 16,
+			want: []string{
+				"  plain words",
+				"  that need",
+				"  wrapping",
+			},
+		},
+		{
+			name:  "color codes do not count",
+			line:  "    • " + ansiComplete + "PROJ-1" + ansiReset + " [completed] Fixed it",
+			width: 30,
+			want: []string{
+				"    • " + ansiComplete + "PROJ-1" + ansiReset + " [completed] Fixed",
+				"      it",
+			},
+		},
+		{
+			name:  "short line",
+			line:  "        ◦ Fits",
+			width: 30,
+			want:  []string{"        ◦ Fits"},
+		},
+		{
+			name:  "no wrapping",
+			line:  "        ◦ Added retries to the token refresh path",
+			width: 0,
+			want:  []string{"        ◦ Added retries to the token refresh path"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapLine(tt.line, tt.width); got != strings.Join(tt.want, "\n") {
+				t.Errorf("WrapLine(%q, %d) =\n%s\nwant\n%s", tt.line, tt.width, got, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
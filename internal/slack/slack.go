@@ -0,0 +1,52 @@
+// Package slack posts reports to Slack incoming webhooks.
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodyBytes limits how much of an error response body is included in errors.
+const maxErrorBodyBytes = 1024
+
+// HTTPClient is shared by all webhook requests so keep-alive connections are
+// reused across posts.
+var HTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// textPayload is the simplest incoming webhook message: a single mrkdwn text field.
+type textPayload struct {
+	Text string `json:"text"`
+}
+
+// TextPayload wraps plain text in an incoming webhook JSON payload.
+func TextPayload(text string) ([]byte, error) {
+	return json.Marshal(textPayload{Text: text})
+}
+
+// PostWebhook sends a JSON payload to a Slack incoming webhook URL. Responses
+// outside the 2xx range are returned as errors that include the response body,
+// which Slack uses to explain what was wrong with the payload.
+func PostWebhook(webhookURL string, payload []byte) error {
+	req, err := http.NewRequest("POST", webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("Slack webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
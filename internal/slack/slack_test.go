@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostWebhook(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Unexpected Content-Type %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if strings.Contains(gotBody, "bad") {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, "invalid_blocks")
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	payload, err := TextPayload("Work Report")
+	if err != nil {
+		t.Fatalf("TextPayload returned error: %v", err)
+	}
+	if err := PostWebhook(server.URL, payload); err != nil {
+		t.Fatalf("PostWebhook returned error: %v", err)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(gotBody), &decoded); err != nil || decoded["text"] != "Work Report" {
+		t.Errorf("Unexpected request body %q", gotBody)
+	}
+
+	err = PostWebhook(server.URL, []byte(`{"text": "bad"}`))
+	if err == nil {
+		t.Fatal("Expected an error for a non-2xx response")
+	}
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("Expected the status and response body in the error, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+// Package workerpool runs keyed fetches concurrently with a bounded number of
+// workers.
+package workerpool
+
+import "sync"
+
+// Map calls fetch for each key using at most workers goroutines, and returns
+// the results keyed by key. A worker count below one is treated as one. Keys
+// are handed out in the order given, and the result does not depend on the
+// order in which fetches complete.
+func Map[T any](keys []string, workers int, fetch func(key string) T) map[string]T {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(keys) {
+		workers = len(keys)
+	}
+
+	results := make(map[string]T, len(keys))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				result := fetch(key)
+				mu.Lock()
+				results[key] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, key := range keys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
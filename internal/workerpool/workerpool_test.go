@@ -0,0 +1,39 @@
+package workerpool
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	for _, workers := range []int{0, 1, 2, 10} {
+		var running, peak atomic.Int32
+		got := Map(keys, workers, func(key string) string {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			return strings.ToUpper(key)
+		})
+
+		want := map[string]string{"a": "A", "b": "B", "c": "C", "d": "D", "e": "E"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Map with %d workers = %v, want %v", workers, got, want)
+		}
+		if limit := int32(max(workers, 1)); peak.Load() > limit {
+			t.Errorf("Map with %d workers ran %d fetches at once", workers, peak.Load())
+		}
+	}
+
+	if got := Map(nil, 3, func(key string) int { return 1 }); len(got) != 0 {
+		t.Errorf("Map with no keys = %v, want empty", got)
+	}
+}